@@ -0,0 +1,54 @@
+package accounts
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	activeMu      sync.RWMutex
+	activeAccount string
+)
+
+// SetActiveAccount sets the server-wide account used when a request omits account_id.
+// An empty value clears it. The active account is kept in memory only.
+func SetActiveAccount(accountID string) (string, error) {
+	trimmed := strings.TrimSpace(accountID)
+	if trimmed != "" {
+		resolved, err := ResolveAccountID(trimmed)
+		if err != nil {
+			return "", err
+		}
+		trimmed = resolved
+	}
+
+	activeMu.Lock()
+	activeAccount = trimmed
+	activeMu.Unlock()
+
+	return trimmed, nil
+}
+
+// ActiveAccount returns the current server-wide account, or an empty string when none is set.
+func ActiveAccount() string {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return activeAccount
+}
+
+// ResolveAccountIDOrActive resolves accountID, falling back to the active account when it is empty,
+// then to the default account when a single-account cookie file is configured.
+// Returns ErrMissingAccountID when none is available.
+func ResolveAccountIDOrActive(accountID string) (string, error) {
+	trimmed := strings.TrimSpace(accountID)
+	if trimmed == "" {
+		trimmed = ActiveAccount()
+	}
+	if trimmed == "" && CookieFileOverride() != "" {
+		trimmed = defaultAccountID
+	}
+	if trimmed == "" {
+		return "", ErrMissingAccountID
+	}
+	return ResolveAccountID(trimmed)
+}
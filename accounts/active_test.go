@@ -0,0 +1,67 @@
+package accounts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAccountIDOrActive(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("XHS_MCP_DATA_DIR", dataDir)
+	t.Setenv(EnvCookiesFile, "")
+	t.Cleanup(func() { _, _ = SetActiveAccount("") })
+
+	_, err := ResolveAccountIDOrActive("")
+	assert.ErrorIs(t, err, ErrMissingAccountID)
+
+	_, err = SetActiveAccount("brand_a")
+	require.NoError(t, err)
+	id, err := ResolveAccountIDOrActive(" ")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_a", id)
+
+	id, err = ResolveAccountIDOrActive("brand_b")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_b", id)
+
+	// 单账号 cookies 文件只在没有活跃账号时兜底，并且只作用于默认账号
+	_, err = SetActiveAccount("")
+	require.NoError(t, err)
+	cookieFile := filepath.Join(t.TempDir(), "legacy", "cookies.json")
+	t.Setenv(EnvCookiesFile, cookieFile)
+
+	id, err = ResolveAccountIDOrActive("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAccountID(), id)
+
+	path, err := CookiesPath(id)
+	require.NoError(t, err)
+	assert.Equal(t, cookieFile, path)
+
+	path, err = CookiesPath("brand_a")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dataDir, "accounts", "brand_a", "cookies.json"), path)
+}
+
+func TestSetCookieFile(t *testing.T) {
+	t.Setenv("XHS_MCP_DATA_DIR", t.TempDir())
+	envFile := filepath.Join(t.TempDir(), "env", "cookies.json")
+	t.Setenv(EnvCookiesFile, envFile)
+
+	// SetCookieFile 优先于环境变量，清空后回退到环境变量
+	file := filepath.Join(t.TempDir(), "option", "cookies.json")
+	SetCookieFile(file)
+	t.Cleanup(func() { SetCookieFile("") })
+
+	path, err := CookiesPath(DefaultAccountID())
+	require.NoError(t, err)
+	assert.Equal(t, file, path)
+
+	SetCookieFile("")
+	path, err = CookiesPath(DefaultAccountID())
+	require.NoError(t, err)
+	assert.Equal(t, envFile, path)
+}
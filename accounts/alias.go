@@ -0,0 +1,170 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	aliasesFileName = "aliases.json"
+	maxAliasLength  = 64
+)
+
+// ErrAliasNotFound 别名不存在
+var ErrAliasNotFound = errors.New("account alias not found")
+
+// aliasMu 保护 aliases.json 的读写
+var aliasMu sync.Mutex
+
+// aliasesPath returns the file that maps aliases to account IDs, shared by all accounts.
+func aliasesPath() (string, error) {
+	dir, err := baseDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, aliasesFileName), nil
+}
+
+// loadAliases 读取别名映射，文件不存在时返回空映射。调用方需持有 aliasMu
+func loadAliases() (map[string]string, error) {
+	path, err := aliasesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := map[string]string{}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// saveAliases 写入别名映射。调用方需持有 aliasMu
+func saveAliases(aliases map[string]string) error {
+	path, err := aliasesPath()
+	if err != nil {
+		return err
+	}
+	buf, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0o644)
+}
+
+// lookupAlias 返回别名对应的账号 ID
+func lookupAlias(alias string) (string, bool, error) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+
+	aliases, err := loadAliases()
+	if err != nil {
+		return "", false, err
+	}
+	id, ok := aliases[alias]
+	return id, ok, nil
+}
+
+// accountExists reports whether an account directory already exists, without creating it.
+func accountExists(accountID string) bool {
+	if !accountIDPattern.MatchString(accountID) {
+		return false
+	}
+	root, err := accountsRootDir()
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(root, accountID))
+	return err == nil && info.IsDir()
+}
+
+// AccountAliases 返回所有别名到账号 ID 的映射
+func AccountAliases() (map[string]string, error) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+	return loadAliases()
+}
+
+// SetAccountAlias 为账号设置别名（如“我的品牌号”），之后传入别名的地方都会解析为该账号。
+// 别名已存在时改为指向新账号；别名不能与已有账号 ID 或默认账号重名。返回别名指向的账号 ID
+func SetAccountAlias(alias, accountID string) (string, error) {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return "", errors.New("alias is required")
+	}
+	if utf8.RuneCountInString(alias) > maxAliasLength {
+		return "", fmt.Errorf("alias must be at most %d characters", maxAliasLength)
+	}
+	if alias == defaultAccountID || accountExists(alias) {
+		return "", fmt.Errorf("alias %q conflicts with an existing account id", alias)
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return "", ErrMissingAccountID
+	}
+
+	id, err := ResolveAccountID(accountID)
+	if err != nil {
+		return "", err
+	}
+	if err := EnsureAccount(id); err != nil {
+		return "", err
+	}
+
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+
+	aliases, err := loadAliases()
+	if err != nil {
+		return "", err
+	}
+	aliases[alias] = id
+	if err := saveAliases(aliases); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// RemoveAccountAlias 删除别名，别名不存在时返回 ErrAliasNotFound
+func RemoveAccountAlias(alias string) error {
+	alias = strings.TrimSpace(alias)
+
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+
+	aliases, err := loadAliases()
+	if err != nil {
+		return err
+	}
+	if _, ok := aliases[alias]; !ok {
+		return fmt.Errorf("%w: %s", ErrAliasNotFound, alias)
+	}
+	delete(aliases, alias)
+	return saveAliases(aliases)
+}
+
+// aliasesByAccount 按账号 ID 分组返回别名，每组按名称排序
+func aliasesByAccount(aliases map[string]string) map[string][]string {
+	grouped := make(map[string][]string)
+	for alias, id := range aliases {
+		grouped[id] = append(grouped[id], alias)
+	}
+	for _, list := range grouped {
+		sort.Strings(list)
+	}
+	return grouped
+}
@@ -0,0 +1,65 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountAlias(t *testing.T) {
+	t.Setenv("XHS_MCP_DATA_DIR", t.TempDir())
+	t.Setenv(EnvCookiesFile, "")
+
+	id, err := SetAccountAlias(" 我的品牌号 ", "brand_a")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_a", id)
+
+	id, err = ResolveAccountID("我的品牌号")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_a", id)
+
+	// 直接使用账号 ID 不受影响
+	id, err = ResolveAccountID("brand_b")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_b", id)
+
+	// 别名不能与已有账号 ID 重名
+	_, err = SetAccountAlias("brand_a", "brand_b")
+	assert.Error(t, err)
+	_, err = SetAccountAlias(DefaultAccountID(), "brand_b")
+	assert.Error(t, err)
+	_, err = SetAccountAlias(" ", "brand_b")
+	assert.Error(t, err)
+
+	infos, err := ListAccounts()
+	require.NoError(t, err)
+	for _, info := range infos {
+		if info.ID == "brand_a" {
+			assert.Equal(t, []string{"我的品牌号"}, info.Aliases)
+		}
+	}
+
+	require.NoError(t, RemoveAccountAlias("我的品牌号"))
+	assert.ErrorIs(t, RemoveAccountAlias("我的品牌号"), ErrAliasNotFound)
+
+	_, err = ResolveAccountID("我的品牌号")
+	assert.Error(t, err)
+}
+
+func TestResolveAccountIDPrefersExistingAccount(t *testing.T) {
+	t.Setenv("XHS_MCP_DATA_DIR", t.TempDir())
+
+	_, err := SetAccountAlias("brand_c", "brand_a")
+	require.NoError(t, err)
+
+	id, err := ResolveAccountID("brand_c")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_a", id)
+
+	// 之后创建了同名账号时，直接使用账号本身
+	require.NoError(t, EnsureAccount("brand_c"))
+	id, err = ResolveAccountID("brand_c")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_c", id)
+}
@@ -0,0 +1,41 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DisplayName 返回用于日志展示的账号名称：设置了备注时为 "ID (备注)"，否则为账号 ID。
+// 只读取已有的账号元数据，不会因为记录日志而创建账号目录。
+func DisplayName(accountID string) string {
+	id := strings.TrimSpace(accountID)
+	if id == "" || !accountIDPattern.MatchString(id) {
+		return accountID
+	}
+
+	if remark := readRemark(id); remark != "" {
+		return fmt.Sprintf("%s (%s)", id, remark)
+	}
+	return id
+}
+
+func readRemark(accountID string) string {
+	baseDir, err := baseDataDir()
+	if err != nil {
+		return ""
+	}
+
+	data, err := os.ReadFile(filepath.Join(baseDir, dataDirName, accountID, metaFileName))
+	if err != nil {
+		return ""
+	}
+
+	var meta AccountMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(meta.Remark)
+}
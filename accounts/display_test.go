@@ -0,0 +1,30 @@
+package accounts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayName(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("XHS_MCP_DATA_DIR", dataDir)
+
+	assert.Equal(t, "", DisplayName(""))
+	assert.Equal(t, "brand_a", DisplayName("brand_a"))
+	_, err := os.Stat(filepath.Join(dataDir, dataDirName, "brand_a"))
+	assert.True(t, os.IsNotExist(err), "display name must not create account dir")
+
+	_, err = SetAccountRemark("brand_a", " 时尚品牌 ")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_a (时尚品牌)", DisplayName("brand_a"))
+
+	_, err = SetAccountRemark("brand_a", "")
+	require.NoError(t, err)
+	assert.Equal(t, "brand_a", DisplayName("brand_a"))
+
+	assert.Equal(t, "../evil", DisplayName("../evil"))
+}
@@ -0,0 +1,66 @@
+package accounts
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ClearImages 删除账号图片缓存目录下的所有内容，返回释放的字节数。
+// 只处理经过校验的账号 images 目录，符号链接只删除链接本身。
+func ClearImages(accountID string) (int64, error) {
+	id, err := ResolveAccountID(accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	dir, err := ImagesDir(id)
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read images dir %s: %w", dir, err)
+	}
+
+	var freed int64
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		size, err := diskUsage(path)
+		if err != nil {
+			return freed, err
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return freed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		freed += size
+	}
+
+	return freed, nil
+}
+
+// diskUsage 统计 path 下普通文件的总大小，不跟随符号链接
+func diskUsage(path string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return total, nil
+}
@@ -0,0 +1,38 @@
+package accounts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearImages(t *testing.T) {
+	t.Setenv("XHS_MCP_DATA_DIR", t.TempDir())
+
+	dir, err := ImagesDir("brand_a")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.jpg"), make([]byte, 100), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.png"), make([]byte, 50), 0o644))
+
+	// 指向目录外的符号链接只删除链接，不影响目标文件
+	outside := filepath.Join(t.TempDir(), "keep.jpg")
+	require.NoError(t, os.WriteFile(outside, make([]byte, 10), 0o644))
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, "link.jpg")))
+
+	freed, err := ClearImages("brand_a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), freed)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.FileExists(t, outside)
+
+	_, err = ClearImages("../brand_a")
+	assert.Error(t, err)
+}
@@ -9,28 +9,53 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
+// EnvCookiesFile 单账号模式下直接使用的 cookies 文件路径，兼容旧版本的单账号部署
+const EnvCookiesFile = "XHS_MCP_COOKIES_FILE"
+
 const (
 	defaultAccountID = "default"
 	cookiesFileName  = "cookies.json"
 	imagesDirName    = "images"
+	exportsDirName   = "exports"
+	chromeDirName    = "chrome"
 	dataDirName      = "accounts"
 	metaFileName     = "meta.json"
+
+	idempotencyFileName = "idempotency.json"
+	jobsFileName        = "jobs.json"
 )
 
 type AccountMeta struct {
-	Remark    string    `json:"remark"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Remark         string          `json:"remark"`
+	Proxy          string          `json:"proxy,omitempty"`
+	SearchDefaults *SearchDefaults `json:"search_defaults,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
 }
 
 type AccountInfo struct {
-	ID        string    `json:"id"`
-	Remark    string    `json:"remark"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string          `json:"id"`
+	Remark         string          `json:"remark"`
+	Proxy          string          `json:"proxy,omitempty"` // 密码已脱敏
+	SearchDefaults *SearchDefaults `json:"search_defaults,omitempty"`
+	Aliases        []string        `json:"aliases,omitempty"` // 指向该账号的别名，仅账号列表返回
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func newAccountInfo(id string, meta *AccountMeta) *AccountInfo {
+	return &AccountInfo{
+		ID:             id,
+		Remark:         meta.Remark,
+		Proxy:          RedactProxyURL(meta.Proxy),
+		SearchDefaults: meta.SearchDefaults,
+		CreatedAt:      meta.CreatedAt,
+		UpdatedAt:      meta.UpdatedAt,
+	}
 }
 
 var accountIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
@@ -160,27 +185,68 @@ func normalizeAccountMeta(meta AccountMeta) AccountMeta {
 
 func saveAccountMeta(path string, meta *AccountMeta) error {
 	meta = &AccountMeta{
-		Remark:    strings.TrimSpace(meta.Remark),
-		CreatedAt: meta.CreatedAt,
-		UpdatedAt: meta.UpdatedAt,
+		Remark:         strings.TrimSpace(meta.Remark),
+		Proxy:          strings.TrimSpace(meta.Proxy),
+		SearchDefaults: meta.SearchDefaults.normalize(),
+		CreatedAt:      meta.CreatedAt,
+		UpdatedAt:      meta.UpdatedAt,
 	}
 	buf, err := json.MarshalIndent(meta, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, buf, 0o644)
+	// meta.json 中的代理地址可能包含账号密码，只允许当前用户读写；
+	// WriteFile 不会修改已存在文件的权限，因此之前以 0644 写入的文件需要单独收紧
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0o600)
 }
 
 // CookiesPath returns the cookies file path for the given account, ensuring directories exist.
+// When a single-account cookie file is configured (SetCookieFile or XHS_MCP_COOKIES_FILE),
+// the default account uses that file instead of its account directory.
 func CookiesPath(accountID string) (string, error) {
 	dir, err := accountDir(accountID)
 	if err != nil {
 		return "", err
 	}
 
+	if file := CookieFileOverride(); file != "" && IsDefaultAccount(accountID) {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return "", fmt.Errorf("failed to ensure cookies dir for %s: %w", file, err)
+		}
+		return file, nil
+	}
+
 	return filepath.Join(dir, cookiesFileName), nil
 }
 
+var (
+	cookieFileMu sync.RWMutex
+	cookieFile   string
+)
+
+// SetCookieFile sets the single-account cookie file used by the default account; an empty path
+// falls back to XHS_MCP_COOKIES_FILE. Accounts other than the default keep their own cookies.
+func SetCookieFile(path string) {
+	cookieFileMu.Lock()
+	defer cookieFileMu.Unlock()
+	cookieFile = strings.TrimSpace(path)
+}
+
+// CookieFileOverride returns the single-account cookie file set via SetCookieFile,
+// or the one configured via XHS_MCP_COOKIES_FILE, if any.
+func CookieFileOverride() string {
+	cookieFileMu.RLock()
+	file := cookieFile
+	cookieFileMu.RUnlock()
+	if file != "" {
+		return file
+	}
+	return strings.TrimSpace(os.Getenv(EnvCookiesFile))
+}
+
 // ImagesDir returns the per-account directory for downloaded images, ensuring it exists.
 func ImagesDir(accountID string) (string, error) {
 	dir, err := accountDir(accountID)
@@ -196,6 +262,56 @@ func ImagesDir(accountID string) (string, error) {
 	return imagesDir, nil
 }
 
+// ExportsDir returns the per-account directory for exported files, ensuring it exists.
+func ExportsDir(accountID string) (string, error) {
+	dir, err := accountDir(accountID)
+	if err != nil {
+		return "", err
+	}
+
+	exportsDir := filepath.Join(dir, exportsDirName)
+	if err := os.MkdirAll(exportsDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to ensure exports dir %s: %w", exportsDir, err)
+	}
+
+	return exportsDir, nil
+}
+
+// ChromeProfileDir returns the per-account Chrome user-data-dir, ensuring it exists.
+func ChromeProfileDir(accountID string) (string, error) {
+	dir, err := accountDir(accountID)
+	if err != nil {
+		return "", err
+	}
+
+	profileDir := filepath.Join(dir, chromeDirName)
+	if err := os.MkdirAll(profileDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to ensure chrome profile dir %s: %w", profileDir, err)
+	}
+
+	return profileDir, nil
+}
+
+// IdempotencyPath returns the file used to persist publish idempotency records for the account.
+func IdempotencyPath(accountID string) (string, error) {
+	dir, err := accountDir(accountID)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, idempotencyFileName), nil
+}
+
+// JobsPath returns the file used to persist async job state, shared by all accounts.
+func JobsPath() (string, error) {
+	dir, err := baseDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, jobsFileName), nil
+}
+
 // ValidateAccountID checks whether an account identifier is acceptable without creating resources.
 func ValidateAccountID(accountID string) error {
 	_, err := sanitizeAccountID(accountID)
@@ -225,7 +341,20 @@ func DefaultAccountID() string {
 }
 
 // ResolveAccountID sanitizes the provided ID and returns the resolved version used internally.
+// Values that are not an existing account ID are looked up in the alias map first,
+// so an existing account always wins over an alias with the same name.
 func ResolveAccountID(accountID string) (string, error) {
+	trimmed := strings.TrimSpace(accountID)
+	if trimmed != "" && !accountExists(trimmed) {
+		id, ok, err := lookupAlias(trimmed)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return id, nil
+		}
+	}
+
 	id, err := sanitizeAccountID(accountID)
 	if err != nil {
 		return "", err
@@ -267,12 +396,7 @@ func ListAccounts() ([]AccountInfo, error) {
 		if err != nil {
 			return nil, err
 		}
-		infos = append(infos, AccountInfo{
-			ID:        id,
-			Remark:    meta.Remark,
-			CreatedAt: meta.CreatedAt,
-			UpdatedAt: meta.UpdatedAt,
-		})
+		infos = append(infos, *newAccountInfo(id, meta))
 	}
 
 	// ensure default account present even if dir missing
@@ -284,12 +408,16 @@ func ListAccounts() ([]AccountInfo, error) {
 		if err != nil {
 			return nil, err
 		}
-		infos = append(infos, AccountInfo{
-			ID:        defaultAccountID,
-			Remark:    meta.Remark,
-			CreatedAt: meta.CreatedAt,
-			UpdatedAt: meta.UpdatedAt,
-		})
+		infos = append(infos, *newAccountInfo(defaultAccountID, meta))
+	}
+
+	aliases, err := AccountAliases()
+	if err != nil {
+		return nil, err
+	}
+	grouped := aliasesByAccount(aliases)
+	for i := range infos {
+		infos[i].Aliases = grouped[infos[i].ID]
 	}
 
 	sort.Slice(infos, func(i, j int) bool {
@@ -331,10 +459,5 @@ func SetAccountRemark(accountID, remark string) (*AccountInfo, error) {
 		return nil, err
 	}
 
-	return &AccountInfo{
-		ID:        id,
-		Remark:    meta.Remark,
-		CreatedAt: meta.CreatedAt,
-		UpdatedAt: meta.UpdatedAt,
-	}, nil
+	return newAccountInfo(id, meta), nil
 }
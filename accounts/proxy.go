@@ -0,0 +1,94 @@
+package accounts
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ValidateProxyURL 校验代理地址，支持 http/https/socks5，必须包含主机和端口。
+// socks5 代理不支持账号密码（Chrome 限制）。返回规范化后的地址。
+func ValidateProxyURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("invalid proxy url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return "", fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return "", fmt.Errorf("proxy url must include host and port: %s", RedactProxyURL(trimmed))
+	}
+	if u.Scheme == "socks5" && u.User != nil {
+		return "", fmt.Errorf("socks5 proxy with credentials is not supported")
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		return "", fmt.Errorf("proxy url must not include path or query: %s", RedactProxyURL(trimmed))
+	}
+
+	return (&url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host}).String(), nil
+}
+
+// RedactProxyURL 隐藏代理地址中的密码，用于展示和日志
+func RedactProxyURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Redacted()
+}
+
+// AccountProxy 返回账号配置的代理地址，未配置时返回空字符串
+func AccountProxy(accountID string) (string, error) {
+	meta, err := ensureMeta(accountID)
+	if err != nil {
+		return "", err
+	}
+	return meta.Proxy, nil
+}
+
+// SetAccountProxy 设置账号使用的代理，传空字符串清除
+func SetAccountProxy(accountID, proxyURL string) (*AccountInfo, error) {
+	id, err := ResolveAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := strings.TrimSpace(proxyURL)
+	if proxy != "" {
+		if proxy, err = ValidateProxyURL(proxy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := EnsureAccount(id); err != nil {
+		return nil, err
+	}
+
+	path, err := metaPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := ensureMeta(id)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Proxy = proxy
+	meta.UpdatedAt = time.Now()
+
+	if err := saveAccountMeta(path, meta); err != nil {
+		return nil, err
+	}
+
+	return newAccountInfo(id, meta), nil
+}
@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"strings"
+	"time"
+)
+
+// SearchDefaults 账号默认的搜索筛选项，请求未指定对应筛选项时使用。
+// 取值与搜索接口的筛选参数一致，由调用方负责校验。
+type SearchDefaults struct {
+	Sort        string `json:"sort,omitempty"`
+	NoteType    string `json:"note_type,omitempty"`
+	PublishTime string `json:"publish_time,omitempty"`
+	SearchScope string `json:"search_scope,omitempty"`
+	Distance    string `json:"distance,omitempty"`
+}
+
+// normalize 去除首尾空白，全部为空时返回 nil
+func (d *SearchDefaults) normalize() *SearchDefaults {
+	if d == nil {
+		return nil
+	}
+
+	n := &SearchDefaults{
+		Sort:        strings.TrimSpace(d.Sort),
+		NoteType:    strings.TrimSpace(d.NoteType),
+		PublishTime: strings.TrimSpace(d.PublishTime),
+		SearchScope: strings.TrimSpace(d.SearchScope),
+		Distance:    strings.TrimSpace(d.Distance),
+	}
+	if *n == (SearchDefaults{}) {
+		return nil
+	}
+	return n
+}
+
+// AccountSearchDefaults 返回账号的默认搜索筛选项，未配置时返回零值
+func AccountSearchDefaults(accountID string) (SearchDefaults, error) {
+	meta, err := ensureMeta(accountID)
+	if err != nil {
+		return SearchDefaults{}, err
+	}
+	if meta.SearchDefaults == nil {
+		return SearchDefaults{}, nil
+	}
+	return *meta.SearchDefaults, nil
+}
+
+// SetAccountSearchDefaults 设置账号默认的搜索筛选项，全部为空时清除
+func SetAccountSearchDefaults(accountID string, defaults SearchDefaults) (*AccountInfo, error) {
+	id, err := ResolveAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := EnsureAccount(id); err != nil {
+		return nil, err
+	}
+
+	path, err := metaPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := ensureMeta(id)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.SearchDefaults = defaults.normalize()
+	meta.UpdatedAt = time.Now()
+
+	if err := saveAccountMeta(path, meta); err != nil {
+		return nil, err
+	}
+
+	return newAccountInfo(id, meta), nil
+}
@@ -0,0 +1,28 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAccountSearchDefaults(t *testing.T) {
+	t.Setenv("XHS_MCP_DATA_DIR", t.TempDir())
+
+	defaults, err := AccountSearchDefaults("brand_a")
+	require.NoError(t, err)
+	assert.Equal(t, SearchDefaults{}, defaults)
+
+	info, err := SetAccountSearchDefaults("brand_a", SearchDefaults{Sort: " latest ", NoteType: "video"})
+	require.NoError(t, err)
+	assert.Equal(t, &SearchDefaults{Sort: "latest", NoteType: "video"}, info.SearchDefaults)
+
+	defaults, err = AccountSearchDefaults("brand_a")
+	require.NoError(t, err)
+	assert.Equal(t, SearchDefaults{Sort: "latest", NoteType: "video"}, defaults)
+
+	info, err = SetAccountSearchDefaults("brand_a", SearchDefaults{})
+	require.NoError(t, err)
+	assert.Nil(t, info.SearchDefaults)
+}
@@ -10,11 +10,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
 // AppServer 应用服务器结构体，封装所有服务和处理器
 type AppServer struct {
 	xiaohongshuService *XiaohongshuService
+	jobs               *jobManager
 	router             *gin.Engine
 	httpServer         *http.Server
 }
@@ -23,6 +25,7 @@ type AppServer struct {
 func NewAppServer(xiaohongshuService *XiaohongshuService) *AppServer {
 	return &AppServer{
 		xiaohongshuService: xiaohongshuService,
+		jobs:               newJobManager(),
 	}
 }
 
@@ -37,8 +40,16 @@ func (s *AppServer) Start(port string) error {
 
 	// 启动服务器的 goroutine
 	go func() {
-		logrus.Infof("启动 HTTP 服务器: %s", port)
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if configs.IsTLSEnabled() {
+			certFile, keyFile := configs.GetTLSFiles()
+			logrus.Infof("启动 HTTPS 服务器: %s", port)
+			err = s.httpServer.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			logrus.Infof("启动 HTTP 服务器: %s", port)
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logrus.Errorf("服务器启动失败: %v", err)
 			os.Exit(1)
 		}
@@ -1,17 +1,42 @@
 package browser
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"github.com/go-rod/rod/lib/proto"
 	"github.com/sirupsen/logrus"
-	"github.com/xpzouying/headless_browser"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
 )
 
+const defaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+
+const (
+	// DefaultLocale 浏览器默认语言，决定 Accept-Language、navigator.language 及日期格式
+	DefaultLocale = "zh-CN"
+	// DefaultTimezone 浏览器默认时区，与站点展示时间一致，避免无头环境使用 UTC 导致相对时间偏差
+	DefaultTimezone = "Asia/Shanghai"
+)
+
 type browserConfig struct {
 	binPath     string
 	cookiesPath string
+	device      string
+	userDataDir string
+	proxy       string
+	locale      string
+	timezone    string
+	onClose     func()
+
+	disableStealth bool
 }
 
 type Option func(*browserConfig)
@@ -28,17 +53,142 @@ func WithCookiesPath(path string) Option {
 	}
 }
 
-func NewBrowser(headless bool, options ...Option) *headless_browser.Browser {
+// WithUserDataDir 使用指定目录作为 Chrome 的 user-data-dir，关闭浏览器后目录保留。
+// 为空时使用临时目录，关闭后删除。
+func WithUserDataDir(dir string) Option {
+	return func(c *browserConfig) {
+		c.userDataDir = dir
+	}
+}
+
+// WithProxy 使用代理启动浏览器，格式 scheme://[user:pass@]host:port，为空表示直连。
+// 代理需要认证时自动应答认证请求。
+func WithProxy(proxyURL string) Option {
+	return func(c *browserConfig) {
+		c.proxy = proxyURL
+	}
+}
+
+// WithLocale 设置浏览器语言（如 zh-CN），影响 Accept-Language 请求头和页面的语言及日期格式。
+// 为空时使用 DefaultLocale。
+func WithLocale(lang string) Option {
+	return func(c *browserConfig) {
+		c.locale = lang
+	}
+}
+
+// WithTimezone 设置页面时区（IANA 名称，如 Asia/Shanghai），为空时使用 DefaultTimezone。
+func WithTimezone(tz string) Option {
+	return func(c *browserConfig) {
+		c.timezone = tz
+	}
+}
+
+// WithOnClose 浏览器关闭后调用 fn，例如归还并发名额。
+func WithOnClose(fn func()) Option {
+	return func(c *browserConfig) {
+		c.onClose = fn
+	}
+}
+
+// Browser 带 stealth 模式的浏览器实例
+type Browser struct {
+	browser     *rod.Browser
+	launcher    *launcher.Launcher
+	profile     *profileLock
+	onClose     func()
+	cookiesPath string
+	locale      string
+	timezone    string
+	stealth     bool
+}
+
+// NewBrowser 启动浏览器，失败时 panic，供测试等无需处理错误的场景使用
+func NewBrowser(headless bool, options ...Option) *Browser {
+	b, err := Launch(context.Background(), headless, options...)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Launch 启动浏览器。使用账号目录（WithUserDataDir）时先独占该目录，ctx 结束或等待超时时
+// 返回 ErrProfileBusy；启动或连接失败时释放目录并返回错误
+func Launch(ctx context.Context, headless bool, options ...Option) (*Browser, error) {
 	cfg := &browserConfig{}
 	for _, opt := range options {
 		opt(cfg)
 	}
+	if cfg.locale == "" {
+		cfg.locale = DefaultLocale
+	}
+	if cfg.timezone == "" {
+		cfg.timezone = DefaultTimezone
+	}
 
-	opts := []headless_browser.Option{
-		headless_browser.WithHeadless(headless),
+	userAgent := defaultUserAgent
+	if cfg.device != "" {
+		if device, err := LookupDevice(cfg.device); err != nil {
+			logrus.Warnf("ignore device emulation: %v", err)
+		} else {
+			userAgent = device.UserAgent
+		}
 	}
+
+	l := launcher.New().
+		Headless(headless).
+		Set("--no-sandbox").
+		Set("user-agent", userAgent).
+		Set("lang", cfg.locale).
+		Set("accept-lang", cfg.locale)
 	if cfg.binPath != "" {
-		opts = append(opts, headless_browser.WithChromeBinPath(cfg.binPath))
+		l = l.Bin(cfg.binPath)
+	}
+	if !cfg.disableStealth {
+		// enable-automation 会显示“受自动测试软件控制”并让 navigator.webdriver 为 true
+		l = l.Delete("enable-automation").
+			Set("disable-blink-features", "AutomationControlled")
+	}
+
+	var proxyUser *url.Userinfo
+	if cfg.proxy != "" {
+		if u, err := url.Parse(cfg.proxy); err != nil {
+			logrus.Warnf("ignore invalid proxy: %v", err)
+		} else {
+			proxyUser = u.User
+			l = l.Proxy((&url.URL{Scheme: u.Scheme, Host: u.Host}).String())
+		}
+	}
+
+	var profile *profileLock
+	if cfg.userDataDir != "" {
+		var err error
+		if profile, err = acquireProfile(ctx, cfg.userDataDir); err != nil {
+			return nil, err
+		}
+		l = l.UserDataDir(cfg.userDataDir)
+	}
+
+	controlURL, err := l.Context(ctx).Launch()
+	if err != nil {
+		cleanupLaunch(l, profile)
+		return nil, fmt.Errorf("failed to launch browser: %w", err)
+	}
+
+	b := rod.New().ControlURL(controlURL)
+	if err := b.Connect(); err != nil {
+		cleanupLaunch(l, profile)
+		return nil, fmt.Errorf("failed to connect browser: %w", err)
+	}
+
+	if proxyUser != nil {
+		password, _ := proxyUser.Password()
+		// Chrome 会缓存认证结果，只需应答首次认证请求；浏览器关闭时随之退出
+		go func() {
+			if err := b.HandleAuth(proxyUser.Username(), password)(); err != nil {
+				logrus.Debugf("proxy auth handler stopped: %v", err)
+			}
+		}()
 	}
 
 	// 加载 cookies
@@ -53,7 +203,7 @@ func NewBrowser(headless bool, options ...Option) *headless_browser.Browser {
 		} else if _, err := os.Stat(cookiePath); err == nil {
 			cookieLoader := cookies.NewLoadCookie(cookiePath)
 			if data, loadErr := cookieLoader.LoadCookies(); loadErr == nil {
-				opts = append(opts, headless_browser.WithCookies(string(data)))
+				setCookies(b, data)
 				logrus.Debugf("loaded cookies from file: %s", cookiePath)
 			} else {
 				logrus.Warnf("failed to load cookies from %s: %v", cookiePath, loadErr)
@@ -63,7 +213,110 @@ func NewBrowser(headless bool, options ...Option) *headless_browser.Browser {
 		}
 	}
 
-	return headless_browser.New(opts...)
+	return &Browser{
+		browser:     b,
+		launcher:    l,
+		profile:     profile,
+		onClose:     cfg.onClose,
+		cookiesPath: cookiePath,
+		locale:      cfg.locale,
+		timezone:    cfg.timezone,
+		stealth:     !cfg.disableStealth,
+	}, nil
+}
+
+// cleanupLaunch 启动失败时结束可能已启动的 Chrome，删除临时目录或释放账号目录
+func cleanupLaunch(l *launcher.Launcher, profile *profileLock) {
+	started := l.PID() != 0
+	if started {
+		l.Kill()
+	}
+
+	switch {
+	case profile != nil:
+		profile.release()
+	case started:
+		l.Cleanup()
+	default:
+		// Chrome 未启动时 Cleanup 会一直等待进程退出，直接删除临时目录
+		_ = os.RemoveAll(l.Get(flags.UserDataDir))
+	}
+}
+
+// ReloadCookies 重新从 cookies 文件加载 cookies 到浏览器，
+// 用于其他进程（如重新登录）刷新了 cookies 文件后恢复长时间运行的浏览器的登录状态。
+func (b *Browser) ReloadCookies() error {
+	if b.cookiesPath == "" {
+		return errors.New("no cookies file configured")
+	}
+
+	data, err := cookies.NewLoadCookie(b.cookiesPath).LoadCookies()
+	if err != nil {
+		return fmt.Errorf("failed to load cookies from %s: %w", b.cookiesPath, err)
+	}
+
+	var cks []*proto.NetworkCookie
+	if err := json.Unmarshal(data, &cks); err != nil {
+		return fmt.Errorf("failed to unmarshal cookies: %w", err)
+	}
+	if len(cks) == 0 {
+		return fmt.Errorf("cookies file %s is empty", b.cookiesPath)
+	}
+	return b.browser.SetCookies(proto.CookiesToParams(cks))
+}
+
+// NewPage 创建页面（默认启用 stealth 模式，见 WithStealth），并应用浏览器的语言和时区
+func (b *Browser) NewPage() *rod.Page {
+	var page *rod.Page
+	if b.stealth {
+		page = b.newStealthPage()
+	} else {
+		page = b.browser.MustPage()
+	}
+	b.emulateLocale(page)
+	return page
+}
+
+// emulateLocale 通过 CDP 覆盖页面的语言和时区，失败时只记录日志，页面仍可使用
+func (b *Browser) emulateLocale(page *rod.Page) {
+	if err := (proto.EmulationSetLocaleOverride{Locale: b.locale}).Call(page); err != nil {
+		logrus.Warnf("failed to set locale %s: %v", b.locale, err)
+	}
+	if err := (proto.EmulationSetTimezoneOverride{TimezoneID: b.timezone}).Call(page); err != nil {
+		logrus.Warnf("failed to set timezone %s: %v", b.timezone, err)
+	}
+}
+
+// Close 关闭浏览器。使用临时 user-data-dir 时一并删除，使用账号目录时保留并释放目录锁。
+func (b *Browser) Close() {
+	if err := b.browser.Close(); err != nil {
+		logrus.Warnf("failed to close browser: %v", err)
+	}
+
+	if b.profile == nil {
+		b.launcher.Cleanup()
+	} else {
+		b.profile.release()
+	}
+
+	if b.onClose != nil {
+		b.onClose()
+	}
+}
+
+func setCookies(b *rod.Browser, data []byte) {
+	var cks []*proto.NetworkCookie
+	if err := json.Unmarshal(data, &cks); err != nil {
+		logrus.Warnf("failed to unmarshal cookies: %v", err)
+		return
+	}
+	if len(cks) == 0 {
+		return
+	}
+
+	if err := b.SetCookies(proto.CookiesToParams(cks)); err != nil {
+		logrus.Warnf("failed to set cookies: %v", err)
+	}
 }
 
 func ensureCookieAvailability(path string) error {
@@ -0,0 +1,61 @@
+package browser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/devices"
+)
+
+// presetDevices 可用于移动端模拟的设备预设，键为对外暴露的设备名
+var presetDevices = map[string]devices.Device{
+	"iphone":    devices.IPhoneX,
+	"iphone_se": devices.IPhone5orSE,
+	"ipad":      devices.IPad,
+	"pixel":     devices.Pixel2,
+	"galaxy":    devices.GalaxyS5,
+}
+
+// DeviceNames 返回所有支持的设备名
+func DeviceNames() []string {
+	names := make([]string, 0, len(presetDevices))
+	for name := range presetDevices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupDevice 根据设备名查找设备预设
+func LookupDevice(name string) (devices.Device, error) {
+	device, ok := presetDevices[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return devices.Device{}, fmt.Errorf("unsupported device: %s (available: %s)",
+			name, strings.Join(DeviceNames(), ", "))
+	}
+	return device, nil
+}
+
+// WithDevice 使用指定设备的 UserAgent 启动浏览器，为空表示桌面端。
+// 视口等页面级参数需要在创建页面后调用 EmulateDevice 生效。
+func WithDevice(name string) Option {
+	return func(c *browserConfig) {
+		c.device = name
+	}
+}
+
+// EmulateDevice 对页面应用设备模拟（UserAgent、视口、触控），name 为空时保持桌面端
+func EmulateDevice(page *rod.Page, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return nil
+	}
+
+	device, err := LookupDevice(name)
+	if err != nil {
+		return err
+	}
+
+	return page.Emulate(device)
+}
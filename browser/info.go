@@ -0,0 +1,51 @@
+package browser
+
+import (
+	"sort"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Info 浏览器运行信息，用于排查选择器等与浏览器版本相关的问题
+type Info struct {
+	BinPath         string   `json:"bin_path"`
+	Product         string   `json:"product"`
+	Revision        string   `json:"revision"`
+	ProtocolVersion string   `json:"protocol_version"`
+	JSVersion       string   `json:"js_version"`
+	UserAgent       string   `json:"user_agent"`
+	Headless        bool     `json:"headless"`
+	Args            []string `json:"args"`
+}
+
+// Info 通过 CDP Browser.getVersion 获取浏览器版本，并附带启动参数
+func (b *Browser) Info() (*Info, error) {
+	version, err := proto.BrowserGetVersion{}.Call(b.browser)
+	if err != nil {
+		return nil, err
+	}
+
+	args := b.launcher.FormatArgs()
+	sort.Strings(args)
+
+	return &Info{
+		BinPath:         resolvedBinPath(b.launcher),
+		Product:         version.Product,
+		Revision:        version.Revision,
+		ProtocolVersion: version.ProtocolVersion,
+		JSVersion:       version.JsVersion,
+		UserAgent:       version.UserAgent,
+		Headless:        b.launcher.Has(flags.Headless),
+		Args:            args,
+	}, nil
+}
+
+// resolvedBinPath 返回实际使用的浏览器路径，未指定 bin 时为 rod 自动下载的浏览器路径
+func resolvedBinPath(l *launcher.Launcher) string {
+	if bin := l.Get(flags.Bin); bin != "" {
+		return bin
+	}
+	return launcher.NewBrowser().BinPath()
+}
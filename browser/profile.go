@@ -0,0 +1,167 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrProfileBusy user-data-dir 正被其他浏览器使用（本进程的其他请求，或 cmd/login 等其他进程），
+// 在等待时间内没有释放
+var ErrProfileBusy = errors.New("chrome profile is in use by another browser")
+
+// Chrome 在 user-data-dir 下创建的单实例锁文件，进程异常退出时会残留
+var chromeSingletonFiles = []string{"SingletonLock", "SingletonSocket", "SingletonCookie"}
+
+const (
+	// profileExitTimeout 关闭浏览器后等待 Chrome 释放 user-data-dir 的最长时间
+	profileExitTimeout = 5 * time.Second
+	// profileAcquireTimeout 等待同一账号的其他浏览器释放 user-data-dir 的最长时间，
+	// 避免扫码登录等长时间占用目录的操作让后续请求一直排队
+	profileAcquireTimeout = 30 * time.Second
+)
+
+var (
+	profileLocksMu sync.Mutex
+	profileLocks   = map[string]chan struct{}{}
+)
+
+// profileLock 进程内对同一个 user-data-dir 的独占锁，Chrome 不支持多个实例共用同一目录
+type profileLock struct {
+	dir  string
+	sem  chan struct{}
+	once sync.Once
+}
+
+// acquireProfile 独占 user-data-dir，并清理异常退出的 Chrome 残留的锁文件。
+// ctx 结束或等待超过 profileAcquireTimeout 仍未拿到，或目录正被其他进程的 Chrome 使用时，
+// 返回 ErrProfileBusy
+func acquireProfile(ctx context.Context, dir string) (*profileLock, error) {
+	profileLocksMu.Lock()
+	sem, ok := profileLocks[dir]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		profileLocks[dir] = sem
+	}
+	profileLocksMu.Unlock()
+
+	timer := time.NewTimer(profileAcquireTimeout)
+	defer timer.Stop()
+
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %s: %v", ErrProfileBusy, dir, ctx.Err())
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: %s: waited %s", ErrProfileBusy, dir, profileAcquireTimeout)
+	}
+	p := &profileLock{dir: dir, sem: sem}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logrus.Warnf("failed to ensure chrome profile dir %s: %v", dir, err)
+	}
+	if err := removeStaleSingletonFiles(ctx, dir); err != nil {
+		p.unlock()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// release 等待 Chrome 退出并释放目录锁，可重复调用
+func (p *profileLock) release() {
+	deadline := time.Now().Add(profileExitTimeout)
+	lockFile := filepath.Join(p.dir, chromeSingletonFiles[0])
+	for time.Now().Before(deadline) {
+		if _, err := os.Lstat(lockFile); os.IsNotExist(err) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	p.unlock()
+}
+
+// unlock 不等待 Chrome 退出，直接释放目录锁，用于浏览器未启动成功时
+func (p *profileLock) unlock() {
+	p.once.Do(func() { <-p.sem })
+}
+
+// removeStaleSingletonFiles 删除已退出的 Chrome 留下的锁文件。SingletonLock 是指向
+// "<hostname>-<pid>" 的符号链接，该进程仍在运行（如 cmd/login 正在使用同一目录）时不删除，
+// 等待其退出，超过 profileExitTimeout 仍未退出时返回 ErrProfileBusy
+func removeStaleSingletonFiles(ctx context.Context, dir string) error {
+	deadline := time.Now().Add(profileExitTimeout)
+	for {
+		host, pid, ok := singletonOwner(dir)
+		if !ok || !ownerAlive(host, pid) {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %s is locked by chrome process %d", ErrProfileBusy, dir, pid)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s: %v", ErrProfileBusy, dir, ctx.Err())
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	for _, name := range chromeSingletonFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Lstat(path); err != nil {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			logrus.Warnf("failed to remove stale chrome lock %s: %v", path, err)
+		} else {
+			logrus.Debugf("removed stale chrome lock: %s", path)
+		}
+	}
+	return nil
+}
+
+// singletonOwner 解析 SingletonLock 指向的主机名和进程号，锁文件不存在或格式不对时 ok 为 false
+func singletonOwner(dir string) (host string, pid int, ok bool) {
+	target, err := os.Readlink(filepath.Join(dir, chromeSingletonFiles[0]))
+	if err != nil {
+		return "", 0, false
+	}
+	return parseSingletonTarget(target)
+}
+
+func parseSingletonTarget(target string) (host string, pid int, ok bool) {
+	idx := strings.LastIndex(target, "-")
+	if idx <= 0 {
+		return "", 0, false
+	}
+	pid, err := strconv.Atoi(target[idx+1:])
+	if err != nil || pid <= 0 {
+		return "", 0, false
+	}
+	return target[:idx], pid, true
+}
+
+// ownerAlive 判断锁文件所属的 Chrome 进程是否仍在运行。主机名与本机不同时视为已退出：
+// 容器重启后主机名会变化，此时锁文件只可能是旧容器残留的
+func ownerAlive(host string, pid int) bool {
+	if hostname, err := os.Hostname(); err != nil || hostname != host {
+		return false
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,108 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSingletonTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		host   string
+		pid    int
+		ok     bool
+	}{
+		{"my-host-1234", "my-host", 1234, true},
+		{"localhost-1", "localhost", 1, true},
+		{"localhost", "", 0, false},
+		{"-1234", "", 0, false},
+		{"host-abc", "", 0, false},
+		{"host-0", "", 0, false},
+	}
+	for _, tt := range tests {
+		host, pid, ok := parseSingletonTarget(tt.target)
+		if host != tt.host || pid != tt.pid || ok != tt.ok {
+			t.Errorf("parseSingletonTarget(%q) = %q, %d, %v; want %q, %d, %v", tt.target, host, pid, ok, tt.host, tt.pid, tt.ok)
+		}
+	}
+}
+
+func TestRemoveStaleSingletonFiles(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+
+	lock := func(t *testing.T, target string) string {
+		dir := t.TempDir()
+		if err := os.Symlink(target, filepath.Join(dir, "SingletonLock")); err != nil {
+			t.Skipf("symlink unsupported: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SingletonCookie"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	t.Run("dead process", func(t *testing.T) {
+		dir := lock(t, fmt.Sprintf("%s-%d", hostname, 1<<30))
+		if err := removeStaleSingletonFiles(context.Background(), dir); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, name := range chromeSingletonFiles {
+			if _, err := os.Lstat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+				t.Errorf("%s not removed", name)
+			}
+		}
+	})
+
+	t.Run("other host", func(t *testing.T) {
+		dir := lock(t, fmt.Sprintf("%s-old-%d", hostname, os.Getpid()))
+		if err := removeStaleSingletonFiles(context.Background(), dir); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := os.Lstat(filepath.Join(dir, "SingletonLock")); !os.IsNotExist(err) {
+			t.Error("SingletonLock not removed")
+		}
+	})
+
+	t.Run("live process", func(t *testing.T) {
+		dir := lock(t, fmt.Sprintf("%s-%d", hostname, os.Getpid()))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := removeStaleSingletonFiles(ctx, dir)
+		if !errors.Is(err, ErrProfileBusy) {
+			t.Fatalf("expected ErrProfileBusy, got %v", err)
+		}
+		if _, err := os.Lstat(filepath.Join(dir, "SingletonLock")); err != nil {
+			t.Errorf("SingletonLock of a live process removed: %v", err)
+		}
+	})
+}
+
+func TestAcquireProfileBusy(t *testing.T) {
+	dir := t.TempDir()
+	p, err := acquireProfile(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := acquireProfile(ctx, dir); !errors.Is(err, ErrProfileBusy) {
+		t.Fatalf("expected ErrProfileBusy while held, got %v", err)
+	}
+
+	p.unlock()
+	p.unlock()
+	p2, err := acquireProfile(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("acquire after unlock: %v", err)
+	}
+	p2.unlock()
+}
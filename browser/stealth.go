@@ -0,0 +1,49 @@
+package browser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/stealth"
+	"github.com/sirupsen/logrus"
+)
+
+// WithStealth 是否启用反自动化检测（默认启用）：去掉 enable-automation 启动参数并禁用
+// AutomationControlled 特性，页面加载前注入脚本隐藏 navigator.webdriver、伪造插件列表等，
+// 并让 navigator.languages 与浏览器语言一致。排查页面兼容问题时可以关闭。
+func WithStealth(enabled bool) Option {
+	return func(c *browserConfig) {
+		c.disableStealth = !enabled
+	}
+}
+
+// stealthLanguages 根据浏览器语言生成 navigator.languages，如 zh-CN → [zh-CN zh]
+func stealthLanguages(locale string) []string {
+	languages := []string{locale}
+	if base, _, ok := strings.Cut(locale, "-"); ok && base != "" {
+		languages = append(languages, base)
+	}
+	return languages
+}
+
+// languagesScript 覆盖 navigator.languages。stealth 脚本默认返回 en-US，与中文的 Accept-Language 不一致
+const languagesScript = `(() => {
+	const languages = Object.freeze(%s);
+	Object.defineProperty(Object.getPrototypeOf(navigator), 'languages', {
+		get: () => languages,
+		configurable: true,
+	});
+})();`
+
+// newStealthPage 创建注入了反自动化检测脚本的页面，脚本在每次导航的页面脚本之前执行
+func (b *Browser) newStealthPage() *rod.Page {
+	page := stealth.MustPage(b.browser)
+
+	languages, _ := json.Marshal(stealthLanguages(b.locale))
+	if _, err := page.EvalOnNewDocument(fmt.Sprintf(languagesScript, languages)); err != nil {
+		logrus.Warnf("failed to override navigator.languages: %v", err)
+	}
+	return page
+}
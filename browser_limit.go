@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// ErrBrowserBusy 同时运行的浏览器数量已达上限，在请求截止前没有空出名额
+var ErrBrowserBusy = errors.New("browser limit reached, too many concurrent browsers")
+
+// browserLimiter 限制全局同时运行的浏览器实例数量，超出时排队等待，避免多账号并发时内存耗尽
+type browserLimiter struct {
+	sem chan struct{}
+}
+
+// newBrowserLimiter 创建并发限制，max<=0 表示不限制
+func newBrowserLimiter(max int) *browserLimiter {
+	if max <= 0 {
+		return &browserLimiter{}
+	}
+	return &browserLimiter{sem: make(chan struct{}, max)}
+}
+
+// acquire 获取一个浏览器名额，返回的 release 可重复调用，只归还一次
+func (l *browserLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w (max %d): %v", ErrBrowserBusy, configs.GetMaxBrowsers(), ctx.Err())
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.sem })
+	}, nil
+}
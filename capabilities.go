@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+const (
+	// serverName 服务名称
+	serverName = "xiaohongshu-mcp"
+	// serverVersion 服务版本，MCP initialize 与服务能力查询共用
+	serverVersion = "2.0.0"
+
+	// capabilitiesResourceURI 服务能力的 MCP 资源地址
+	capabilitiesResourceURI = "xiaohongshu://capabilities"
+)
+
+// PublishField 发布接口支持的字段
+type PublishField struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+// Capabilities 服务能力，供客户端按服务端版本做功能探测与降级
+type Capabilities struct {
+	Server                string                    `json:"server"`
+	Version               string                    `json:"version"`
+	SiteAdaptationVersion int                       `json:"site_adaptation_version"`
+	Tools                 []string                  `json:"tools"`
+	PublishFields         map[string][]PublishField `json:"publish_fields"`
+}
+
+// buildCapabilities 根据 MCP 工具注册表和发布请求结构体生成服务能力，新增工具或发布字段时无需另外维护
+func buildCapabilities() *Capabilities {
+	tools := mcpTools()
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		if name, ok := tool["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+
+	return &Capabilities{
+		Server:                serverName,
+		Version:               serverVersion,
+		SiteAdaptationVersion: xiaohongshu.SiteAdaptationVersion,
+		Tools:                 names,
+		PublishFields: map[string][]PublishField{
+			"image": requestFields(PublishRequest{}),
+			"video": requestFields(PublishVideoRequest{}),
+		},
+	}
+}
+
+// requestFields 从请求结构体的 json/binding 标签中读取字段名及是否必填
+func requestFields(v any) []PublishField {
+	t := reflect.TypeOf(v)
+
+	fields := make([]PublishField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		required := false
+		for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+			if rule == "required" {
+				required = true
+			}
+		}
+		fields = append(fields, PublishField{Name: name, Required: required})
+	}
+	return fields
+}
+
+// processResourcesList 处理资源列表请求
+func (s *AppServer) processResourcesList(request *JSONRPCRequest) *JSONRPCResponse {
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"resources": []map[string]interface{}{
+				{
+					"uri":         capabilitiesResourceURI,
+					"name":        "capabilities",
+					"description": "服务版本、支持的工具、发布字段及站点适配版本",
+					"mimeType":    "application/json",
+				},
+			},
+		},
+		ID: request.ID,
+	}
+}
+
+// processResourcesRead 处理资源读取请求
+func (s *AppServer) processResourcesRead(request *JSONRPCRequest) *JSONRPCResponse {
+	params, _ := request.Params.(map[string]interface{})
+	uri, _ := params["uri"].(string)
+	if uri != capabilitiesResourceURI {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			Error: &JSONRPCError{
+				Code:    -32002,
+				Message: "Resource not found",
+				Data:    uri,
+			},
+			ID: request.ID,
+		}
+	}
+
+	data, err := json.MarshalIndent(buildCapabilities(), "", "  ")
+	if err != nil {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			Error: &JSONRPCError{
+				Code:    -32603,
+				Message: "Internal error",
+				Data:    err.Error(),
+			},
+			ID: request.ID,
+		}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{
+					"uri":      capabilitiesResourceURI,
+					"mimeType": "application/json",
+					"text":     string(data),
+				},
+			},
+		},
+		ID: request.ID,
+	}
+}
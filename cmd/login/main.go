@@ -4,13 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"flag"
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/accounts"
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/qrcode"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
@@ -18,11 +22,17 @@ func main() {
 	var (
 		binPath   string // 浏览器二进制文件路径
 		accountID string // 账号标识
+		qrOutput  string // 登录二维码的输出方式
 	)
 	flag.StringVar(&binPath, "bin", "", "浏览器二进制文件路径")
 	flag.StringVar(&accountID, "account", "", "账号标识，用于区分 cookies 存储")
+	flag.StringVar(&qrOutput, "qr-output", "", "以无头模式登录并输出二维码：terminal（终端显示）、file:<path>（保存为 PNG）、base64（打印 data URL）；为空时打开浏览器窗口扫码")
 	flag.Parse()
 
+	if err := validateQrOutput(qrOutput); err != nil {
+		logrus.Fatalf("invalid qr-output: %v", err)
+	}
+
 	resolvedAccountID, err := accounts.ResolveAccountID(accountID)
 	if err != nil {
 		logrus.Fatalf("invalid account id: %v", err)
@@ -39,13 +49,26 @@ func main() {
 		logrus.Fatalf("failed to resolve cookies path: %v", err)
 	}
 
-	// 登录的时候，需要界面，所以不能无头模式
-	options := []browser.Option{browser.WithCookiesPath(cookiePath)}
+	profileDir, err := accounts.ChromeProfileDir(resolvedAccountID)
+	if err != nil {
+		logrus.Fatalf("failed to resolve chrome profile dir: %v", err)
+	}
+
+	proxy, err := accounts.AccountProxy(resolvedAccountID)
+	if err != nil {
+		logrus.Fatalf("failed to load account proxy: %v", err)
+	}
+
+	options := []browser.Option{browser.WithCookiesPath(cookiePath), browser.WithUserDataDir(profileDir), browser.WithProxy(proxy)}
 	if binPath != "" {
 		options = append(options, browser.WithBinPath(binPath))
 	}
 
-	b := browser.NewBrowser(false, options...)
+	// 在浏览器窗口中扫码时需要界面，所以不能无头模式；输出二维码时无需界面
+	b, err := browser.Launch(context.Background(), qrOutput != "", options...)
+	if err != nil {
+		logrus.Fatalf("failed to launch browser: %v", err)
+	}
 	defer b.Close()
 
 	page := b.NewPage()
@@ -66,12 +89,15 @@ func main() {
 
 	// 开始登录流程
 	logrus.Info("开始登录流程...")
-	if err = action.Login(context.Background()); err != nil {
-		logrus.Fatalf("登录失败: %v", err)
-	} else {
-		if err := saveCookies(resolvedAccountID, page); err != nil {
-			logrus.Fatalf("failed to save cookies: %v", err)
+	if qrOutput != "" {
+		if err := loginWithQrcode(action, qrOutput); err != nil {
+			logrus.Fatalf("登录失败: %v", err)
 		}
+	} else if err = action.Login(context.Background()); err != nil {
+		logrus.Fatalf("登录失败: %v", err)
+	}
+	if err := saveCookies(resolvedAccountID, page); err != nil {
+		logrus.Fatalf("failed to save cookies: %v", err)
 	}
 
 	// 再次检查登录状态确认成功
@@ -88,6 +114,69 @@ func main() {
 
 }
 
+// validateQrOutput 校验二维码输出方式
+func validateQrOutput(output string) error {
+	switch {
+	case output == "", output == "terminal", output == "base64":
+		return nil
+	case strings.HasPrefix(output, "file:"):
+		if strings.TrimSpace(strings.TrimPrefix(output, "file:")) == "" {
+			return fmt.Errorf("file path is empty, expected file:<path>")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output %q (available: terminal, file:<path>, base64)", output)
+	}
+}
+
+// loginWithQrcode 获取登录二维码并按 output 输出，等待扫码完成
+func loginWithQrcode(action *xiaohongshu.LoginAction, output string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Minute)
+	defer cancel()
+
+	src, loggedIn, err := action.FetchQrcodeImage(ctx)
+	if err != nil {
+		return err
+	}
+	if loggedIn {
+		return nil
+	}
+
+	if err := writeQrcode(src, output); err != nil {
+		return err
+	}
+
+	logrus.Info("请使用小红书 App 扫描二维码登录，4 分钟内有效...")
+	if !action.WaitForLogin(ctx) {
+		return fmt.Errorf("等待扫码超时")
+	}
+	return nil
+}
+
+// writeQrcode 按 output 输出二维码图片
+func writeQrcode(src, output string) error {
+	if output == "base64" {
+		fmt.Println(src)
+		return nil
+	}
+
+	data, err := qrcode.DecodeDataURL(src)
+	if err != nil {
+		return err
+	}
+
+	if path, ok := strings.CutPrefix(output, "file:"); ok {
+		path = strings.TrimSpace(path)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to save qrcode to %s: %w", path, err)
+		}
+		logrus.Infof("登录二维码已保存到 %s", path)
+		return nil
+	}
+
+	return qrcode.RenderTerminal(os.Stdout, data)
+}
+
 func saveCookies(accountID string, page *rod.Page) error {
 	cks, err := page.Browser().GetCookies()
 	if err != nil {
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/browser"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/moderation"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templating"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+	"gopkg.in/yaml.v3"
+)
+
+// 退出码：输入不合法时为 2，发布失败时为 1
+const (
+	exitPublishFailed = 1
+	exitInvalidInput  = 2
+)
+
+// post 发布内容定义，字段与服务端的 PublishRequest / PublishVideoRequest 一致，
+// images 与 video 二选一，分别发布图文和视频
+type post struct {
+	AccountID  string   `json:"account_id" yaml:"account_id"`
+	Title      string   `json:"title" yaml:"title"`
+	Content    string   `json:"content" yaml:"content"`
+	Images     []string `json:"images" yaml:"images"`
+	Video      string   `json:"video" yaml:"video"`
+	Tags       []string `json:"tags" yaml:"tags"`
+	ProductIDs []string `json:"product_ids" yaml:"product_ids"`
+	MusicQuery string   `json:"music_query" yaml:"music_query"`
+
+	// Template 正文模板，与 content 二选一，发布前用 vars 展开，标题中的占位符同时展开
+	Template string            `json:"template" yaml:"template"`
+	Vars     map[string]string `json:"vars" yaml:"vars"`
+}
+
+// result 发布结果，输出到标准输出
+type result struct {
+	AccountID string `json:"account_id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Images    int    `json:"images,omitempty"`
+	Video     string `json:"video,omitempty"`
+	Status    string `json:"status"`
+}
+
+func main() {
+	var (
+		file      string // 发布内容定义文件
+		accountID string // 账号标识，覆盖文件中的 account_id
+	)
+	flag.StringVar(&file, "file", "-", "发布内容定义文件（JSON 或 YAML），- 表示从标准输入读取")
+	flag.StringVar(&accountID, "account", "", "账号标识，优先于文件中的 account_id；都为空时使用当前活跃账号")
+
+	// 与服务端共用配置参数（-bin、-headless、-publish_url、-request_timeout 等）
+	cfg, err := configs.Load()
+	if err != nil {
+		logrus.Errorf("invalid config: %v", err)
+		os.Exit(exitInvalidInput)
+	}
+
+	p, baseDir, err := readPost(file)
+	if err != nil {
+		logrus.Errorf("读取发布内容失败: %v", err)
+		os.Exit(exitInvalidInput)
+	}
+	if strings.TrimSpace(accountID) != "" {
+		p.AccountID = accountID
+	}
+	p.resolvePaths(baseDir)
+
+	if err := p.expandTemplate(); err != nil {
+		logrus.Errorf("展开模板失败: %v", err)
+		os.Exit(exitInvalidInput)
+	}
+	if err := p.validate(); err != nil {
+		logrus.Errorf("发布内容不合法: %v", err)
+		os.Exit(exitInvalidInput)
+	}
+
+	resolvedAccountID, err := accounts.ResolveAccountIDOrActive(p.AccountID)
+	if err != nil {
+		logrus.Errorf("invalid account id: %v", err)
+		os.Exit(exitInvalidInput)
+	}
+
+	ctx := context.Background()
+	if cfg.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.RequestTimeout)
+		defer cancel()
+	}
+
+	res, err := publish(ctx, cfg, resolvedAccountID, p)
+	if err != nil {
+		if errors.Is(err, xiaohongshu.ErrNotLoggedIn) {
+			logrus.Errorf("账号 %s 未登录，请先运行 go run cmd/login/main.go --account %s", resolvedAccountID, resolvedAccountID)
+		} else {
+			logrus.Errorf("发布失败: %v", err)
+		}
+		os.Exit(exitPublishFailed)
+	}
+
+	out, _ := json.MarshalIndent(res, "", "  ")
+	fmt.Println(string(out))
+}
+
+// readPost 读取发布内容定义，path 为 - 时从标准输入读取。
+// 返回文件所在目录，用于解析相对路径；标准输入时为当前目录。
+func readPost(path string) (*post, string, error) {
+	var (
+		data    []byte
+		err     error
+		baseDir = "."
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+		baseDir = filepath.Dir(path)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, err := decodePost(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return p, baseDir, nil
+}
+
+// decodePost 解析 JSON 或 YAML 格式的发布内容，以 { 开头时按 JSON 解析，未知字段视为错误
+func decodePost(data []byte) (*post, error) {
+	var p post
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("parse json failed: %w", err)
+		}
+		return &p, nil
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("parse yaml failed: %w", err)
+	}
+	return &p, nil
+}
+
+// resolvePaths 将本地图片、视频的相对路径解析为相对于定义文件所在目录的路径
+func (p *post) resolvePaths(baseDir string) {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) || downloader.IsImageURL(path) {
+			return path
+		}
+		return filepath.Join(baseDir, path)
+	}
+
+	for i, img := range p.Images {
+		p.Images[i] = resolve(img)
+	}
+	p.Video = resolve(p.Video)
+}
+
+// expandTemplate 设置了正文模板时，用 vars 展开标题和正文
+func (p *post) expandTemplate() error {
+	if p.Template == "" {
+		return nil
+	}
+	if p.Content != "" {
+		return fmt.Errorf("content and template are mutually exclusive")
+	}
+
+	out, err := templating.Expand(p.Vars, p.Title, p.Template)
+	if err != nil {
+		return err
+	}
+	p.Title = strings.TrimSpace(out[0])
+	p.Content = out[1]
+	return nil
+}
+
+// validate 按服务端发布接口的规则校验发布内容
+func (p *post) validate() error {
+	if strings.TrimSpace(p.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+	// 小红书限制：最大40个单位长度，中文/日文/韩文占2个单位，英文/数字占1个单位
+	if runewidth.StringWidth(p.Title) > 40 {
+		return fmt.Errorf("标题长度超过限制")
+	}
+	if strings.TrimSpace(p.Content) == "" {
+		return fmt.Errorf("content is required")
+	}
+
+	switch {
+	case len(p.Images) == 0 && p.Video == "":
+		return fmt.Errorf("images or video is required")
+	case len(p.Images) > 0 && p.Video != "":
+		return fmt.Errorf("images and video are mutually exclusive")
+	case p.Video != "":
+		if len(p.ProductIDs) > 0 {
+			return fmt.Errorf("product_ids is only supported for image posts")
+		}
+		if err := xiaohongshu.ValidateVideoFile(p.Video); err != nil {
+			return err
+		}
+	}
+	if p.Video == "" && strings.TrimSpace(p.MusicQuery) != "" {
+		return fmt.Errorf("music_query is only supported for video posts")
+	}
+
+	return moderation.Check(append([]string{p.Title, p.Content}, p.Tags...)...)
+}
+
+// publish 启动账号的浏览器并发布图文或视频
+func publish(ctx context.Context, cfg configs.Config, accountID string, p *post) (*result, error) {
+	res := &result{AccountID: accountID, Title: p.Title, Content: p.Content, Status: "发布完成"}
+
+	var imagePaths []string
+	if len(p.Images) > 0 {
+		imageDir, err := accounts.ImagesDir(accountID)
+		if err != nil {
+			return nil, err
+		}
+		imagePaths, err = downloader.NewImageProcessor(imageDir, downloader.Limits{
+			RequestTimeout: cfg.ImageDownloadTimeout,
+			TotalTimeout:   cfg.ImageDownloadTotalTimeout,
+			MaxRedirects:   cfg.ImageDownloadMaxRedirects,
+			MaxSize:        cfg.ImageDownloadMaxSize,
+		}).ProcessImages(ctx, p.Images)
+		if err != nil {
+			return nil, err
+		}
+		res.Images = len(imagePaths)
+	}
+
+	b, err := newBrowser(cfg, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	if p.Video != "" {
+		action, err := xiaohongshu.NewPublishVideoAction(page)
+		if err != nil {
+			return nil, err
+		}
+		if err := action.PublishVideo(ctx, xiaohongshu.PublishVideoContent{
+			Title:      p.Title,
+			Content:    p.Content,
+			Tags:       p.Tags,
+			VideoPath:  p.Video,
+			MusicQuery: p.MusicQuery,
+		}); err != nil {
+			return nil, err
+		}
+		res.Video = p.Video
+		return res, nil
+	}
+
+	action, err := xiaohongshu.NewPublishImageAction(page)
+	if err != nil {
+		return nil, err
+	}
+	if err := action.Publish(ctx, xiaohongshu.PublishImageContent{
+		Title:      p.Title,
+		Content:    p.Content,
+		Tags:       p.Tags,
+		ImagePaths: imagePaths,
+		ProductIDs: xiaohongshu.NormalizeProductIDs(p.ProductIDs),
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// newBrowser 使用账号的 cookies、Chrome 配置目录和代理启动浏览器
+func newBrowser(cfg configs.Config, accountID string) (*browser.Browser, error) {
+	cookiePath, err := accounts.CookiesPath(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	profileDir, err := accounts.ChromeProfileDir(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := accounts.AccountProxy(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []browser.Option{
+		browser.WithCookiesPath(cookiePath),
+		browser.WithUserDataDir(profileDir),
+		browser.WithProxy(proxy),
+		browser.WithLocale(cfg.BrowserLocale),
+		browser.WithTimezone(cfg.BrowserTimezone),
+		browser.WithStealth(cfg.Stealth),
+	}
+	if cfg.BinPath != "" {
+		options = append(options, browser.WithBinPath(cfg.BinPath))
+	}
+	return browser.Launch(context.Background(), cfg.Headless, options...)
+}
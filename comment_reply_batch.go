@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+const (
+	// maxReplyBatchSize 单次批量回复的评论数上限
+	maxReplyBatchSize = 20
+	// defaultReplyInterval 相邻两条回复之间的默认间隔，连续快速回复容易被判定为刷屏
+	defaultReplyInterval = 10 * time.Second
+	// minReplyInterval 相邻两条回复之间的最短间隔
+	minReplyInterval = 3 * time.Second
+	// maxReplyInterval 相邻两条回复之间的最长间隔，批次需在请求超时内完成
+	maxReplyInterval = 5 * time.Minute
+	// estimatedReplyDuration 单条回复（打开笔记、定位评论、输入并发送）的预估耗时，用于估算批次总时长
+	estimatedReplyDuration = 15 * time.Second
+)
+
+// replyNicknamePlaceholder 回复模板中替换为评论作者昵称的占位符
+const replyNicknamePlaceholder = "{nickname}"
+
+// ReplyRecentCommentsRequest 批量回复评论请求，replies 与 template 二选一
+type ReplyRecentCommentsRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+
+	// Replies 评论 ID 到回复内容的映射，按评论 ID 排序依次回复
+	Replies map[string]string `json:"replies,omitempty"`
+	// Template 回复最近的一级评论使用的内容，{nickname} 替换为评论作者昵称
+	Template string `json:"template,omitempty"`
+	// Limit 使用 Template 时回复的评论数，默认且最多 maxReplyBatchSize
+	Limit int `json:"limit,omitempty"`
+
+	MentionAuthor bool `json:"mention_author,omitempty"` // 自动 @ 被回复评论的作者
+	// IntervalSeconds 相邻两条回复的间隔秒数，为 0 时使用默认值，取值在 minReplyInterval 与 maxReplyInterval 之间
+	IntervalSeconds int `json:"interval_seconds,omitempty" binding:"min=0,max=300"`
+}
+
+// ReplyBatchItemResult 单条评论的回复结果
+type ReplyBatchItemResult struct {
+	CommentID string `json:"comment_id"`
+	Content   string `json:"content"`
+	ReplyID   string `json:"reply_id,omitempty"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReplyRecentCommentsResponse 批量回复评论响应
+type ReplyRecentCommentsResponse struct {
+	FeedID    string                 `json:"feed_id"`
+	Results   []ReplyBatchItemResult `json:"results"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+}
+
+// replyTarget 待回复的评论及回复内容
+type replyTarget struct {
+	commentID string
+	content   string
+}
+
+// ReplyToRecentComments 批量回复笔记下的评论：提供 replies 时按评论 ID 回复对应内容，
+// 否则用 template 回复详情页首屏中最近的 limit 条一级评论（跳过自己发表或已回复过的评论）。相邻两条回复之间按间隔等待，
+// 单条失败不影响后续评论；请求被取消后剩余的评论不再回复，并标记为失败。
+func (s *XiaohongshuService) ReplyToRecentComments(ctx context.Context, accountID string, req *ReplyRecentCommentsRequest) (*ReplyRecentCommentsResponse, error) {
+	if err := validateReplyBatch(req); err != nil {
+		return nil, err
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	token := req.XsecToken
+	var targets []replyTarget
+	if len(req.Replies) > 0 {
+		targets = replyTargetsFromMap(req.Replies)
+	} else {
+		// 跳过自己发表或已回复过的评论需要当前账号的用户 ID
+		var selfUserID string
+		if err := s.withLoginRetry(accountID, b, func() (err error) {
+			selfUserID, err = xiaohongshu.NewUserProfileAction(page).SelfUserID(ctx)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		var detail *xiaohongshu.FeedDetailResponse
+		if err := s.withTokenRefresh(ctx, accountID, b, req.FeedID, token, func(t string) (err error) {
+			token = t
+			detail, err = xiaohongshu.NewFeedDetailAction(page).GetFeedDetail(ctx, req.FeedID, t)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		targets = replyTargetsFromTemplate(detail.Comments.List, selfUserID, req.Template, req.Limit)
+	}
+
+	interval := replyInterval(req)
+
+	log := logrus.WithField("account", accounts.DisplayName(accountID))
+	action := xiaohongshu.NewCommentFeedAction(page)
+	response := &ReplyRecentCommentsResponse{
+		FeedID:  req.FeedID,
+		Results: make([]ReplyBatchItemResult, 0, len(targets)),
+	}
+	for i, target := range targets {
+		item := ReplyBatchItemResult{CommentID: target.commentID, Content: target.content}
+
+		if i > 0 && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			item.Error = "已取消: " + err.Error()
+		} else if err := s.withTokenRefresh(ctx, accountID, b, req.FeedID, token, func(t string) (err error) {
+			token = t
+			item.ReplyID, err = action.ReplyToComment(ctx, req.FeedID, t, target.commentID, target.content, req.MentionAuthor)
+			return err
+		}); err != nil {
+			log.Warnf("批量回复评论第 %d 条（%s）失败: %v", i+1, target.commentID, err)
+			item.Error = err.Error()
+		} else {
+			item.Success = true
+		}
+
+		if item.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	return response, nil
+}
+
+// validateReplyBatch 校验 replies 与 template 二选一、回复内容不为空、数量和间隔不超过上限，
+// 且按最多回复条数估算的总耗时不超过请求超时（否则后面的评论会被取消）
+func validateReplyBatch(req *ReplyRecentCommentsRequest) error {
+	template := strings.TrimSpace(req.Template)
+	switch {
+	case len(req.Replies) == 0 && template == "":
+		return fmt.Errorf("replies or template is required")
+	case len(req.Replies) > 0 && template != "":
+		return fmt.Errorf("replies and template are mutually exclusive")
+	case len(req.Replies) > maxReplyBatchSize:
+		return fmt.Errorf("too many replies: %d, at most %d", len(req.Replies), maxReplyBatchSize)
+	case time.Duration(req.IntervalSeconds)*time.Second > maxReplyInterval:
+		return fmt.Errorf("interval_seconds %d exceeds the maximum %d", req.IntervalSeconds, int(maxReplyInterval.Seconds()))
+	}
+
+	count := len(req.Replies)
+	if count == 0 {
+		count = replyLimit(req.Limit)
+	}
+	if err := checkBatchDuration(count, estimatedReplyDuration, replyInterval(req), configs.GetRequestTimeout()); err != nil {
+		return err
+	}
+
+	for commentID, content := range req.Replies {
+		if strings.TrimSpace(commentID) == "" {
+			return fmt.Errorf("replies contains an empty comment id")
+		}
+		if strings.TrimSpace(content) == "" {
+			return fmt.Errorf("reply to comment %s is empty", commentID)
+		}
+	}
+	return nil
+}
+
+// replyInterval 相邻两条回复的间隔，未指定时使用默认值，不低于 minReplyInterval
+func replyInterval(req *ReplyRecentCommentsRequest) time.Duration {
+	interval := time.Duration(req.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultReplyInterval
+	}
+	return max(interval, minReplyInterval)
+}
+
+// replyLimit 使用模板时回复的评论数，默认且最多 maxReplyBatchSize
+func replyLimit(limit int) int {
+	if limit <= 0 || limit > maxReplyBatchSize {
+		return maxReplyBatchSize
+	}
+	return limit
+}
+
+// replyTargetsFromMap 按评论 ID 排序，保证多次调用的回复顺序一致
+func replyTargetsFromMap(replies map[string]string) []replyTarget {
+	targets := make([]replyTarget, 0, len(replies))
+	for commentID, content := range replies {
+		targets = append(targets, replyTarget{commentID: commentID, content: strings.TrimSpace(content)})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].commentID < targets[j].commentID })
+	return targets
+}
+
+// replyTargetsFromTemplate 跳过当前账号自己发表或已回复过的评论后，选出最近发表的 limit 条一级评论，
+// 按模板生成回复内容，重复执行时不会再次回复同一条评论
+func replyTargetsFromTemplate(comments []xiaohongshu.Comment, selfUserID, template string, limit int) []replyTarget {
+	limit = replyLimit(limit)
+
+	pending := xiaohongshu.CommentsAwaitingReply(comments, selfUserID)
+	recent := make([]xiaohongshu.Comment, len(pending))
+	copy(recent, pending)
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].CreateTime > recent[j].CreateTime })
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+
+	targets := make([]replyTarget, 0, len(recent))
+	for _, c := range recent {
+		content := strings.ReplaceAll(template, replyNicknamePlaceholder, strings.TrimSpace(c.UserInfo.Nickname))
+		targets = append(targets, replyTarget{commentID: c.ID, content: strings.TrimSpace(content)})
+	}
+	return targets
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// CommentsRequest 获取笔记评论请求
+type CommentsRequest struct {
+	FeedID    string
+	XsecToken string // 为空时自动查找，见 FeedDetailRequest
+	Keyword   string // 只返回内容包含该关键词的评论
+	Author    string // 只返回该作者（用户 ID 或昵称）的评论
+	Limit     int    // 最多扫描的一级评论数量
+}
+
+// CommentsResponse 获取笔记评论响应
+type CommentsResponse struct {
+	FeedID    string                `json:"feed_id"`
+	XsecToken string                `json:"xsec_token,omitempty"`
+	Comments  []xiaohongshu.Comment `json:"comments"`
+	Scanned   int                   `json:"scanned"` // 扫描的评论数（含已加载的子评论）
+	Matched   int                   `json:"matched"` // 匹配筛选条件的评论数
+	HasMore   bool                  `json:"has_more"`
+}
+
+// GetComments 打开笔记详情页滚动加载评论，最多扫描 req.Limit 条一级评论，加载完成后按关键词和作者筛选
+func (s *XiaohongshuService) GetComments(ctx context.Context, accountID string, req *CommentsRequest) (*CommentsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDetailCommentLimit
+	}
+	if limit > maxDetailCommentLimit {
+		limit = maxDetailCommentLimit
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewFeedDetailAction(page)
+
+	var comments xiaohongshu.CommentList
+	var usedToken string
+	err = s.withResolvedToken(ctx, accountID, b, req.FeedID, req.XsecToken, "", func(token string) error {
+		usedToken = token
+		if _, err := action.GetFeedDetail(ctx, req.FeedID, token); err != nil {
+			return err
+		}
+		comments, err = action.LoadComments(ctx, req.FeedID, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, scanned, matched := xiaohongshu.FilterComments(comments.List, xiaohongshu.CommentFilter{
+		Keyword: req.Keyword,
+		Author:  req.Author,
+	})
+
+	return &CommentsResponse{
+		FeedID:    req.FeedID,
+		XsecToken: usedToken,
+		Comments:  filtered,
+		Scanned:   scanned,
+		Matched:   matched,
+		HasMore:   comments.HasMore,
+	}, nil
+}
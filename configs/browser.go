@@ -1,24 +1,94 @@
 package configs
 
-var (
-	useHeadless = true
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"time"
+	_ "time/tzdata" // 校验 browser_timezone 时不依赖系统时区数据库
+)
 
-	binPath = ""
+const (
+	// DefaultBrowserLocale 默认浏览器语言
+	DefaultBrowserLocale = "zh-CN"
+	// DefaultBrowserTimezone 默认浏览器时区，与站点展示时间（北京时间）一致
+	DefaultBrowserTimezone = "Asia/Shanghai"
 )
 
+// localePattern BCP 47 语言标签，如 zh-CN、en-US、zh-Hans-CN
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
 func InitHeadless(h bool) {
-	useHeadless = h
+	current.Headless = h
 }
 
 // IsHeadless 是否无头模式。
 func IsHeadless() bool {
-	return useHeadless
+	return current.Headless
 }
 
 func SetBinPath(b string) {
-	binPath = b
+	current.BinPath = b
 }
 
 func GetBinPath() string {
-	return binPath
+	return current.BinPath
+}
+
+// checkBinPath 校验配置的浏览器二进制文件存在且可执行，未配置时跳过（使用自动下载的浏览器）。
+func checkBinPath(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("browser binary %s does not exist", path)
+		}
+		return fmt.Errorf("failed to stat browser binary %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("browser binary %s is a directory", path)
+	}
+	// Windows 没有可执行权限位，只检查文件是否存在
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("browser binary %s is not executable", path)
+	}
+	return nil
+}
+
+// checkBrowserLocale 校验浏览器语言为 BCP 47 语言标签
+func checkBrowserLocale(locale string) error {
+	if !localePattern.MatchString(locale) {
+		return fmt.Errorf("%q is not a language tag like zh-CN", locale)
+	}
+	return nil
+}
+
+// checkBrowserTimezone 校验浏览器时区为 IANA 时区名
+func checkBrowserTimezone(tz string) error {
+	if tz == "" || tz == "Local" {
+		return fmt.Errorf("%q is not an IANA time zone like Asia/Shanghai", tz)
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fmt.Errorf("%q is not an IANA time zone like Asia/Shanghai", tz)
+	}
+	return nil
+}
+
+// IsStealth 是否启用反自动化检测。
+func IsStealth() bool {
+	return current.Stealth
+}
+
+// GetBrowserLocale 获取浏览器语言，如 zh-CN。
+func GetBrowserLocale() string {
+	return current.BrowserLocale
+}
+
+// GetBrowserTimezone 获取浏览器时区，如 Asia/Shanghai。
+func GetBrowserTimezone() string {
+	return current.BrowserTimezone
 }
@@ -0,0 +1,29 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBinPath(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, checkBinPath(""))
+	assert.ErrorContains(t, checkBinPath(filepath.Join(dir, "missing")), "does not exist")
+	assert.ErrorContains(t, checkBinPath(dir), "is a directory")
+
+	bin := filepath.Join(dir, "chrome")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\n"), 0o755))
+	assert.NoError(t, checkBinPath(bin))
+
+	if runtime.GOOS != "windows" {
+		plain := filepath.Join(dir, "plain")
+		require.NoError(t, os.WriteFile(plain, nil, 0o644))
+		assert.ErrorContains(t, checkBinPath(plain), "not executable")
+	}
+}
@@ -0,0 +1,32 @@
+package configs
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// checkCaptchaCallbackURL 校验验证码回调地址为 http(s) 绝对地址，为空表示不通知
+func checkCaptchaCallbackURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", raw)
+	}
+	return nil
+}
+
+// GetCaptchaSolveTimeout 获取出现验证码时等待手动完成验证的最长时间，<=0 表示不等待。
+func GetCaptchaSolveTimeout() time.Duration {
+	return current.CaptchaSolveTimeout
+}
+
+// GetCaptchaCallbackURL 获取出现验证码时通知的回调地址，为空表示不通知。
+func GetCaptchaCallbackURL() string {
+	return current.CaptchaCallbackURL
+}
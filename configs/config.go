@@ -0,0 +1,420 @@
+package configs
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// Config 服务配置，启动时通过 Load 从命令行参数和环境变量加载一次。
+// 各配置项原有的 Get/Is 函数读取当前生效的配置。
+type Config struct {
+	Headless bool   // 是否无头模式
+	BinPath  string // 浏览器二进制文件路径
+
+	BrowserLocale   string // 浏览器语言，决定 Accept-Language 及页面语言
+	BrowserTimezone string // 浏览器时区（IANA 名称）
+	Stealth         bool   // 是否启用反自动化检测
+
+	CaptchaSolveTimeout time.Duration // 出现验证码时等待手动完成验证的最长时间，<=0 表示不等待
+	CaptchaCallbackURL  string        // 出现验证码时通知的回调地址，为空表示不通知
+
+	InitialStateRetries int           // __INITIAL_STATE__ 为空时的刷新重试次数
+	RequestTimeout      time.Duration // 单个请求的整体超时，<=0 表示不限制
+	JobTimeout          time.Duration // 单个异步任务的整体超时，<=0 表示不限制
+
+	PublishConfirmTimeout time.Duration // 点击发布后等待发布结果的最长时间
+	PublishTabTimeout     time.Duration // 点击发布 TAB 后等待其切换完成、上传面板出现的最长时间
+
+	HumanDelayMin time.Duration // 交互前随机等待的最小值
+	HumanDelayMax time.Duration // 交互前随机等待的最大值
+
+	WaitStrategy string // 页面导航后的等待策略，格式 action=strategy,...
+
+	VideoMaxSize     int64         // 上传视频的最大文件大小（字节），<=0 表示不限制
+	VideoMaxDuration time.Duration // 上传视频的最大时长，<=0 表示不限制
+
+	VideoUploadRetries int // 视频上传失败时重新选择文件上传的次数
+
+	UploadPreviewTolerance int           // 图片上传允许缺少的预览数量，0 表示严格要求全部预览出现
+	UploadPreviewGrace     time.Duration // 预览数量保持不变多久后按容忍数量判定上传完成
+
+	ImageUploadRetries int           // 图片上传停滞时重新设置文件的次数
+	ImageUploadStall   time.Duration // 预览数量低于预期且持续多久没有变化视为上传停滞，<=0 表示不检测
+
+	ImageDownloadTimeout      time.Duration // 单张 URL 图片的下载超时，<=0 使用默认值
+	ImageDownloadTotalTimeout time.Duration // 一次发布下载全部 URL 图片的整体超时，<=0 表示不限制
+	ImageDownloadMaxRedirects int           // 下载图片时最多跟随的重定向次数
+	ImageDownloadMaxSize      int64         // 单张 URL 图片的最大字节数，<=0 表示不限制
+
+	UploadRoot string // 允许发布的本地图片和视频所在的根目录，为空表示不限制
+
+	CookiesFile string // 单账号部署时默认账号使用的 cookies 文件，为空时使用账号目录下的 cookies.json
+
+	TLSCert string // HTTPS 证书文件
+	TLSKey  string // HTTPS 私钥文件
+
+	CORSOrigins string // 允许跨域访问 REST API 的来源，逗号分隔
+
+	Gzip bool // 是否压缩 REST API 的 JSON 响应
+
+	LoginStatusCacheTTL time.Duration // 登录状态检查结果的缓存时间，<=0 表示不缓存
+
+	PublishURL string // 发布编辑器页面地址
+
+	PublishDedup       string        // 发布前的重复检查方式：off/title/content，默认 off
+	PublishDedupWindow time.Duration // 重复检查的时间窗口，只比较该时间内发布的笔记
+
+	SelectorsFile string // 选择器覆盖文件（JSON/YAML），为空使用内置选择器
+
+	Debug bool // 是否开启调试功能
+
+	MaxBrowsers int // 全局同时运行的浏览器实例上限，<=0 表示不限制
+
+	LogLevel string // 日志级别：trace/debug/info/warn/error
+	LogJSON  bool   // 是否以 JSON 格式输出日志
+}
+
+// DefaultConfig 返回默认配置。
+func DefaultConfig() Config {
+	return Config{
+		Headless:                  true,
+		BrowserLocale:             DefaultBrowserLocale,
+		BrowserTimezone:           DefaultBrowserTimezone,
+		Stealth:                   true,
+		CaptchaSolveTimeout:       2 * time.Minute,
+		InitialStateRetries:       1,
+		RequestTimeout:            10 * time.Minute,
+		JobTimeout:                time.Hour,
+		PublishConfirmTimeout:     30 * time.Second,
+		PublishTabTimeout:         10 * time.Second,
+		HumanDelayMin:             500 * time.Millisecond,
+		HumanDelayMax:             1500 * time.Millisecond,
+		VideoMaxSize:              20 << 30,
+		VideoMaxDuration:          15 * time.Minute,
+		VideoUploadRetries:        2,
+		UploadPreviewGrace:        10 * time.Second,
+		ImageUploadRetries:        2,
+		ImageUploadStall:          15 * time.Second,
+		ImageDownloadTimeout:      30 * time.Second,
+		ImageDownloadTotalTimeout: 2 * time.Minute,
+		ImageDownloadMaxRedirects: 5,
+		ImageDownloadMaxSize:      20 << 20,
+		Gzip:                      true,
+		LoginStatusCacheTTL:       30 * time.Second,
+		PublishURL:                DefaultPublishURL,
+		PublishDedup:              PublishDedupOff,
+		PublishDedupWindow:        24 * time.Hour,
+		MaxBrowsers:               4,
+		LogLevel:                  DefaultLogLevel,
+	}
+}
+
+// current 当前生效的配置
+var current = DefaultConfig()
+
+// Current 返回当前生效的配置。
+func Current() Config {
+	return current
+}
+
+// Load 从命令行参数和环境变量加载配置，校验通过后设为当前配置，只应在启动时调用一次。
+func Load() (Config, error) {
+	cfg, err := Parse(flag.CommandLine, os.Args[1:], os.Getenv)
+	if err != nil {
+		return cfg, err
+	}
+	if err := Apply(cfg); err != nil {
+		return cfg, err
+	}
+	return Current(), nil
+}
+
+// Parse 在 fs 上注册配置参数并解析 args，未通过参数设置的项使用 getenv 读取环境变量，最后校验配置。
+func Parse(fs *flag.FlagSet, args []string, getenv func(string) string) (Config, error) {
+	cfg := DefaultConfig()
+	videoMaxSizeMB := cfg.VideoMaxSize >> 20
+	imageDownloadMaxSizeMB := cfg.ImageDownloadMaxSize >> 20
+
+	fs.BoolVar(&cfg.Headless, "headless", cfg.Headless, "是否无头模式")
+	fs.StringVar(&cfg.BinPath, "bin", "", "浏览器二进制文件路径（环境变量 ROD_BROWSER_BIN）")
+	fs.StringVar(&cfg.BrowserLocale, "browser_locale", "", "浏览器语言，决定 Accept-Language 请求头及页面的语言和日期格式，为空使用 zh-CN（环境变量 XHS_MCP_BROWSER_LOCALE）")
+	fs.StringVar(&cfg.BrowserTimezone, "browser_timezone", "", "浏览器时区（IANA 名称），为空使用 Asia/Shanghai（环境变量 XHS_MCP_BROWSER_TIMEZONE）")
+	fs.BoolVar(&cfg.Stealth, "stealth", cfg.Stealth, "隐藏 navigator.webdriver 等自动化特征，降低无头模式被识别、出现验证码的概率；排查页面问题时可用 -stealth=false 关闭")
+	fs.DurationVar(&cfg.CaptchaSolveTimeout, "captcha_solve_timeout", cfg.CaptchaSolveTimeout, "出现滑块等验证码时，非无头模式（或配置了 captcha_callback_url）下等待完成验证的最长时间，0 表示不等待直接返回 CAPTCHA_REQUIRED")
+	fs.StringVar(&cfg.CaptchaCallbackURL, "captcha_callback_url", "", "出现验证码时 POST 通知的地址，便于接入外部处理（环境变量 XHS_MCP_CAPTCHA_CALLBACK_URL）")
+	fs.IntVar(&cfg.InitialStateRetries, "state_retries", cfg.InitialStateRetries, "页面数据(__INITIAL_STATE__)为空时刷新重试次数")
+	fs.DurationVar(&cfg.RequestTimeout, "request_timeout", cfg.RequestTimeout, "单个请求的整体超时，0 表示不限制")
+	fs.DurationVar(&cfg.JobTimeout, "job_timeout", cfg.JobTimeout, "单个异步任务（如异步发布视频）的整体超时，不受 request_timeout 限制，0 表示不限制")
+	fs.DurationVar(&cfg.PublishConfirmTimeout, "publish_confirm_timeout", cfg.PublishConfirmTimeout, "点击发布后等待发布结果的最长时间")
+	fs.DurationVar(&cfg.PublishTabTimeout, "publish_tab_timeout", cfg.PublishTabTimeout, "点击上传图文/上传视频 TAB 后等待其选中且上传面板出现的最长时间")
+	fs.DurationVar(&cfg.HumanDelayMin, "human_delay_min", cfg.HumanDelayMin, "点赞/收藏/评论等点击前随机等待的最小值，与最大值均为 0 时关闭")
+	fs.DurationVar(&cfg.HumanDelayMax, "human_delay_max", cfg.HumanDelayMax, "点赞/收藏/评论等点击前随机等待的最大值")
+	fs.StringVar(&cfg.WaitStrategy, "wait_strategy", "", "页面导航后的等待策略，格式 action=strategy,...；action 可选 feeds/search/feed_detail/user_profile/comment/interact 或 *，strategy 可选 domstable/networkidle/selector/default")
+	fs.Int64Var(&videoMaxSizeMB, "video_max_size_mb", videoMaxSizeMB, "上传视频的最大文件大小（MB），0 表示不限制")
+	fs.DurationVar(&cfg.VideoMaxDuration, "video_max_duration", cfg.VideoMaxDuration, "上传视频的最大时长，0 表示不限制")
+	fs.IntVar(&cfg.VideoUploadRetries, "video_upload_retries", cfg.VideoUploadRetries, "视频上传界面提示上传失败时重新上传的次数，0 表示不重试")
+	fs.IntVar(&cfg.UploadPreviewTolerance, "upload_preview_tolerance", cfg.UploadPreviewTolerance, "图片上传时允许缺少的预览数量（预览渲染偶尔滞后），0 表示严格要求全部预览出现")
+	fs.DurationVar(&cfg.UploadPreviewGrace, "upload_preview_grace", cfg.UploadPreviewGrace, "预览数量保持不变超过该时间后，按 upload_preview_tolerance 判定上传完成")
+	fs.IntVar(&cfg.ImageUploadRetries, "image_upload_retries", cfg.ImageUploadRetries, "图片上传停滞（预览数量长时间不增加）时重新上传该图片的次数，0 表示不重试")
+	fs.DurationVar(&cfg.ImageUploadStall, "image_upload_stall", cfg.ImageUploadStall, "图片预览数量低于预期且超过该时间没有变化时视为上传停滞，0 表示不检测")
+	fs.DurationVar(&cfg.ImageDownloadTimeout, "image_download_timeout", cfg.ImageDownloadTimeout, "下载单张 URL 图片的超时时间")
+	fs.DurationVar(&cfg.ImageDownloadTotalTimeout, "image_download_total_timeout", cfg.ImageDownloadTotalTimeout, "一次发布下载全部 URL 图片的整体超时，0 表示不限制")
+	fs.IntVar(&cfg.ImageDownloadMaxRedirects, "image_download_max_redirects", cfg.ImageDownloadMaxRedirects, "下载图片时最多跟随的重定向次数，0 表示不跟随重定向")
+	fs.Int64Var(&imageDownloadMaxSizeMB, "image_download_max_size_mb", imageDownloadMaxSizeMB, "单张 URL 图片的最大大小（MB），0 表示不限制")
+	fs.StringVar(&cfg.TLSCert, "tls_cert", "", "HTTPS 证书文件路径，与 tls_key 同时设置时以 HTTPS 提供服务（环境变量 XHS_MCP_TLS_CERT）")
+	fs.StringVar(&cfg.TLSKey, "tls_key", "", "HTTPS 私钥文件路径（环境变量 XHS_MCP_TLS_KEY）")
+	fs.StringVar(&cfg.CORSOrigins, "cors_origins", "", "允许跨域访问 /api 的来源，逗号分隔，* 表示任意来源，为空表示仅同源（环境变量 XHS_MCP_CORS_ORIGINS）")
+	fs.BoolVar(&cfg.Gzip, "gzip", cfg.Gzip, "客户端支持时对 /api 的较大 JSON 响应启用 gzip 压缩")
+	fs.DurationVar(&cfg.LoginStatusCacheTTL, "login_status_cache_ttl", cfg.LoginStatusCacheTTL, "登录状态检查结果的缓存时间，0 表示不缓存")
+	fs.StringVar(&cfg.PublishDedup, "publish_dedup", cfg.PublishDedup, "发布图文前检查近期是否已发布过相同笔记：off（默认，不检查）、title（标题相同）、content（标题和正文相同）")
+	fs.DurationVar(&cfg.PublishDedupWindow, "publish_dedup_window", cfg.PublishDedupWindow, "发布重复检查的时间窗口，只比较该时间内发布的笔记")
+	fs.StringVar(&cfg.PublishURL, "publish_url", "", "发布编辑器页面地址，需为 https://creator.xiaohongshu.com 下的地址，为空使用默认值（环境变量 XHS_MCP_PUBLISH_URL）")
+	fs.StringVar(&cfg.UploadRoot, "upload_root", "", "允许发布的本地图片和视频文件所在的根目录，设置后拒绝该目录以外的本地路径，为空表示不限制（环境变量 XHS_MCP_UPLOAD_ROOT）")
+	fs.StringVar(&cfg.CookiesFile, "cookies_file", "", "单账号部署时默认账号直接读写的 cookies 文件，不再使用账号目录；未提供 account_id 且没有活跃账号时使用默认账号（环境变量 XHS_MCP_COOKIES_FILE）")
+	fs.StringVar(&cfg.SelectorsFile, "selectors", "", "选择器覆盖文件（JSON 或 YAML），站点改版时无需重新编译即可替换页面选择器（环境变量 XHS_MCP_SELECTORS）")
+	fs.BoolVar(&cfg.Debug, "debug", false, "开启调试功能，如详情接口的 debug_html（环境变量 XHS_MCP_DEBUG）")
+	fs.IntVar(&cfg.MaxBrowsers, "max_browsers", cfg.MaxBrowsers, "全局同时运行的浏览器实例上限，超出时请求排队等待，0 表示不限制")
+	fs.StringVar(&cfg.LogLevel, "log_level", "", "日志级别 trace/debug/info/warn/error，为空使用 info（环境变量 XHS_MCP_LOG_LEVEL）")
+	fs.BoolVar(&cfg.LogJSON, "log_json", false, "以 JSON 格式输出日志，便于日志系统采集（环境变量 XHS_MCP_LOG_JSON）")
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	cfg.VideoMaxSize = videoMaxSizeMB << 20
+	cfg.ImageDownloadMaxSize = imageDownloadMaxSizeMB << 20
+
+	if len(cfg.BinPath) == 0 {
+		cfg.BinPath = getenv("ROD_BROWSER_BIN")
+	}
+	if len(cfg.BrowserLocale) == 0 {
+		cfg.BrowserLocale = getenv("XHS_MCP_BROWSER_LOCALE")
+	}
+	if len(cfg.BrowserLocale) == 0 {
+		cfg.BrowserLocale = DefaultBrowserLocale
+	}
+	if len(cfg.BrowserTimezone) == 0 {
+		cfg.BrowserTimezone = getenv("XHS_MCP_BROWSER_TIMEZONE")
+	}
+	if len(cfg.BrowserTimezone) == 0 {
+		cfg.BrowserTimezone = DefaultBrowserTimezone
+	}
+	if len(cfg.CaptchaCallbackURL) == 0 {
+		cfg.CaptchaCallbackURL = getenv("XHS_MCP_CAPTCHA_CALLBACK_URL")
+	}
+	if len(cfg.TLSCert) == 0 {
+		cfg.TLSCert = getenv("XHS_MCP_TLS_CERT")
+	}
+	if len(cfg.TLSKey) == 0 {
+		cfg.TLSKey = getenv("XHS_MCP_TLS_KEY")
+	}
+	if len(cfg.CORSOrigins) == 0 {
+		cfg.CORSOrigins = getenv("XHS_MCP_CORS_ORIGINS")
+	}
+	if len(cfg.PublishURL) == 0 {
+		cfg.PublishURL = getenv("XHS_MCP_PUBLISH_URL")
+	}
+	if len(cfg.PublishURL) == 0 {
+		cfg.PublishURL = DefaultPublishURL
+	}
+	if len(cfg.UploadRoot) == 0 {
+		cfg.UploadRoot = getenv("XHS_MCP_UPLOAD_ROOT")
+	}
+	if len(cfg.CookiesFile) == 0 {
+		cfg.CookiesFile = getenv("XHS_MCP_COOKIES_FILE")
+	}
+	if len(cfg.SelectorsFile) == 0 {
+		cfg.SelectorsFile = getenv("XHS_MCP_SELECTORS")
+	}
+	if !cfg.Debug {
+		cfg.Debug, _ = strconv.ParseBool(getenv("XHS_MCP_DEBUG"))
+	}
+	if len(cfg.LogLevel) == 0 {
+		cfg.LogLevel = getenv("XHS_MCP_LOG_LEVEL")
+	}
+	if len(cfg.LogLevel) == 0 {
+		cfg.LogLevel = DefaultLogLevel
+	}
+	if !cfg.LogJSON {
+		cfg.LogJSON, _ = strconv.ParseBool(getenv("XHS_MCP_LOG_JSON"))
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate 校验配置，返回所有不合法的配置项。
+func (c Config) Validate() error {
+	var errs []error
+
+	if err := checkBinPath(c.BinPath); err != nil {
+		errs = append(errs, fmt.Errorf("invalid bin: %w", err))
+	}
+	if err := checkBrowserLocale(c.BrowserLocale); err != nil {
+		errs = append(errs, fmt.Errorf("invalid browser_locale: %w", err))
+	}
+	if err := checkBrowserTimezone(c.BrowserTimezone); err != nil {
+		errs = append(errs, fmt.Errorf("invalid browser_timezone: %w", err))
+	}
+	if c.CaptchaSolveTimeout < 0 {
+		errs = append(errs, fmt.Errorf("captcha_solve_timeout must not be negative"))
+	}
+	if err := checkCaptchaCallbackURL(c.CaptchaCallbackURL); err != nil {
+		errs = append(errs, fmt.Errorf("invalid captcha_callback_url: %w", err))
+	}
+	if c.InitialStateRetries < 0 {
+		errs = append(errs, fmt.Errorf("state_retries must not be negative: %d", c.InitialStateRetries))
+	}
+	if c.JobTimeout < 0 {
+		errs = append(errs, fmt.Errorf("job_timeout must not be negative"))
+	}
+	if c.HumanDelayMin < 0 || c.HumanDelayMax < 0 {
+		errs = append(errs, fmt.Errorf("human_delay_min/human_delay_max must not be negative"))
+	} else if c.HumanDelayMax < c.HumanDelayMin {
+		errs = append(errs, fmt.Errorf("human_delay_max (%s) must not be less than human_delay_min (%s)", c.HumanDelayMax, c.HumanDelayMin))
+	}
+	if c.VideoMaxSize < 0 {
+		errs = append(errs, fmt.Errorf("video_max_size_mb must not be negative"))
+	}
+	if c.VideoUploadRetries < 0 {
+		errs = append(errs, fmt.Errorf("video_upload_retries must not be negative: %d", c.VideoUploadRetries))
+	}
+	if c.UploadPreviewTolerance < 0 {
+		errs = append(errs, fmt.Errorf("upload_preview_tolerance must not be negative: %d", c.UploadPreviewTolerance))
+	}
+	if c.UploadPreviewGrace < 0 {
+		errs = append(errs, fmt.Errorf("upload_preview_grace must not be negative"))
+	}
+	if c.ImageUploadRetries < 0 {
+		errs = append(errs, fmt.Errorf("image_upload_retries must not be negative: %d", c.ImageUploadRetries))
+	}
+	if c.ImageUploadStall < 0 {
+		errs = append(errs, fmt.Errorf("image_upload_stall must not be negative"))
+	}
+	if c.ImageDownloadTimeout < 0 || c.ImageDownloadTotalTimeout < 0 {
+		errs = append(errs, fmt.Errorf("image_download_timeout/image_download_total_timeout must not be negative"))
+	}
+	if c.ImageDownloadMaxRedirects < 0 {
+		errs = append(errs, fmt.Errorf("image_download_max_redirects must not be negative: %d", c.ImageDownloadMaxRedirects))
+	}
+	if c.ImageDownloadMaxSize < 0 {
+		errs = append(errs, fmt.Errorf("image_download_max_size_mb must not be negative"))
+	}
+	if c.MaxBrowsers < 0 {
+		errs = append(errs, fmt.Errorf("max_browsers must not be negative: %d", c.MaxBrowsers))
+	}
+	if _, err := parseLogLevel(c.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("invalid log_level: %w", err))
+	}
+	if _, err := parseWaitStrategies(c.WaitStrategy); err != nil {
+		errs = append(errs, fmt.Errorf("invalid wait_strategy: %w", err))
+	}
+	if err := checkTLSFiles(c.TLSCert, c.TLSKey); err != nil {
+		errs = append(errs, fmt.Errorf("invalid tls config: %w", err))
+	}
+	if _, err := parseCORSOrigins(c.CORSOrigins); err != nil {
+		errs = append(errs, fmt.Errorf("invalid cors_origins: %w", err))
+	}
+	if _, err := parsePublishDedup(c.PublishDedup); err != nil {
+		errs = append(errs, fmt.Errorf("invalid publish_dedup: %w", err))
+	}
+	if c.PublishDedupWindow < 0 {
+		errs = append(errs, fmt.Errorf("publish_dedup_window must not be negative"))
+	}
+	if _, err := normalizePublishURL(c.PublishURL); err != nil {
+		errs = append(errs, fmt.Errorf("invalid publish_url: %w", err))
+	}
+	if _, err := normalizeUploadRoot(c.UploadRoot); err != nil {
+		errs = append(errs, fmt.Errorf("invalid upload_root: %w", err))
+	}
+	if _, err := loadSelectorOverrides(c.SelectorsFile); err != nil {
+		errs = append(errs, fmt.Errorf("invalid selectors: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Apply 校验配置并设为当前配置。
+func Apply(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	selectorOverrides, err := loadSelectorOverrides(c.SelectorsFile)
+	if err != nil {
+		return err
+	}
+	strategies, _ := parseWaitStrategies(c.WaitStrategy)
+	origins, _ := parseCORSOrigins(c.CORSOrigins)
+	c.PublishURL, _ = normalizePublishURL(c.PublishURL)
+	c.PublishDedup, _ = parsePublishDedup(c.PublishDedup)
+	c.UploadRoot, _ = normalizeUploadRoot(c.UploadRoot)
+	if c.PublishDedupWindow <= 0 {
+		c.PublishDedupWindow = DefaultConfig().PublishDedupWindow
+	}
+	if c.PublishConfirmTimeout <= 0 {
+		c.PublishConfirmTimeout = DefaultConfig().PublishConfirmTimeout
+	}
+	if c.PublishTabTimeout <= 0 {
+		c.PublishTabTimeout = DefaultConfig().PublishTabTimeout
+	}
+	if c.ImageDownloadTimeout <= 0 {
+		c.ImageDownloadTimeout = DefaultConfig().ImageDownloadTimeout
+	}
+
+	setWaitStrategies(strategies)
+	corsOrigins = origins
+	selectors.SetOverrides(selectorOverrides)
+	current = c
+	return nil
+}
+
+// Summary 返回用于启动日志的生效配置。
+func (c Config) Summary() map[string]any {
+	return map[string]any{
+		"headless":                     c.Headless,
+		"bin":                          c.BinPath,
+		"browser_locale":               c.BrowserLocale,
+		"browser_timezone":             c.BrowserTimezone,
+		"stealth":                      c.Stealth,
+		"captcha_solve_timeout":        c.CaptchaSolveTimeout.String(),
+		"captcha_callback_url":         c.CaptchaCallbackURL != "",
+		"state_retries":                c.InitialStateRetries,
+		"request_timeout":              c.RequestTimeout.String(),
+		"job_timeout":                  c.JobTimeout.String(),
+		"publish_confirm_timeout":      c.PublishConfirmTimeout.String(),
+		"publish_tab_timeout":          c.PublishTabTimeout.String(),
+		"human_delay_min":              c.HumanDelayMin.String(),
+		"human_delay_max":              c.HumanDelayMax.String(),
+		"wait_strategy":                c.WaitStrategy,
+		"video_max_size_mb":            c.VideoMaxSize >> 20,
+		"video_max_duration":           c.VideoMaxDuration.String(),
+		"video_upload_retries":         c.VideoUploadRetries,
+		"upload_preview_tolerance":     c.UploadPreviewTolerance,
+		"upload_preview_grace":         c.UploadPreviewGrace.String(),
+		"image_upload_retries":         c.ImageUploadRetries,
+		"image_upload_stall":           c.ImageUploadStall.String(),
+		"image_download_timeout":       c.ImageDownloadTimeout.String(),
+		"image_download_total_timeout": c.ImageDownloadTotalTimeout.String(),
+		"image_download_max_redirects": c.ImageDownloadMaxRedirects,
+		"image_download_max_size_mb":   c.ImageDownloadMaxSize >> 20,
+		"tls":                          c.TLSCert != "",
+		"cors_origins":                 c.CORSOrigins,
+		"gzip":                         c.Gzip,
+		"login_status_cache_ttl":       c.LoginStatusCacheTTL.String(),
+		"publish_url":                  c.PublishURL,
+		"publish_dedup":                c.PublishDedup,
+		"publish_dedup_window":         c.PublishDedupWindow.String(),
+		"upload_root":                  c.UploadRoot,
+		"cookies_file":                 c.CookiesFile,
+		"selectors":                    c.SelectorsFile,
+		"debug":                        c.Debug,
+		"max_browsers":                 c.MaxBrowsers,
+		"log_level":                    c.LogLevel,
+		"log_json":                     c.LogJSON,
+	}
+}
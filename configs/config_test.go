@@ -0,0 +1,223 @@
+package configs
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseForTest(args []string, env map[string]string) (Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return Parse(fs, args, func(key string) string { return env[key] })
+}
+
+func TestParseDefaults(t *testing.T) {
+	cfg, err := parseForTest(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), cfg)
+}
+
+func TestParseFlagsAndEnv(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "chrome")
+	require.NoError(t, os.WriteFile(bin, nil, 0o755))
+
+	cfg, err := parseForTest(
+		[]string{"-headless=false", "-video_max_size_mb=10", "-max_browsers=2", "-bin=" + bin},
+		map[string]string{
+			"ROD_BROWSER_BIN":      "/env/chrome",
+			"XHS_MCP_CORS_ORIGINS": "https://a.com",
+			"XHS_MCP_DEBUG":        "true",
+		},
+	)
+	require.NoError(t, err)
+
+	assert.False(t, cfg.Headless)
+	assert.Equal(t, int64(10<<20), cfg.VideoMaxSize)
+	assert.Equal(t, 2, cfg.MaxBrowsers)
+	assert.Equal(t, bin, cfg.BinPath, "flag takes precedence over env")
+	assert.Equal(t, "https://a.com", cfg.CORSOrigins)
+	assert.True(t, cfg.Debug)
+}
+
+func TestParseCollectsAllErrors(t *testing.T) {
+	_, err := parseForTest([]string{
+		"-max_browsers=-1",
+		"-wait_strategy=bad",
+		"-cors_origins=ftp://a.com",
+		"-human_delay_min=2s",
+		"-human_delay_max=1s",
+		"-bin=/nonexistent/chrome",
+		"-image_upload_retries=-1",
+	}, nil)
+	require.Error(t, err)
+
+	for _, want := range []string{"max_browsers", "wait_strategy", "cors_origins", "human_delay_max", "bin", "image_upload_retries"} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	saved := Current()
+	t.Cleanup(func() { require.NoError(t, Apply(saved)) })
+
+	cfg := DefaultConfig()
+	cfg.WaitStrategy = "search=networkidle"
+	cfg.CORSOrigins = "https://a.com"
+	cfg.PublishURL = ""
+	cfg.PublishConfirmTimeout = 0
+	cfg.PublishTabTimeout = 0
+	cfg.RequestTimeout = time.Minute
+	require.NoError(t, Apply(cfg))
+
+	assert.Equal(t, WaitNetworkIdle, GetWaitStrategy(WaitActionSearch))
+	assert.True(t, IsCORSOriginAllowed("https://a.com"))
+	assert.Equal(t, DefaultPublishURL, GetPublishURL())
+	assert.Equal(t, DefaultConfig().PublishConfirmTimeout, GetPublishConfirmTimeout())
+	assert.Equal(t, DefaultConfig().PublishTabTimeout, GetPublishTabTimeout())
+	assert.Equal(t, time.Minute, GetRequestTimeout())
+
+	cfg.MaxBrowsers = -1
+	assert.Error(t, Apply(cfg))
+	assert.Equal(t, DefaultConfig().MaxBrowsers, GetMaxBrowsers(), "invalid config is not applied")
+}
+
+func TestParseSelectorsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "selectors.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"like_btn": ".like"}`), 0o644))
+
+	_, err := parseForTest(nil, map[string]string{"XHS_MCP_SELECTORS": path})
+	assert.ErrorContains(t, err, "invalid selectors")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"like_button": ".like"}`), 0o644))
+	cfg, err := parseForTest([]string{"-selectors", path}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, path, cfg.SelectorsFile)
+}
+
+func TestParseLogConfig(t *testing.T) {
+	cfg, err := parseForTest(nil, map[string]string{"XHS_MCP_LOG_LEVEL": "DEBUG", "XHS_MCP_LOG_JSON": "true"})
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG", cfg.LogLevel)
+	assert.True(t, cfg.LogJSON)
+
+	cfg, err = parseForTest([]string{"-log_level=warn"}, map[string]string{"XHS_MCP_LOG_LEVEL": "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, "warn", cfg.LogLevel, "flag takes precedence over env")
+
+	_, err = parseForTest([]string{"-log_level=verbose"}, nil)
+	assert.ErrorContains(t, err, "invalid log_level")
+
+	saved := Current()
+	t.Cleanup(func() { require.NoError(t, Apply(saved)) })
+
+	assert.Equal(t, logrus.InfoLevel, GetLogLevel())
+	assert.False(t, IsLogJSON())
+
+	cfg.LogLevel = "DEBUG"
+	cfg.LogJSON = true
+	require.NoError(t, Apply(cfg))
+	assert.Equal(t, logrus.DebugLevel, GetLogLevel())
+	assert.True(t, IsLogJSON())
+}
+
+func TestParsePublishDedup(t *testing.T) {
+	cfg, err := parseForTest([]string{"-publish_dedup=Title", "-publish_dedup_window=12h"}, nil)
+	require.NoError(t, err)
+
+	saved := Current()
+	t.Cleanup(func() { require.NoError(t, Apply(saved)) })
+
+	require.NoError(t, Apply(cfg))
+	assert.Equal(t, PublishDedupTitle, GetPublishDedup())
+	assert.Equal(t, 12*time.Hour, GetPublishDedupWindow())
+
+	_, err = parseForTest([]string{"-publish_dedup=hash"}, nil)
+	assert.ErrorContains(t, err, "invalid publish_dedup")
+}
+
+func TestParseUploadRoot(t *testing.T) {
+	root := t.TempDir()
+
+	cfg, err := parseForTest(nil, map[string]string{"XHS_MCP_UPLOAD_ROOT": root})
+	require.NoError(t, err)
+	assert.Equal(t, root, cfg.UploadRoot)
+
+	saved := Current()
+	t.Cleanup(func() { require.NoError(t, Apply(saved)) })
+
+	require.NoError(t, Apply(cfg))
+	resolved, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, GetUploadRoot())
+
+	file := filepath.Join(root, "a.jpg")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	_, err = parseForTest([]string{"-upload_root", file}, nil)
+	assert.ErrorContains(t, err, "invalid upload_root")
+
+	_, err = parseForTest([]string{"-upload_root", filepath.Join(root, "missing")}, nil)
+	assert.ErrorContains(t, err, "invalid upload_root")
+}
+
+func TestParseCaptchaConfig(t *testing.T) {
+	cfg, err := parseForTest([]string{"-captcha_solve_timeout=30s"}, map[string]string{"XHS_MCP_CAPTCHA_CALLBACK_URL": "https://hooks.example.com/captcha"})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.CaptchaSolveTimeout)
+	assert.Equal(t, "https://hooks.example.com/captcha", cfg.CaptchaCallbackURL)
+
+	_, err = parseForTest([]string{"-captcha_callback_url=ftp://example.com"}, nil)
+	assert.ErrorContains(t, err, "invalid captcha_callback_url")
+
+	_, err = parseForTest([]string{"-captcha_solve_timeout=-1s"}, nil)
+	assert.ErrorContains(t, err, "captcha_solve_timeout must not be negative")
+}
+
+func TestParseJobTimeout(t *testing.T) {
+	cfg, err := parseForTest(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, cfg.JobTimeout)
+
+	cfg, err = parseForTest([]string{"-job_timeout=0"}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, cfg.JobTimeout)
+
+	_, err = parseForTest([]string{"-job_timeout=-1m"}, nil)
+	assert.ErrorContains(t, err, "job_timeout must not be negative")
+}
+
+func TestParseBrowserLocale(t *testing.T) {
+	cfg, err := parseForTest([]string{"-browser_locale=en-US"}, map[string]string{"XHS_MCP_BROWSER_TIMEZONE": "America/New_York"})
+	require.NoError(t, err)
+	assert.Equal(t, "en-US", cfg.BrowserLocale)
+	assert.Equal(t, "America/New_York", cfg.BrowserTimezone)
+
+	assert.True(t, cfg.Stealth)
+
+	cfg, err = parseForTest([]string{"-stealth=false"}, nil)
+	require.NoError(t, err)
+	assert.False(t, cfg.Stealth)
+
+	_, err = parseForTest([]string{"-browser_locale=zh_CN"}, nil)
+	assert.ErrorContains(t, err, "invalid browser_locale")
+
+	_, err = parseForTest([]string{"-browser_timezone=Mars/Olympus"}, nil)
+	assert.ErrorContains(t, err, "invalid browser_timezone")
+}
+
+func TestParseCookiesFile(t *testing.T) {
+	cfg, err := parseForTest(nil, map[string]string{"XHS_MCP_COOKIES_FILE": "/data/cookies.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "/data/cookies.json", cfg.CookiesFile)
+
+	cfg, err = parseForTest([]string{"-cookies_file=/opt/cookies.json"}, map[string]string{"XHS_MCP_COOKIES_FILE": "/data/cookies.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/cookies.json", cfg.CookiesFile)
+}
@@ -0,0 +1,52 @@
+package configs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// corsOrigins 允许跨域访问 REST API 的来源，为空表示不允许跨域（仅同源）
+var corsOrigins []string
+
+// SetCORSOrigins 设置允许跨域访问的来源，逗号分隔，如 https://a.com,http://localhost:3000；
+// * 表示允许任意来源，空字符串表示关闭跨域。
+func SetCORSOrigins(spec string) error {
+	origins, err := parseCORSOrigins(spec)
+	if err != nil {
+		return err
+	}
+
+	corsOrigins = origins
+	current.CORSOrigins = spec
+	return nil
+}
+
+// parseCORSOrigins 解析逗号分隔的跨域来源列表。
+func parseCORSOrigins(spec string) ([]string, error) {
+	var origins []string
+	for _, item := range strings.Split(spec, ",") {
+		origin := strings.TrimRight(strings.TrimSpace(item), "/")
+		if origin == "" {
+			continue
+		}
+		if origin != "*" {
+			u, err := url.Parse(origin)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+				return nil, fmt.Errorf("invalid cors origin %q, expected scheme://host[:port]", item)
+			}
+		}
+		origins = append(origins, origin)
+	}
+	return origins, nil
+}
+
+// IsCORSOriginAllowed 判断来源是否允许跨域访问。
+func IsCORSOriginAllowed(origin string) bool {
+	for _, allowed := range corsOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCORSOrigins(t *testing.T) {
+	t.Cleanup(func() { _ = SetCORSOrigins("") })
+
+	assert.False(t, IsCORSOriginAllowed("http://localhost:3000"))
+
+	require.NoError(t, SetCORSOrigins(" http://localhost:3000/, https://dash.example.com "))
+	assert.True(t, IsCORSOriginAllowed("http://localhost:3000"))
+	assert.True(t, IsCORSOriginAllowed("https://DASH.example.com"))
+	assert.False(t, IsCORSOriginAllowed("https://evil.example.com"))
+
+	require.NoError(t, SetCORSOrigins("*"))
+	assert.True(t, IsCORSOriginAllowed("https://evil.example.com"))
+
+	assert.Error(t, SetCORSOrigins("localhost:3000"))
+	assert.Error(t, SetCORSOrigins("https://a.com/path"))
+	assert.True(t, IsCORSOriginAllowed("https://evil.example.com"), "invalid spec keeps previous config")
+}
@@ -0,0 +1,11 @@
+package configs
+
+// SetDebugEnabled 设置是否开启调试功能（如返回详情页原始 HTML），避免生产环境泄露大量页面内容。
+func SetDebugEnabled(enabled bool) {
+	current.Debug = enabled
+}
+
+// IsDebugEnabled 是否开启调试功能。
+func IsDebugEnabled() bool {
+	return current.Debug
+}
@@ -0,0 +1,19 @@
+package configs
+
+import "time"
+
+// SetHumanDelayRange 设置交互前随机等待的范围，负数按 0 处理，max 小于 min 时取 min，均为 0 时关闭。
+func SetHumanDelayRange(min, max time.Duration) {
+	if min < 0 {
+		min = 0
+	}
+	if max < min {
+		max = min
+	}
+	current.HumanDelayMin, current.HumanDelayMax = min, max
+}
+
+// GetHumanDelayRange 获取交互前随机等待的范围。
+func GetHumanDelayRange() (time.Duration, time.Duration) {
+	return current.HumanDelayMin, current.HumanDelayMax
+}
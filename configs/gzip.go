@@ -0,0 +1,11 @@
+package configs
+
+// SetGzipEnabled 设置是否对 REST API 的 JSON 响应启用 gzip 压缩。
+func SetGzipEnabled(enabled bool) {
+	current.Gzip = enabled
+}
+
+// IsGzipEnabled 是否启用 gzip 压缩。
+func IsGzipEnabled() bool {
+	return current.Gzip
+}
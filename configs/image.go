@@ -3,6 +3,7 @@ package configs
 import (
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const (
@@ -12,3 +13,23 @@ const (
 func GetImagesPath() string {
 	return filepath.Join(os.TempDir(), ImagesDir)
 }
+
+// GetImageDownloadTimeout 获取单张 URL 图片的下载超时。
+func GetImageDownloadTimeout() time.Duration {
+	return current.ImageDownloadTimeout
+}
+
+// GetImageDownloadTotalTimeout 获取一次发布下载全部 URL 图片的整体超时，<=0 表示不限制。
+func GetImageDownloadTotalTimeout() time.Duration {
+	return current.ImageDownloadTotalTimeout
+}
+
+// GetImageDownloadMaxRedirects 获取下载图片时最多跟随的重定向次数。
+func GetImageDownloadMaxRedirects() int {
+	return current.ImageDownloadMaxRedirects
+}
+
+// GetImageDownloadMaxSize 获取单张 URL 图片的最大字节数，<=0 表示不限制。
+func GetImageDownloadMaxSize() int64 {
+	return current.ImageDownloadMaxSize
+}
@@ -0,0 +1,11 @@
+package configs
+
+// SetMaxBrowsers 设置全局同时运行的浏览器实例上限，<=0 表示不限制。
+func SetMaxBrowsers(n int) {
+	current.MaxBrowsers = n
+}
+
+// GetMaxBrowsers 获取全局同时运行的浏览器实例上限。
+func GetMaxBrowsers() int {
+	return current.MaxBrowsers
+}
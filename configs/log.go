@@ -0,0 +1,33 @@
+package configs
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultLogLevel 默认日志级别
+const DefaultLogLevel = "info"
+
+// parseLogLevel 解析日志级别，不区分大小写，为空时使用默认级别
+func parseLogLevel(level string) (logrus.Level, error) {
+	level = strings.TrimSpace(level)
+	if level == "" {
+		level = DefaultLogLevel
+	}
+	return logrus.ParseLevel(level)
+}
+
+// GetLogLevel 返回日志级别，默认 info。
+func GetLogLevel() logrus.Level {
+	level, err := parseLogLevel(current.LogLevel)
+	if err != nil {
+		return logrus.InfoLevel
+	}
+	return level
+}
+
+// IsLogJSON 是否以 JSON 格式输出日志。
+func IsLogJSON() bool {
+	return current.LogJSON
+}
@@ -0,0 +1,13 @@
+package configs
+
+import "time"
+
+// SetLoginStatusCacheTTL 设置登录状态检查结果的缓存时间，<=0 表示不缓存。
+func SetLoginStatusCacheTTL(d time.Duration) {
+	current.LoginStatusCacheTTL = d
+}
+
+// GetLoginStatusCacheTTL 获取登录状态检查结果的缓存时间。
+func GetLoginStatusCacheTTL() time.Duration {
+	return current.LoginStatusCacheTTL
+}
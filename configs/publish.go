@@ -0,0 +1,74 @@
+package configs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultPublishURL 默认的创作者中心发布页地址
+const DefaultPublishURL = "https://creator.xiaohongshu.com/publish/publish?source=official"
+
+// SetPublishURL 设置发布编辑器页面地址，站点调整发布入口时可通过配置快速适配，为空时恢复默认值。
+// 仅接受 https://creator.xiaohongshu.com 下的地址，格式不合法时返回错误并保持原配置。
+func SetPublishURL(raw string) error {
+	normalized, err := normalizePublishURL(raw)
+	if err != nil {
+		return err
+	}
+
+	current.PublishURL = normalized
+	return nil
+}
+
+// normalizePublishURL 校验发布页地址并返回规范化后的地址，为空时返回默认值。
+func normalizePublishURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return DefaultPublishURL, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid publish url %q: %w", raw, err)
+	}
+	if u.Scheme != "https" || !strings.EqualFold(u.Hostname(), "creator.xiaohongshu.com") || u.Port() != "" {
+		return "", fmt.Errorf("invalid publish url %q: must be an https://creator.xiaohongshu.com address", raw)
+	}
+	return u.String(), nil
+}
+
+// GetPublishURL 获取发布编辑器页面地址。
+func GetPublishURL() string {
+	return current.PublishURL
+}
+
+// 发布前的重复检查方式
+const (
+	PublishDedupOff     = "off"     // 不检查
+	PublishDedupTitle   = "title"   // 近期已发布过标题相同的笔记时跳过
+	PublishDedupContent = "content" // 近期已发布过标题和正文都相同的笔记时跳过
+)
+
+// parsePublishDedup 校验重复检查方式，为空时返回 PublishDedupOff
+func parsePublishDedup(raw string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(raw)); mode {
+	case "":
+		return PublishDedupOff, nil
+	case PublishDedupOff, PublishDedupTitle, PublishDedupContent:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown mode %q, expected one of off, title, content", raw)
+	}
+}
+
+// GetPublishDedup 获取发布前的重复检查方式。
+func GetPublishDedup() string {
+	return current.PublishDedup
+}
+
+// GetPublishDedupWindow 获取发布重复检查的时间窗口。
+func GetPublishDedupWindow() time.Duration {
+	return current.PublishDedupWindow
+}
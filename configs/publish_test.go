@@ -0,0 +1,26 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPublishURL(t *testing.T) {
+	t.Cleanup(func() { _ = SetPublishURL("") })
+
+	assert.Equal(t, DefaultPublishURL, GetPublishURL())
+
+	require.NoError(t, SetPublishURL(" https://creator.xiaohongshu.com/publish/publish?source=web "))
+	assert.Equal(t, "https://creator.xiaohongshu.com/publish/publish?source=web", GetPublishURL())
+
+	assert.Error(t, SetPublishURL("http://creator.xiaohongshu.com/publish/publish"))
+	assert.Error(t, SetPublishURL("https://www.xiaohongshu.com/publish"))
+	assert.Error(t, SetPublishURL("https://creator.xiaohongshu.com.evil.com/publish"))
+	assert.Error(t, SetPublishURL("creator.xiaohongshu.com/publish"))
+	assert.Equal(t, "https://creator.xiaohongshu.com/publish/publish?source=web", GetPublishURL(), "invalid url keeps previous config")
+
+	require.NoError(t, SetPublishURL(""))
+	assert.Equal(t, DefaultPublishURL, GetPublishURL())
+}
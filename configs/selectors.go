@@ -0,0 +1,18 @@
+package configs
+
+import (
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// loadSelectorOverrides 读取选择器覆盖文件，未配置时返回 nil
+func loadSelectorOverrides(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return selectors.ParseFile(path)
+}
+
+// GetSelectorsFile 获取选择器覆盖文件路径。
+func GetSelectorsFile() string {
+	return current.SelectorsFile
+}
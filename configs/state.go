@@ -0,0 +1,14 @@
+package configs
+
+// SetInitialStateRetries 设置页面已加载但 __INITIAL_STATE__ 为空时的刷新重试次数，负数按 0 处理。
+func SetInitialStateRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	current.InitialStateRetries = n
+}
+
+// GetInitialStateRetries 获取 __INITIAL_STATE__ 为空时的刷新重试次数。
+func GetInitialStateRetries() int {
+	return current.InitialStateRetries
+}
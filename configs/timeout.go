@@ -0,0 +1,42 @@
+package configs
+
+import "time"
+
+// SetRequestTimeout 设置单个 HTTP/MCP 请求的整体超时，<=0 表示不限制。
+func SetRequestTimeout(d time.Duration) {
+	current.RequestTimeout = d
+}
+
+// GetRequestTimeout 获取单个请求的整体超时。
+func GetRequestTimeout() time.Duration {
+	return current.RequestTimeout
+}
+
+// GetJobTimeout 获取单个异步任务的整体超时，<=0 表示不限制。
+func GetJobTimeout() time.Duration {
+	return current.JobTimeout
+}
+
+// SetPublishConfirmTimeout 设置点击发布后等待发布结果的最长时间，<=0 时保持原值。
+func SetPublishConfirmTimeout(d time.Duration) {
+	if d > 0 {
+		current.PublishConfirmTimeout = d
+	}
+}
+
+// GetPublishConfirmTimeout 获取点击发布后等待发布结果的最长时间。
+func GetPublishConfirmTimeout() time.Duration {
+	return current.PublishConfirmTimeout
+}
+
+// SetPublishTabTimeout 设置点击发布 TAB 后等待其切换完成的最长时间，<=0 时保持原值。
+func SetPublishTabTimeout(d time.Duration) {
+	if d > 0 {
+		current.PublishTabTimeout = d
+	}
+}
+
+// GetPublishTabTimeout 获取点击发布 TAB 后等待其切换完成、上传面板出现的最长时间。
+func GetPublishTabTimeout() time.Duration {
+	return current.PublishTabTimeout
+}
@@ -0,0 +1,40 @@
+package configs
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// SetTLSFiles 设置 HTTPS 证书与私钥文件，二者需同时提供或同时为空（使用 HTTP）。
+// 设置时会加载一次证书，证书与私钥不匹配或无法读取时返回错误。
+func SetTLSFiles(certFile, keyFile string) error {
+	if err := checkTLSFiles(certFile, keyFile); err != nil {
+		return err
+	}
+
+	current.TLSCert, current.TLSKey = certFile, keyFile
+	return nil
+}
+
+// checkTLSFiles 校验证书与私钥是否成对提供且可以加载。
+func checkTLSFiles(certFile, keyFile string) error {
+	if (certFile == "") != (keyFile == "") {
+		return fmt.Errorf("tls cert and key must be provided together")
+	}
+	if certFile != "" {
+		if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+			return fmt.Errorf("failed to load tls cert %s and key %s: %w", certFile, keyFile, err)
+		}
+	}
+	return nil
+}
+
+// GetTLSFiles 获取 HTTPS 证书与私钥文件，未配置时返回空字符串。
+func GetTLSFiles() (certFile, keyFile string) {
+	return current.TLSCert, current.TLSKey
+}
+
+// IsTLSEnabled 是否以 HTTPS 提供服务。
+func IsTLSEnabled() bool {
+	return current.TLSCert != ""
+}
@@ -0,0 +1,66 @@
+package configs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestCert(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestSetTLSFiles(t *testing.T) {
+	t.Cleanup(func() { _ = SetTLSFiles("", "") })
+
+	dir := t.TempDir()
+	certA, keyA := writeTestCert(t, dir, "a")
+	_, keyB := writeTestCert(t, dir, "b")
+
+	require.NoError(t, SetTLSFiles(certA, keyA))
+	assert.True(t, IsTLSEnabled())
+	cert, key := GetTLSFiles()
+	assert.Equal(t, certA, cert)
+	assert.Equal(t, keyA, key)
+
+	// 校验失败时保留原有配置
+	assert.Error(t, SetTLSFiles(certA, ""))
+	assert.Error(t, SetTLSFiles(certA, keyB))
+	assert.Error(t, SetTLSFiles(filepath.Join(dir, "missing.crt"), keyA))
+	cert, _ = GetTLSFiles()
+	assert.Equal(t, certA, cert)
+
+	require.NoError(t, SetTLSFiles("", ""))
+	assert.False(t, IsTLSEnabled())
+}
@@ -0,0 +1,72 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SetUploadPreviewTolerance 设置图片上传允许缺少的预览数量及判定前需等待预览数量稳定的时间。
+// tolerance 为 0 时严格要求全部预览出现，负数按 0 处理。
+func SetUploadPreviewTolerance(tolerance int, grace time.Duration) {
+	if tolerance < 0 {
+		tolerance = 0
+	}
+	if grace < 0 {
+		grace = 0
+	}
+	current.UploadPreviewTolerance, current.UploadPreviewGrace = tolerance, grace
+}
+
+// GetUploadPreviewTolerance 获取图片上传允许缺少的预览数量及预览数量稳定的等待时间。
+func GetUploadPreviewTolerance() (int, time.Duration) {
+	return current.UploadPreviewTolerance, current.UploadPreviewGrace
+}
+
+// SetImageUploadRetries 设置图片上传停滞时的重试次数及判定停滞的时间，负数按 0 处理；stall 为 0 时不检测停滞。
+func SetImageUploadRetries(retries int, stall time.Duration) {
+	if retries < 0 {
+		retries = 0
+	}
+	if stall < 0 {
+		stall = 0
+	}
+	current.ImageUploadRetries, current.ImageUploadStall = retries, stall
+}
+
+// GetImageUploadRetries 获取图片上传停滞时的重试次数及判定停滞的时间。
+func GetImageUploadRetries() (int, time.Duration) {
+	return current.ImageUploadRetries, current.ImageUploadStall
+}
+
+// normalizeUploadRoot 把上传根目录转换为解析过符号链接的绝对路径，目录必须存在；为空表示不限制。
+func normalizeUploadRoot(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	abs, err := filepath.Abs(raw)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", raw)
+	}
+	return resolved, nil
+}
+
+// GetUploadRoot 获取允许发布的本地文件根目录（已解析为绝对路径），为空表示不限制。
+func GetUploadRoot() string {
+	return current.UploadRoot
+}
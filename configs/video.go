@@ -0,0 +1,32 @@
+package configs
+
+import "time"
+
+// SetVideoLimits 设置上传视频的大小与时长上限，<=0 表示不限制。
+func SetVideoLimits(maxSize int64, maxDuration time.Duration) {
+	current.VideoMaxSize = maxSize
+	current.VideoMaxDuration = maxDuration
+}
+
+// GetVideoMaxSize 获取上传视频的最大文件大小（字节）。
+func GetVideoMaxSize() int64 {
+	return current.VideoMaxSize
+}
+
+// GetVideoMaxDuration 获取上传视频的最大时长。
+func GetVideoMaxDuration() time.Duration {
+	return current.VideoMaxDuration
+}
+
+// SetVideoUploadRetries 设置视频上传失败时的重试次数，负数按 0 处理。
+func SetVideoUploadRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	current.VideoUploadRetries = n
+}
+
+// GetVideoUploadRetries 获取视频上传失败时的重试次数。
+func GetVideoUploadRetries() int {
+	return current.VideoUploadRetries
+}
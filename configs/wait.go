@@ -0,0 +1,103 @@
+package configs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// WaitStrategy 页面导航后的等待策略
+type WaitStrategy string
+
+const (
+	// WaitDefault 保持各动作原有的等待方式
+	WaitDefault WaitStrategy = ""
+	// WaitDOMStable 等待 DOM 稳定
+	WaitDOMStable WaitStrategy = "domstable"
+	// WaitNetworkIdle 等待网络请求空闲
+	WaitNetworkIdle WaitStrategy = "networkidle"
+	// WaitSelector 等待动作对应的关键元素出现
+	WaitSelector WaitStrategy = "selector"
+)
+
+// 可单独配置等待策略的动作类型
+const (
+	WaitActionFeeds       = "feeds"
+	WaitActionSearch      = "search"
+	WaitActionFeedDetail  = "feed_detail"
+	WaitActionUserProfile = "user_profile"
+	WaitActionComment     = "comment"
+	WaitActionInteract    = "interact"
+)
+
+// waitAll 作为动作名时表示对所有动作生效
+const waitAll = "*"
+
+var (
+	waitStrategiesMu sync.RWMutex
+	waitStrategies   = map[string]WaitStrategy{}
+)
+
+// ParseWaitStrategy 解析等待策略名称，空字符串或 default 表示默认行为
+func ParseWaitStrategy(s string) (WaitStrategy, error) {
+	switch strategy := WaitStrategy(strings.ToLower(strings.TrimSpace(s))); strategy {
+	case WaitDefault, "default":
+		return WaitDefault, nil
+	case WaitDOMStable, WaitNetworkIdle, WaitSelector:
+		return strategy, nil
+	default:
+		return WaitDefault, fmt.Errorf("unsupported wait strategy: %s (available: domstable, networkidle, selector)", s)
+	}
+}
+
+// SetWaitStrategies 按 "action=strategy,..." 格式设置等待策略，action 为 * 时对所有动作生效。
+// 例如 "*=domstable,search=networkidle"。
+func SetWaitStrategies(spec string) error {
+	parsed, err := parseWaitStrategies(spec)
+	if err != nil {
+		return err
+	}
+
+	setWaitStrategies(parsed)
+	current.WaitStrategy = spec
+	return nil
+}
+
+// parseWaitStrategies 解析 "action=strategy,..." 格式的等待策略配置。
+func parseWaitStrategies(spec string) (map[string]WaitStrategy, error) {
+	parsed := map[string]WaitStrategy{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		action, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid wait strategy %q, expected action=strategy", item)
+		}
+		strategy, err := ParseWaitStrategy(value)
+		if err != nil {
+			return nil, err
+		}
+		parsed[strings.ToLower(strings.TrimSpace(action))] = strategy
+	}
+	return parsed, nil
+}
+
+func setWaitStrategies(parsed map[string]WaitStrategy) {
+	waitStrategiesMu.Lock()
+	waitStrategies = parsed
+	waitStrategiesMu.Unlock()
+}
+
+// GetWaitStrategy 获取动作的等待策略，未单独配置时使用 * 的配置，都未配置时为默认行为。
+func GetWaitStrategy(action string) WaitStrategy {
+	waitStrategiesMu.RLock()
+	defer waitStrategiesMu.RUnlock()
+
+	if strategy, ok := waitStrategies[action]; ok {
+		return strategy
+	}
+	return waitStrategies[waitAll]
+}
@@ -0,0 +1,23 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetWaitStrategies(t *testing.T) {
+	t.Cleanup(func() { _ = SetWaitStrategies("") })
+
+	require.NoError(t, SetWaitStrategies(""))
+	assert.Equal(t, WaitDefault, GetWaitStrategy(WaitActionSearch))
+
+	require.NoError(t, SetWaitStrategies("*=domstable, search=networkidle, comment=default"))
+	assert.Equal(t, WaitNetworkIdle, GetWaitStrategy(WaitActionSearch))
+	assert.Equal(t, WaitDOMStable, GetWaitStrategy(WaitActionFeeds))
+	assert.Equal(t, WaitDefault, GetWaitStrategy(WaitActionComment))
+
+	assert.Error(t, SetWaitStrategies("search"))
+	assert.Error(t, SetWaitStrategies("search=fast"))
+}
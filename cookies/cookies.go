@@ -1,12 +1,19 @@
 package cookies
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
+// ErrNoXiaohongshuCookies cookies 文件中没有任何小红书域名下的 cookie，
+// 通常是给账号放错了 cookies 文件
+var ErrNoXiaohongshuCookies = errors.New("no xiaohongshu.com cookies found")
+
 type Cookier interface {
 	LoadCookies() ([]byte, error)
 	SaveCookies(data []byte) error
@@ -27,6 +34,7 @@ func NewLoadCookie(path string) Cookier {
 }
 
 // LoadCookies 从文件中加载 cookies。
+// 文件中没有小红书域名下的 cookie 时记录警告，仍然返回文件内容，由调用方决定如何处理。
 func (c *localCookie) LoadCookies() ([]byte, error) {
 
 	data, err := os.ReadFile(c.path)
@@ -34,6 +42,10 @@ func (c *localCookie) LoadCookies() ([]byte, error) {
 		return nil, errors.Wrap(err, "failed to read cookies from tmp file")
 	}
 
+	if err := ValidateDomains(data); err != nil {
+		logrus.Warnf("cookies file %s may belong to another site or account: %v", c.path, err)
+	}
+
 	return data, nil
 }
 
@@ -42,6 +54,33 @@ func (c *localCookie) SaveCookies(data []byte) error {
 	return os.WriteFile(c.path, data, 0644)
 }
 
+// ValidateDomains 检查 cookies JSON 数组中至少有一个 xiaohongshu.com（含子域名）的 cookie。
+// 空数组视为未登录，不算错误。
+func ValidateDomains(data []byte) error {
+	var cks []struct {
+		Domain string `json:"domain"`
+	}
+	if err := json.Unmarshal(data, &cks); err != nil {
+		return errors.Wrap(err, "invalid cookies json")
+	}
+	if len(cks) == 0 {
+		return nil
+	}
+
+	for _, ck := range cks {
+		if isXiaohongshuDomain(ck.Domain) {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrNoXiaohongshuCookies, "%d cookie(s) checked", len(cks))
+}
+
+// isXiaohongshuDomain 判断 cookie 域名是否为 xiaohongshu.com 或其子域名（域名可能带前导点）
+func isXiaohongshuDomain(domain string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(domain), ".")
+	return domain == "xiaohongshu.com" || strings.HasSuffix(domain, ".xiaohongshu.com")
+}
+
 // GetCookiesFilePath 获取 cookies 文件路径。
 // 为了向后兼容，如果旧路径 /tmp/cookies.json 存在，则继续使用；
 // 否则使用当前目录下的 cookies.json
@@ -0,0 +1,19 @@
+package cookies
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDomains(t *testing.T) {
+	assert.NoError(t, ValidateDomains([]byte(`[]`)))
+	assert.NoError(t, ValidateDomains([]byte(`[{"name":"a1","domain":".xiaohongshu.com"}]`)))
+	assert.NoError(t, ValidateDomains([]byte(`[{"name":"x","domain":"example.com"},{"name":"web_session","domain":"www.xiaohongshu.com"}]`)))
+
+	err := ValidateDomains([]byte(`[{"name":"sid","domain":".example.com"},{"name":"t","domain":"evilxiaohongshu.com"}]`))
+	assert.True(t, errors.Is(err, ErrNoXiaohongshuCookies))
+
+	assert.Error(t, ValidateDomains([]byte(`{"cookies":[]}`)))
+}
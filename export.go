@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// ExportFeedsResponse 导出结果
+type ExportFeedsResponse struct {
+	Path   string `json:"path"`
+	Format string `json:"format"`
+	Count  int    `json:"count"`
+}
+
+// ExportFeeds 将 Feed 列表导出为 JSON 或 CSV 文件，文件只能写入账号的 exports 目录
+func (s *XiaohongshuService) ExportFeeds(ctx context.Context, accountID string, feeds []xiaohongshu.Feed, format, path string) (*ExportFeedsResponse, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = ExportFormatJSON
+	}
+	if format != ExportFormatJSON && format != ExportFormatCSV {
+		return nil, fmt.Errorf("unsupported export format %q, expected json or csv", format)
+	}
+
+	dir, err := accounts.ExportsDir(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := resolveExportPath(dir, path, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	records := xiaohongshu.NewFeedRecords(feeds)
+	if err := writeFeedRecords(target, format, records); err != nil {
+		return nil, err
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("导出 %d 条笔记到 %s", len(records), target)
+
+	return &ExportFeedsResponse{
+		Path:   target,
+		Format: format,
+		Count:  len(records),
+	}, nil
+}
+
+// resolveExportPath 计算导出文件的绝对路径，path 为空时按时间戳生成文件名，
+// 相对路径基于 dir 解析，最终路径必须位于 dir 内
+func resolveExportPath(dir, path, format string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = fmt.Sprintf("feeds_%s.%s", time.Now().Format("20060102_150405"), format)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("export path %s is outside of allowed directory %s", path, dir)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to ensure export dir: %w", err)
+	}
+
+	return path, nil
+}
+
+func writeFeedRecords(path, format string, records []xiaohongshu.FeedRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case ExportFormatCSV:
+		w := csv.NewWriter(f)
+		if err := w.Write(xiaohongshu.FeedRecordCSVHeader); err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err := w.Write(r.CSVRow()); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+	default:
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(records); err != nil {
+			return err
+		}
+	}
+
+	return f.Close()
+}
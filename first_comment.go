@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+const (
+	// firstCommentAttempts 查找刚发布笔记的最多次数，笔记发布后可能需要一段时间才出现在主页中
+	firstCommentAttempts = 4
+	// firstCommentRetryInterval 两次查找之间的间隔
+	firstCommentRetryInterval = 5 * time.Second
+)
+
+// postFirstComment 发布成功后在自己的主页中找到新笔记并发表首评。
+// 返回找到的笔记（未找到时为 nil）和评论结果，失败原因记录在结果的 Error 中
+func (s *XiaohongshuService) postFirstComment(ctx context.Context, accountID, title, comment string, publishedAt time.Time) (*xiaohongshu.Feed, *FirstCommentResult) {
+	log := logrus.WithField("account", accounts.DisplayName(accountID))
+	result := &FirstCommentResult{Content: comment}
+
+	// 卡片发布时间精度有限，留出一分钟余量
+	feed, err := s.findPublishedNote(ctx, accountID, title, publishedAt.Add(-time.Minute))
+	if err != nil {
+		log.Warnf("发布成功，但未找到新笔记，无法发表首评: %v", err)
+		result.Error = "未找到刚发布的笔记: " + err.Error()
+		return nil, result
+	}
+
+	posted, err := s.PostCommentToFeed(ctx, accountID, feed.ID, feed.XsecToken, comment, "")
+	if err != nil {
+		log.Warnf("发表首评失败 %s: %v", feed.ID, err)
+		result.Error = err.Error()
+		return feed, result
+	}
+
+	result.Success = true
+	result.CommentID = posted.CommentID
+	result.Content = posted.Content
+	return feed, result
+}
+
+// findPublishedNote 在自己的主页中查找刚发布的笔记，尚未出现时间隔 firstCommentRetryInterval 重试
+func (s *XiaohongshuService) findPublishedNote(ctx context.Context, accountID, title string, since time.Time) (*xiaohongshu.Feed, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewUserProfileAction(page)
+	for attempt := 1; ; attempt++ {
+		var feed *xiaohongshu.Feed
+		err := s.withLoginRetry(accountID, b, func() (err error) {
+			feed, err = action.FindOwnNote(ctx, title, since)
+			return err
+		})
+		if err == nil {
+			s.tokens.remember(accountID, []xiaohongshu.Feed{*feed})
+			return feed, nil
+		}
+		if !errors.Is(err, xiaohongshu.ErrOwnNoteNotFound) || attempt >= firstCommentAttempts {
+			return nil, err
+		}
+
+		logrus.WithField("account", accounts.DisplayName(accountID)).Infof("新笔记暂未出现在主页中，%v 后重试（%d/%d）", firstCommentRetryInterval, attempt, firstCommentAttempts)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(firstCommentRetryInterval):
+		}
+	}
+}
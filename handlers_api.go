@@ -1,17 +1,44 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/moderation"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templating"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
-// respondError 返回错误响应
+// respondError 返回错误响应，请求整体超时导致的失败统一返回 REQUEST_TIMEOUT
 func respondError(c *gin.Context, statusCode int, code, message string, details any) {
+	if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		statusCode = http.StatusGatewayTimeout
+		code = "REQUEST_TIMEOUT"
+		message = fmt.Sprintf("请求超时（超过 %s）", configs.GetRequestTimeout())
+	}
+	writeError(c, statusCode, code, message, details)
+}
+
+// respondServiceError 返回服务调用失败的错误响应。err 是 classifyError 能识别的失败原因
+// （如验证码、登录失效）时使用对应的状态码、错误码和提示，details 保留原始错误；否则同 respondError
+func respondServiceError(c *gin.Context, statusCode int, code, message string, err error) {
+	if class, ok := classifyError(err); ok {
+		writeError(c, class.status, class.code, class.message, err.Error())
+		return
+	}
+	respondError(c, statusCode, code, message, err.Error())
+}
+
+func writeError(c *gin.Context, statusCode int, code, message string, details any) {
 	response := ErrorResponse{
 		Error:   message,
 		Code:    code,
@@ -19,11 +46,61 @@ func respondError(c *gin.Context, statusCode int, code, message string, details
 	}
 
 	logrus.Errorf("%s %s %s %d", c.Request.Method, c.Request.URL.Path,
-		c.GetString("account"), statusCode)
+		accounts.DisplayName(c.GetString("account")), statusCode)
 
 	c.JSON(statusCode, response)
 }
 
+// errorClass 可识别的失败原因对应的响应
+type errorClass struct {
+	status  int
+	code    string
+	message string
+}
+
+// classifyError 用 errors.Is/As 识别无需重试或需要用户处理的失败原因，未识别时返回 false
+func classifyError(err error) (errorClass, bool) {
+	switch {
+	case err == nil:
+		return errorClass{}, false
+	// 出现验证码且未在等待时间内完成，details 中保留验证码类型和页面地址
+	case errors.Is(err, xiaohongshu.ErrCaptchaRequired):
+		return errorClass{http.StatusConflict, "CAPTCHA_REQUIRED", captchaRequiredMessage}, true
+	// 笔记被年龄/内容确认弹窗拦截且无法自动确认，details 中保留笔记 ID 和弹窗提示
+	case errors.Is(err, xiaohongshu.ErrContentGated):
+		return errorClass{http.StatusForbidden, "CONTENT_GATED", contentGatedMessage}, true
+	// 内容受地区限制，重试无效，details 中保留被限制的 ID 和页面提示
+	case errors.Is(err, xiaohongshu.ErrRegionBlocked):
+		return errorClass{http.StatusUnavailableForLegalReasons, "REGION_BLOCKED", regionBlockedMessage}, true
+	// 登录状态失效且重新加载 cookies 后仍未恢复，需要重新扫码登录
+	case errors.Is(err, xiaohongshu.ErrNotLoggedIn):
+		return errorClass{http.StatusUnauthorized, "NOT_LOGGED_IN", notLoggedInMessage}, true
+	// 新账号被完善资料弹窗拦截，需要用户手动完成设置
+	case errors.Is(err, xiaohongshu.ErrProfileSetupRequired):
+		return errorClass{http.StatusConflict, "PROFILE_SETUP_REQUIRED", profileSetupRequiredMessage}, true
+	// 等待浏览器名额或账号配置目录超时，而不是笼统的请求超时
+	case errors.Is(err, ErrBrowserBusy):
+		return errorClass{http.StatusServiceUnavailable, "BROWSER_BUSY",
+			fmt.Sprintf("同时运行的浏览器已达上限（%d）或账号的浏览器正被占用，请稍后重试", configs.GetMaxBrowsers())}, true
+	}
+	return errorClass{}, false
+}
+
+// profileSetupRequiredMessage 账号需要完善资料时返回给调用方的提示
+const profileSetupRequiredMessage = "账号需要先完善资料，请使用非无头模式打开浏览器手动完成设置后重试"
+
+// notLoggedInMessage 账号登录状态失效时返回给调用方的提示
+const notLoggedInMessage = "账号登录状态已失效，请重新扫码登录"
+
+// regionBlockedMessage 内容受地区限制时返回给调用方的提示
+const regionBlockedMessage = "内容在当前网络所在地区不可见，重试无效，请为账号更换其他地区的代理后重试（set_account_proxy 或 POST /api/v1/accounts/proxy）"
+
+// contentGatedMessage 笔记被内容确认弹窗拦截时返回给调用方的提示
+const contentGatedMessage = "笔记需要在年龄/内容确认弹窗中确认后才能查看，自动确认失败，请使用非无头模式打开浏览器手动确认后重试"
+
+// captchaRequiredMessage 出现验证码时返回给调用方的提示
+const captchaRequiredMessage = "页面出现验证码，请使用非无头模式打开浏览器手动完成验证（服务会在 -captcha_solve_timeout 内等待）后重试"
+
 // respondSuccess 返回成功响应
 func respondSuccess(c *gin.Context, data any, message string) {
 	response := SuccessResponse{
@@ -33,20 +110,19 @@ func respondSuccess(c *gin.Context, data any, message string) {
 	}
 
 	logrus.Infof("%s %s %s %d", c.Request.Method, c.Request.URL.Path,
-		c.GetString("account"), http.StatusOK)
+		accounts.DisplayName(c.GetString("account")), http.StatusOK)
 
 	c.JSON(http.StatusOK, response)
 }
 
+// resolveAccountID 解析请求中的账号，未提供时使用当前活跃账号
 func resolveAccountID(c *gin.Context, raw string) (string, bool) {
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" {
+	resolved, err := accounts.ResolveAccountIDOrActive(raw)
+	if errors.Is(err, accounts.ErrMissingAccountID) {
 		respondError(c, http.StatusBadRequest, "MISSING_ACCOUNT_ID",
-			"缺少账号参数", "account_id is required")
+			"缺少账号参数", "account_id is required when no active account is set")
 		return "", false
 	}
-
-	resolved, err := accounts.ResolveAccountID(trimmed)
 	if err != nil {
 		respondError(c, http.StatusBadRequest, "INVALID_ACCOUNT_ID",
 			"账号格式不正确", err.Error())
@@ -67,10 +143,12 @@ func (s *AppServer) checkLoginStatusHandler(c *gin.Context) {
 		return
 	}
 
-	status, err := s.xiaohongshuService.CheckLoginStatus(c.Request.Context(), accountID)
+	force, _ := strconv.ParseBool(c.Query("force"))
+
+	status, err := s.xiaohongshuService.CheckLoginStatus(c.Request.Context(), accountID, force)
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "STATUS_CHECK_FAILED",
-			"检查登录状态失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "STATUS_CHECK_FAILED",
+			"检查登录状态失败", err)
 		return
 	}
 
@@ -78,6 +156,33 @@ func (s *AppServer) checkLoginStatusHandler(c *gin.Context) {
 	respondSuccess(c, status, "检查登录状态成功")
 }
 
+// warmupHandler 处理 [POST /api/v1/warmup] 请求，预热账号浏览器并检查登录状态
+func (s *AppServer) warmupHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.Warmup(c.Request.Context(), accountID)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "WARMUP_FAILED",
+			"预热失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "预热成功")
+}
+
 // getLoginQrcodeHandler 处理 [GET /api/login/qrcode] 请求。
 // 用于生成并返回登录二维码（Base64 图片 + 超时时间），供前端展示给用户扫码登录。
 func (s *AppServer) getLoginQrcodeHandler(c *gin.Context) {
@@ -88,8 +193,8 @@ func (s *AppServer) getLoginQrcodeHandler(c *gin.Context) {
 
 	result, err := s.xiaohongshuService.GetLoginQrcode(c.Request.Context(), accountID)
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "STATUS_CHECK_FAILED",
-			"获取登录二维码失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "STATUS_CHECK_FAILED",
+			"获取登录二维码失败", err)
 		return
 	}
 
@@ -100,7 +205,7 @@ func (s *AppServer) getLoginQrcodeHandler(c *gin.Context) {
 // publishHandler 发布内容
 func (s *AppServer) publishHandler(c *gin.Context) {
 	var payload struct {
-		AccountID string `json:"account_id" binding:"required"`
+		AccountID string `json:"account_id"`
 		PublishRequest
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
@@ -116,21 +221,75 @@ func (s *AppServer) publishHandler(c *gin.Context) {
 
 	// 执行发布
 	result, err := s.xiaohongshuService.PublishContent(c.Request.Context(), accountID, &payload.PublishRequest)
+	var matchErr *moderation.MatchError
+	if errors.As(err, &matchErr) {
+		respondError(c, http.StatusUnprocessableEntity, "SENSITIVE_CONTENT",
+			"内容包含敏感词", matchErr.Terms)
+		return
+	}
+	var missingVarsErr *templating.MissingVarsError
+	if errors.As(err, &missingVarsErr) {
+		respondError(c, http.StatusBadRequest, "MISSING_TEMPLATE_VARS",
+			"模板缺少变量", missingVarsErr.Vars)
+		return
+	}
+	if errors.Is(err, templating.ErrInvalidTemplate) {
+		respondError(c, http.StatusBadRequest, "INVALID_TEMPLATE",
+			"模板不合法", err.Error())
+		return
+	}
+	if errors.Is(err, xiaohongshu.ErrUploadPathNotAllowed) {
+		respondServiceError(c, http.StatusForbidden, "UPLOAD_PATH_NOT_ALLOWED",
+			"本地文件不在允许上传的目录中", err)
+		return
+	}
+	if errors.Is(err, xiaohongshu.ErrProductPermission) {
+		respondServiceError(c, http.StatusForbidden, "PRODUCT_PERMISSION_DENIED",
+			"账号没有商品权限，无法挂载商品", err)
+		return
+	}
+	var publishedErr *AlreadyPublishedError
+	if errors.As(err, &publishedErr) {
+		respondError(c, http.StatusConflict, "ALREADY_PUBLISHED",
+			"近期已发布过相同的笔记，已跳过本次发布", publishedErr)
+		return
+	}
+	var uploadErr *xiaohongshu.ImageUploadError
+	if errors.As(err, &uploadErr) {
+		respondError(c, http.StatusInternalServerError, "IMAGE_UPLOAD_FAILED",
+			"部分图片上传失败", uploadErr.Failed)
+		return
+	}
+	var downloadErr *downloader.DownloadError
+	if errors.As(err, &downloadErr) {
+		respondError(c, http.StatusBadGateway, "IMAGE_DOWNLOAD_FAILED",
+			"部分图片下载失败", downloadErr.Failed)
+		return
+	}
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "PUBLISH_FAILED",
-			"发布失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "PUBLISH_FAILED",
+			"发布失败", err)
 		return
 	}
 
 	c.Set("account", accountID)
+	if result.Unconfirmed {
+		respondUnconfirmed(c, result)
+		return
+	}
 	respondSuccess(c, result, "发布成功")
 }
 
-// publishVideoHandler 发布视频内容
-func (s *AppServer) publishVideoHandler(c *gin.Context) {
+// respondUnconfirmed 已点击发布但未能确认结果时返回 202，笔记可能已发布，调用方不应直接重试
+func respondUnconfirmed(c *gin.Context, data any) {
+	c.JSON(http.StatusAccepted, SuccessResponse{Success: true, Data: data, Message: publishUnconfirmedStatus})
+}
+
+// publishBatchHandler 批量发布图文，按顺序逐篇发布并返回每篇的结果
+func (s *AppServer) publishBatchHandler(c *gin.Context) {
 	var payload struct {
-		AccountID string `json:"account_id" binding:"required"`
-		PublishVideoRequest
+		AccountID string `json:"account_id"`
+		PublishBatchRequest
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
@@ -143,79 +302,95 @@ func (s *AppServer) publishVideoHandler(c *gin.Context) {
 		return
 	}
 
-	result, err := s.xiaohongshuService.PublishVideo(c.Request.Context(), accountID, &payload.PublishVideoRequest)
-	if err != nil {
-		respondError(c, http.StatusInternalServerError, "PUBLISH_VIDEO_FAILED",
-			"发布视频失败", err.Error())
+	if err := payload.checkDuration(configs.GetRequestTimeout()); err != nil {
+		respondError(c, http.StatusBadRequest, "BATCH_TOO_LONG",
+			"批次预估耗时超过请求超时，请减少篇数、缩短间隔或改用 POST /api/v1/publish/batch/async", err.Error())
 		return
 	}
 
+	result := s.xiaohongshuService.PublishBatch(c.Request.Context(), accountID, &payload.PublishBatchRequest)
+
 	c.Set("account", accountID)
-	respondSuccess(c, result, "发布视频成功")
+	message := fmt.Sprintf("批量发布完成，成功 %d 篇，失败 %d 篇", result.Succeeded, result.Failed)
+	if result.Unconfirmed > 0 {
+		message += fmt.Sprintf("，%d 篇结果未确认（可能已发布，请先在主页确认）", result.Unconfirmed)
+	}
+	respondSuccess(c, result, message)
 }
 
-// listFeedsHandler 获取账号推荐内容列表
-func (s *AppServer) listFeedsHandler(c *gin.Context) {
-	accountID, ok := accountIDFromQuery(c)
+// publishBatchAsyncHandler 异步批量发布图文，立即返回 job_id，批次受 job_timeout 而不是 request_timeout 限制
+func (s *AppServer) publishBatchAsyncHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		PublishBatchRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
 	if !ok {
 		return
 	}
-	// 获取 Feeds 列表
-	result, err := s.xiaohongshuService.ListFeeds(c.Request.Context(), accountID)
+
+	if err := payload.checkDuration(configs.GetJobTimeout()); err != nil {
+		respondError(c, http.StatusBadRequest, "BATCH_TOO_LONG",
+			"批次预估耗时超过任务超时，请减少篇数、缩短间隔或调大 -job_timeout", err.Error())
+		return
+	}
+
+	req := payload.PublishBatchRequest
+	job, err := s.jobs.submit("publish_batch", accountID, func(ctx context.Context) (any, error) {
+		return s.xiaohongshuService.PublishBatch(ctx, accountID, &req), nil
+	})
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "LIST_FEEDS_FAILED",
-			"获取推荐内容列表失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "SUBMIT_JOB_FAILED",
+			"提交异步任务失败", err)
 		return
 	}
 
 	c.Set("account", accountID)
-	respondSuccess(c, result, "获取推荐内容列表成功")
+	c.JSON(http.StatusAccepted, SuccessResponse{Success: true, Data: job, Message: "异步批量发布任务已提交"})
 }
 
-// searchFeedsHandler 搜索Feeds
-func (s *AppServer) searchFeedsHandler(c *gin.Context) {
-	accountID, ok := accountIDFromQuery(c)
-	if !ok {
-		return
+// publishAsyncHandler 异步发布图文，立即返回 job_id，通过 [GET /api/v1/jobs/:id] 查询结果
+func (s *AppServer) publishAsyncHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		PublishRequest
 	}
-
-	keyword := strings.TrimSpace(c.Query("keyword"))
-	if keyword == "" {
-		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
-			"缺少关键词参数", "keyword parameter is required")
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
 		return
 	}
 
-	filters, err := xiaohongshu.NewSearchFilters(
-		strings.TrimSpace(c.Query("sort")),
-		strings.TrimSpace(c.Query("note_type")),
-		strings.TrimSpace(c.Query("publish_time")),
-		strings.TrimSpace(c.Query("search_scope")),
-		strings.TrimSpace(c.Query("distance")),
-	)
-	if err != nil {
-		respondError(c, http.StatusBadRequest, "INVALID_FILTER",
-			"筛选参数不合法", err.Error())
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
 		return
 	}
 
-	// 搜索 Feeds
-	result, err := s.xiaohongshuService.SearchFeeds(c.Request.Context(), accountID, keyword, filters)
+	req := payload.PublishRequest
+	job, err := s.jobs.submit("publish", accountID, func(ctx context.Context) (any, error) {
+		return s.xiaohongshuService.PublishContent(ctx, accountID, &req)
+	})
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "SEARCH_FEEDS_FAILED",
-			"搜索Feeds失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "SUBMIT_JOB_FAILED",
+			"提交异步任务失败", err)
 		return
 	}
 
 	c.Set("account", accountID)
-	respondSuccess(c, result, "搜索Feeds成功")
+	c.JSON(http.StatusAccepted, SuccessResponse{Success: true, Data: job, Message: "异步发布任务已提交"})
 }
 
-// getFeedDetailHandler 获取Feed详情
-func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
+// publishVideoAsyncHandler 异步发布视频，立即返回 job_id
+func (s *AppServer) publishVideoAsyncHandler(c *gin.Context) {
 	var payload struct {
-		AccountID string `json:"account_id" binding:"required"`
-		FeedDetailRequest
+		AccountID string `json:"account_id"`
+		PublishVideoRequest
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
@@ -228,23 +403,56 @@ func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
 		return
 	}
 
-	// 获取 Feed 详情
-	result, err := s.xiaohongshuService.GetFeedDetail(c.Request.Context(), accountID, payload.FeedID, payload.XsecToken)
+	req := payload.PublishVideoRequest
+	job, err := s.jobs.submit("publish_video", accountID, func(ctx context.Context) (any, error) {
+		return s.xiaohongshuService.PublishVideo(ctx, accountID, &req)
+	})
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "GET_FEED_DETAIL_FAILED",
-			"获取Feed详情失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "SUBMIT_JOB_FAILED",
+			"提交异步任务失败", err)
 		return
 	}
 
 	c.Set("account", accountID)
-	respondSuccess(c, result, "获取Feed详情成功")
+	c.JSON(http.StatusAccepted, SuccessResponse{Success: true, Data: job, Message: "异步发布视频任务已提交"})
 }
 
-// userProfileHandler 用户主页
-func (s *AppServer) userProfileHandler(c *gin.Context) {
+// getJobHandler 查询异步任务状态
+func (s *AppServer) getJobHandler(c *gin.Context) {
+	job, ok := s.jobs.get(c.Param("id"))
+	if !ok {
+		respondError(c, http.StatusNotFound, "JOB_NOT_FOUND",
+			"任务不存在或已过期", c.Param("id"))
+		return
+	}
+
+	c.Set("account", job.AccountID)
+	respondSuccess(c, job, "查询任务状态成功")
+}
+
+// cancelJobHandler 取消未结束的异步任务
+func (s *AppServer) cancelJobHandler(c *gin.Context) {
+	job, err := s.jobs.cancel(c.Param("id"))
+	if errors.Is(err, ErrJobNotFound) {
+		respondError(c, http.StatusNotFound, "JOB_NOT_FOUND",
+			"任务不存在或已过期", c.Param("id"))
+		return
+	}
+	if errors.Is(err, ErrJobFinished) {
+		respondError(c, http.StatusConflict, "JOB_FINISHED",
+			"任务已结束，无法取消", job)
+		return
+	}
+
+	c.Set("account", job.AccountID)
+	respondSuccess(c, job, "任务已取消")
+}
+
+// uploadAssetHandler 上传 base64 编码的图片，返回可在发布请求中引用的本地路径
+func (s *AppServer) uploadAssetHandler(c *gin.Context) {
 	var payload struct {
-		AccountID string `json:"account_id" binding:"required"`
-		UserProfileRequest
+		AccountID string `json:"account_id"`
+		UploadAssetRequest
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
@@ -257,23 +465,27 @@ func (s *AppServer) userProfileHandler(c *gin.Context) {
 		return
 	}
 
-	// 获取用户信息
-	result, err := s.xiaohongshuService.UserProfile(c.Request.Context(), accountID, payload.UserID, payload.XsecToken)
+	result, err := s.xiaohongshuService.UploadAsset(c.Request.Context(), accountID, &payload.UploadAssetRequest)
+	if errors.Is(err, downloader.ErrInvalidImageData) {
+		respondError(c, http.StatusBadRequest, "INVALID_IMAGE",
+			"图片数据不合法", err.Error())
+		return
+	}
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "GET_USER_PROFILE_FAILED",
-			"获取用户主页失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "UPLOAD_ASSET_FAILED",
+			"保存图片失败", err)
 		return
 	}
 
 	c.Set("account", accountID)
-	respondSuccess(c, map[string]any{"data": result}, "result.Message")
+	respondSuccess(c, result, "上传图片成功")
 }
 
-// postCommentHandler 发表评论到Feed
-func (s *AppServer) postCommentHandler(c *gin.Context) {
+// publishVideoHandler 发布视频内容
+func (s *AppServer) publishVideoHandler(c *gin.Context) {
 	var payload struct {
-		AccountID string `json:"account_id" binding:"required"`
-		PostCommentRequest
+		AccountID string `json:"account_id"`
+		PublishVideoRequest
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
@@ -286,60 +498,868 @@ func (s *AppServer) postCommentHandler(c *gin.Context) {
 		return
 	}
 
-	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), accountID, payload.FeedID, payload.XsecToken, payload.Content)
+	result, err := s.xiaohongshuService.PublishVideo(c.Request.Context(), accountID, &payload.PublishVideoRequest)
+	var matchErr *moderation.MatchError
+	if errors.As(err, &matchErr) {
+		respondError(c, http.StatusUnprocessableEntity, "SENSITIVE_CONTENT",
+			"内容包含敏感词", matchErr.Terms)
+		return
+	}
+	if errors.Is(err, xiaohongshu.ErrUploadPathNotAllowed) {
+		respondServiceError(c, http.StatusForbidden, "UPLOAD_PATH_NOT_ALLOWED",
+			"本地文件不在允许上传的目录中", err)
+		return
+	}
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "POST_COMMENT_FAILED",
-			"发表评论失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "PUBLISH_VIDEO_FAILED",
+			"发布视频失败", err)
 		return
 	}
 
 	c.Set("account", accountID)
-	respondSuccess(c, result, result.Message)
+	if result.Unconfirmed {
+		respondUnconfirmed(c, result)
+		return
+	}
+	respondSuccess(c, result, "发布视频成功")
 }
 
-// healthHandler 健康检查
-func healthHandler(c *gin.Context) {
-	respondSuccess(c, map[string]any{
-		"status":    "healthy",
-		"service":   "xiaohongshu-mcp",
-		"account":   "ai-report",
-		"timestamp": "now",
-	}, "服务正常")
+// listFeedsHandler 获取账号推荐内容列表
+func (s *AppServer) listFeedsHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
+	}
+	// 获取 Feeds 列表
+	dedupByAuthor, _ := strconv.ParseBool(c.Query("dedup_by_author"))
+	result, err := s.xiaohongshuService.ListFeeds(c.Request.Context(), accountID, strings.TrimSpace(c.Query("note_type")), dedupByAuthor,
+		strings.TrimSpace(c.Query("since_feed_id")))
+	var filterErr *xiaohongshu.FilterError
+	if errors.As(err, &filterErr) {
+		respondError(c, http.StatusBadRequest, "INVALID_FILTER",
+			"筛选参数不合法", filterErr.Fields)
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "LIST_FEEDS_FAILED",
+			"获取推荐内容列表失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取推荐内容列表成功")
 }
 
-// listAccountsHandler 返回所有账号信息
-func (s *AppServer) listAccountsHandler(c *gin.Context) {
-	infos, err := accounts.ListAccounts()
+// hotSearchesHandler 获取热搜词
+func (s *AppServer) hotSearchesHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.GetHotSearches(c.Request.Context(), accountID)
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "LIST_ACCOUNTS_FAILED",
-			"获取账号列表失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "GET_HOT_SEARCHES_FAILED",
+			"获取热搜失败", err)
 		return
 	}
 
-	c.Set("account", "*")
-	respondSuccess(c, map[string]any{"accounts": infos}, "获取账号列表成功")
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取热搜成功")
 }
 
-// setAccountRemarkHandler 更新账号备注
-func (s *AppServer) setAccountRemarkHandler(c *gin.Context) {
-	var payload struct {
-		AccountID string `json:"account_id" binding:"required"`
-		Remark    string `json:"remark"`
+// searchUsersHandler 处理 [GET /api/v1/search/users] 请求，按关键词搜索用户
+func (s *AppServer) searchUsersHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
 	}
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
-			"请求参数错误", err.Error())
+
+	keyword := strings.TrimSpace(c.Query("keyword"))
+	if keyword == "" {
+		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
+			"缺少关键词参数", "keyword parameter is required")
 		return
 	}
 
-	info, err := accounts.SetAccountRemark(payload.AccountID, payload.Remark)
+	result, err := s.xiaohongshuService.SearchUsers(c.Request.Context(), accountID, keyword)
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, "SET_ACCOUNT_REMARK_FAILED",
-			"更新账号备注失败", err.Error())
+		respondServiceError(c, http.StatusInternalServerError, "SEARCH_USERS_FAILED",
+			"搜索用户失败", err)
 		return
 	}
 
-	c.Set("account", info.ID)
-	respondSuccess(c, info, "更新账号备注成功")
+	c.Set("account", accountID)
+	respondSuccess(c, result, "搜索用户成功")
+}
+
+// notificationsHandler 获取未读通知数量
+func (s *AppServer) notificationsHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.GetNotifications(c.Request.Context(), accountID)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "GET_NOTIFICATIONS_FAILED",
+			"获取未读通知失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取未读通知成功")
+}
+
+// searchFeedsHandler 搜索Feeds
+func (s *AppServer) searchFeedsHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
+	}
+
+	keyword, filters, ok := searchParamsFromQuery(c, accountID)
+	if !ok {
+		return
+	}
+
+	// 搜索 Feeds
+	dedupByAuthor, _ := strconv.ParseBool(c.Query("dedup_by_author"))
+	result, err := s.xiaohongshuService.SearchFeeds(c.Request.Context(), accountID, keyword, filters, dedupByAuthor)
+	if err != nil {
+		respondSearchError(c, err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "搜索Feeds成功")
+}
+
+// searchStreamHandler 处理 [GET /api/v1/feeds/search/stream] 请求，滚动加载搜索结果，
+// 每加载出一批新结果推送一个 feeds 事件，结束时推送 done 事件。
+// 推送第一批结果之前失败时按普通接口返回错误，之后失败时推送 error 事件。
+func (s *AppServer) searchStreamHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
+	}
+
+	keyword, filters, ok := searchParamsFromQuery(c, accountID)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+				"请求参数错误", "limit must be a non-negative integer")
+			return
+		}
+		limit = n
+	}
+
+	c.Set("account", accountID)
+
+	started := false
+	total := 0
+	err := s.xiaohongshuService.SearchFeedsStream(c.Request.Context(), accountID, keyword, filters, limit, func(feeds []xiaohongshu.Feed) error {
+		if !started {
+			started = true
+			c.Header("Cache-Control", "no-cache")
+			c.Header("X-Accel-Buffering", "no")
+		}
+		total += len(feeds)
+		c.SSEvent("feeds", gin.H{"feeds": feeds, "count": len(feeds), "total": total})
+		c.Writer.Flush()
+		return c.Request.Context().Err()
+	})
+	if err != nil && !started {
+		respondSearchError(c, err)
+		return
+	}
+	if err != nil {
+		logrus.Errorf("%s %s %s stream failed after %d feeds: %v", c.Request.Method, c.Request.URL.Path,
+			accounts.DisplayName(accountID), total, err)
+		c.SSEvent("error", ErrorResponse{Error: "搜索Feeds失败", Code: "SEARCH_FEEDS_FAILED", Details: err.Error()})
+		c.Writer.Flush()
+		return
+	}
+
+	c.SSEvent("done", gin.H{"total": total})
+	c.Writer.Flush()
+}
+
+// searchParamsFromQuery 解析搜索关键词和筛选参数，未指定的筛选项使用账号的默认搜索筛选，
+// 参数不合法时返回错误响应并返回 false
+func searchParamsFromQuery(c *gin.Context, accountID string) (string, *xiaohongshu.SearchFilters, bool) {
+	keyword := strings.TrimSpace(c.Query("keyword"))
+	if keyword == "" {
+		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
+			"缺少关键词参数", "keyword parameter is required")
+		return "", nil, false
+	}
+
+	filters, err := newAccountSearchFilters(accountID,
+		strings.TrimSpace(c.Query("sort")),
+		strings.TrimSpace(c.Query("note_type")),
+		strings.TrimSpace(c.Query("publish_time")),
+		strings.TrimSpace(c.Query("search_scope")),
+		strings.TrimSpace(c.Query("distance")),
+	)
+	var filterErr *xiaohongshu.FilterError
+	if errors.As(err, &filterErr) {
+		respondError(c, http.StatusBadRequest, "INVALID_FILTER",
+			"筛选参数不合法", filterErr.Fields)
+		return "", nil, false
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_FILTER",
+			"筛选参数不合法", err.Error())
+		return "", nil, false
+	}
+
+	return keyword, filters, true
+}
+
+// respondSearchError 返回搜索失败的错误响应
+func respondSearchError(c *gin.Context, err error) {
+	if errors.Is(err, xiaohongshu.ErrFilterUIChanged) {
+		respondServiceError(c, http.StatusBadGateway, "FILTER_UI_CHANGED",
+			"搜索筛选面板结构已变化，无法应用筛选条件", err)
+		return
+	}
+	respondServiceError(c, http.StatusInternalServerError, "SEARCH_FEEDS_FAILED",
+		"搜索Feeds失败", err)
+}
+
+// getFeedDetailHandler 获取Feed详情
+func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		FeedDetailRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	// 获取 Feed 详情
+	result, err := s.xiaohongshuService.GetFeedDetail(c.Request.Context(), accountID, &payload.FeedDetailRequest)
+	if errors.Is(err, ErrDebugDisabled) {
+		respondServiceError(c, http.StatusForbidden, "DEBUG_DISABLED",
+			"未开启调试，无法返回页面 HTML", err)
+		return
+	}
+	if errors.Is(err, ErrXsecTokenNotFound) {
+		respondServiceError(c, http.StatusNotFound, "XSEC_TOKEN_NOT_FOUND",
+			"未能获取笔记的 xsec_token，请提供 xsec_token 或 keyword", err)
+		return
+	}
+	var debugErr *FeedDetailDebugError
+	if errors.As(err, &debugErr) {
+		respondError(c, http.StatusInternalServerError, "GET_FEED_DETAIL_FAILED",
+			"获取Feed详情失败", gin.H{"error": debugErr.Error(), "html": debugErr.HTML})
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "GET_FEED_DETAIL_FAILED",
+			"获取Feed详情失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取Feed详情成功")
+}
+
+// checkFeedExistsHandler 检查笔记是否存在
+func (s *AppServer) checkFeedExistsHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		FeedDetailRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if payload.XsecToken == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", "xsec_token is required")
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	exists, reason, err := s.xiaohongshuService.CheckFeedExists(c.Request.Context(), accountID, payload.FeedID, payload.XsecToken)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "CHECK_FEED_EXISTS_FAILED",
+			"检查笔记状态失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, &FeedExistsResponse{
+		FeedID: payload.FeedID,
+		Exists: exists,
+		Reason: reason,
+	}, "检查笔记状态成功")
+}
+
+// markNotInterestedHandler 将首页推荐的笔记标记为不感兴趣，笔记不在推荐列表或没有该菜单时 success 为 false
+func (s *AppServer) markNotInterestedHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		FeedID    string `json:"feed_id" binding:"required"`
+		XsecToken string `json:"xsec_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.MarkNotInterested(c.Request.Context(), accountID, payload.FeedID, payload.XsecToken)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "MARK_NOT_INTERESTED_FAILED",
+			"标记不感兴趣失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	c.JSON(http.StatusOK, SuccessResponse{Success: result.Success, Data: result, Message: result.Message})
+}
+
+// commentsHandler 处理 [GET /api/v1/feeds/comments] 请求，滚动加载笔记评论后按关键词和作者筛选
+func (s *AppServer) commentsHandler(c *gin.Context) {
+	accountID, ok := accountIDFromQuery(c)
+	if !ok {
+		return
+	}
+
+	req := &CommentsRequest{
+		FeedID:    strings.TrimSpace(c.Query("feed_id")),
+		XsecToken: strings.TrimSpace(c.Query("xsec_token")),
+		Keyword:   strings.TrimSpace(c.Query("keyword")),
+		Author:    strings.TrimSpace(c.Query("author")),
+	}
+	if req.FeedID == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", "feed_id is required")
+		return
+	}
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+				"请求参数错误", "limit must be a non-negative integer")
+			return
+		}
+		req.Limit = n
+	}
+
+	result, err := s.xiaohongshuService.GetComments(c.Request.Context(), accountID, req)
+	if errors.Is(err, ErrXsecTokenNotFound) {
+		respondServiceError(c, http.StatusNotFound, "XSEC_TOKEN_NOT_FOUND",
+			"未能获取笔记的 xsec_token，请提供 xsec_token", err)
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "GET_COMMENTS_FAILED",
+			"获取评论失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取评论成功")
+}
+
+// shareLinkHandler 获取笔记分享链接
+func (s *AppServer) shareLinkHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		FeedDetailRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if payload.XsecToken == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", "xsec_token is required")
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.GetShareLink(c.Request.Context(), accountID, payload.FeedID, payload.XsecToken)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "GET_SHARE_LINK_FAILED",
+			"获取分享链接失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取分享链接成功")
+}
+
+// userProfileHandler 用户主页
+func (s *AppServer) userProfileHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		UserProfileRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	// 获取用户信息
+	result, err := s.xiaohongshuService.UserProfile(c.Request.Context(), accountID, payload.UserID, payload.XsecToken, payload.Device, payload.NoteType,
+		strings.TrimSpace(payload.SinceFeedID))
+	var filterErr *xiaohongshu.FilterError
+	if errors.As(err, &filterErr) {
+		respondError(c, http.StatusBadRequest, "INVALID_FILTER",
+			"筛选参数不合法", filterErr.Fields)
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "GET_USER_PROFILE_FAILED",
+			"获取用户主页失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, map[string]any{"data": result}, "result.Message")
+}
+
+// postCommentHandler 发表评论到Feed
+func (s *AppServer) postCommentHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		PostCommentRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	sticker, err := normalizeCommentInput(payload.Content, payload.Sticker)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	// 发表评论
+	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), accountID, payload.FeedID, payload.XsecToken, payload.Content, sticker)
+	if errors.Is(err, xiaohongshu.ErrCommentTextMismatch) {
+		respondServiceError(c, http.StatusUnprocessableEntity, "COMMENT_TEXT_MISMATCH",
+			"评论输入框中的内容与请求不一致，未提交评论", err)
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "POST_COMMENT_FAILED",
+			"发表评论失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, result.Message)
+}
+
+// normalizeCommentInput 校验评论内容与表情代码，content 和 sticker 至少填一个，返回规范化后的表情代码
+func normalizeCommentInput(content, sticker string) (string, error) {
+	if strings.TrimSpace(sticker) == "" {
+		if strings.TrimSpace(content) == "" {
+			return "", errors.New("content 和 sticker 不能同时为空")
+		}
+		return "", nil
+	}
+	return xiaohongshu.NormalizeStickerCode(sticker)
+}
+
+// replyCommentHandler 回复Feed下的评论
+func (s *AppServer) replyCommentHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		ReplyCommentRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.ReplyToComment(c.Request.Context(), accountID,
+		payload.FeedID, payload.XsecToken, payload.CommentID, payload.Content, payload.MentionAuthor)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "REPLY_COMMENT_FAILED",
+			"回复评论失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, result.Message)
+}
+
+// replyRecentCommentsHandler 批量回复笔记下的评论
+func (s *AppServer) replyRecentCommentsHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		ReplyRecentCommentsRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := validateReplyBatch(&payload.ReplyRecentCommentsRequest); errors.Is(err, ErrBatchTooLong) {
+		respondError(c, http.StatusBadRequest, "BATCH_TOO_LONG",
+			"批次预估耗时超过请求超时，请减少回复条数（limit）或缩短 interval_seconds", err.Error())
+		return
+	} else if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.ReplyToRecentComments(c.Request.Context(), accountID, &payload.ReplyRecentCommentsRequest)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "REPLY_COMMENTS_FAILED",
+			"批量回复评论失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "批量回复评论完成")
+}
+
+// healthHandler 健康检查
+func healthHandler(c *gin.Context) {
+	respondSuccess(c, map[string]any{
+		"status":    "healthy",
+		"service":   "xiaohongshu-mcp",
+		"account":   "ai-report",
+		"timestamp": "now",
+	}, "服务正常")
+}
+
+// capabilitiesHandler 处理 [GET /api/capabilities] 请求，返回服务版本、支持的 MCP 工具与发布字段
+func capabilitiesHandler(c *gin.Context) {
+	respondSuccess(c, buildCapabilities(), "获取服务能力成功")
+}
+
+// latestNoteHandler 处理 [POST /api/v1/user/latest_note] 请求，返回用户最近发布的笔记
+func (s *AppServer) latestNoteHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		LatestNoteRequest
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.LatestNote(c.Request.Context(), accountID, payload.UserID, payload.XsecToken)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "GET_LATEST_NOTE_FAILED",
+			"获取用户最新笔记失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "获取用户最新笔记成功")
+}
+
+// browserInfoHandler 处理 [GET /api/debug/browser-info] 请求，返回实际使用的浏览器路径、版本及启动参数。
+// 提供或设置了活跃账号时按该账号的配置（代理、配置目录）启动浏览器。
+func (s *AppServer) browserInfoHandler(c *gin.Context) {
+	accountID, err := accounts.ResolveAccountIDOrActive(c.Query("account_id"))
+	if err != nil && !errors.Is(err, accounts.ErrMissingAccountID) {
+		respondError(c, http.StatusBadRequest, "INVALID_ACCOUNT_ID",
+			"账号格式不正确", err.Error())
+		return
+	}
+
+	info, err := s.xiaohongshuService.BrowserInfo(c.Request.Context(), accountID)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "BROWSER_INFO_FAILED",
+			"获取浏览器信息失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, info, "获取浏览器信息成功")
+}
+
+// debugQueryHandler 处理 [POST /api/debug/query] 请求，以账号的登录态打开小红书页面并查询 CSS 选择器，
+// 返回是否找到元素、匹配数量、文本和可见性，用于排查选择器失效。需服务以 -debug 启动。
+func (s *AppServer) debugQueryHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		URL       string `json:"url" binding:"required"`
+		Selector  string `json:"selector" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	result, err := s.xiaohongshuService.QuerySelector(c.Request.Context(), accountID, payload.URL, payload.Selector)
+	if errors.Is(err, ErrDebugDisabled) {
+		respondServiceError(c, http.StatusForbidden, "DEBUG_DISABLED",
+			"未开启调试，无法查询页面元素", err)
+		return
+	}
+	if errors.Is(err, xiaohongshu.ErrURLNotAllowed) {
+		respondError(c, http.StatusBadRequest, "URL_NOT_ALLOWED",
+			"只能访问小红书的 https 地址", err.Error())
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "DEBUG_QUERY_FAILED",
+			"查询页面元素失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, result, "查询页面元素成功")
+}
+
+// listAccountsHandler 返回所有账号信息
+func (s *AppServer) listAccountsHandler(c *gin.Context) {
+	infos, err := accounts.ListAccounts()
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "LIST_ACCOUNTS_FAILED",
+			"获取账号列表失败", err)
+		return
+	}
+
+	c.Set("account", "*")
+	respondSuccess(c, map[string]any{"accounts": infos}, "获取账号列表成功")
+}
+
+// setAccountRemarkHandler 更新账号备注
+func (s *AppServer) setAccountRemarkHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id" binding:"required"`
+		Remark    string `json:"remark"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	info, err := accounts.SetAccountRemark(payload.AccountID, payload.Remark)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "SET_ACCOUNT_REMARK_FAILED",
+			"更新账号备注失败", err)
+		return
+	}
+
+	c.Set("account", info.ID)
+	respondSuccess(c, info, "更新账号备注成功")
+}
+
+// setAccountAliasHandler 为账号设置别名，之后 account_id 可以直接传别名
+func (s *AppServer) setAccountAliasHandler(c *gin.Context) {
+	var payload struct {
+		Alias     string `json:"alias" binding:"required"`
+		AccountID string `json:"account_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, err := accounts.SetAccountAlias(payload.Alias, payload.AccountID)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "SET_ACCOUNT_ALIAS_FAILED",
+			"设置账号别名失败", err.Error())
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, &AccountAliasResponse{Alias: strings.TrimSpace(payload.Alias), AccountID: accountID}, "设置账号别名成功")
+}
+
+// removeAccountAliasHandler 删除账号别名
+func (s *AppServer) removeAccountAliasHandler(c *gin.Context) {
+	alias := c.Param("alias")
+
+	err := accounts.RemoveAccountAlias(alias)
+	if errors.Is(err, accounts.ErrAliasNotFound) {
+		respondServiceError(c, http.StatusNotFound, "ALIAS_NOT_FOUND",
+			"账号别名不存在", err)
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "REMOVE_ACCOUNT_ALIAS_FAILED",
+			"删除账号别名失败", err)
+		return
+	}
+
+	c.Set("account", "*")
+	respondSuccess(c, &AccountAliasResponse{Alias: strings.TrimSpace(alias)}, "删除账号别名成功")
+}
+
+// getActiveAccountHandler 获取当前活跃账号
+func (s *AppServer) getActiveAccountHandler(c *gin.Context) {
+	c.Set("account", "*")
+	respondSuccess(c, &ActiveAccountResponse{AccountID: accounts.ActiveAccount()}, "获取活跃账号成功")
+}
+
+// setActiveAccountHandler 设置当前活跃账号，请求未提供 account_id 时使用该账号，传空字符串清除
+func (s *AppServer) setActiveAccountHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, err := accounts.SetActiveAccount(payload.AccountID)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ACCOUNT_ID",
+			"账号格式不正确", err.Error())
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, &ActiveAccountResponse{AccountID: accountID}, "设置活跃账号成功")
+}
+
+// setAccountSearchDefaultsHandler 设置账号默认的搜索筛选项
+func (s *AppServer) setAccountSearchDefaultsHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id"`
+		accounts.SearchDefaults
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	accountID, ok := resolveAccountID(c, payload.AccountID)
+	if !ok {
+		return
+	}
+
+	info, err := setAccountSearchDefaults(accountID, payload.SearchDefaults)
+	var filterErr *xiaohongshu.FilterError
+	if errors.As(err, &filterErr) {
+		respondError(c, http.StatusBadRequest, "INVALID_FILTER",
+			"筛选参数不合法", filterErr.Fields)
+		return
+	}
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "SET_SEARCH_DEFAULTS_FAILED",
+			"更新账号默认搜索条件失败", err)
+		return
+	}
+
+	c.Set("account", info.ID)
+	respondSuccess(c, info, "更新账号默认搜索条件成功")
+}
+
+// clearImagesHandler 清空账号下载的图片缓存
+func (s *AppServer) clearImagesHandler(c *gin.Context) {
+	accountID, err := accounts.ResolveAccountID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ACCOUNT_ID",
+			"账号标识无效", err.Error())
+		return
+	}
+
+	freed, err := accounts.ClearImages(accountID)
+	if err != nil {
+		respondServiceError(c, http.StatusInternalServerError, "CLEAR_IMAGES_FAILED",
+			"清理图片缓存失败", err)
+		return
+	}
+
+	c.Set("account", accountID)
+	respondSuccess(c, ClearImagesResponse{AccountID: accountID, FreedBytes: freed}, "清理图片缓存成功")
+}
+
+// setAccountProxyHandler 设置账号代理，传空字符串清除
+func (s *AppServer) setAccountProxyHandler(c *gin.Context) {
+	var payload struct {
+		AccountID string `json:"account_id" binding:"required"`
+		Proxy     string `json:"proxy"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	info, err := accounts.SetAccountProxy(payload.AccountID, payload.Proxy)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "SET_ACCOUNT_PROXY_FAILED",
+			"更新账号代理失败", err.Error())
+		return
+	}
+
+	c.Set("account", info.ID)
+	respondSuccess(c, info, "更新账号代理成功")
 }
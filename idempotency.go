@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+)
+
+// idempotencyTTL 幂等记录的保留时间
+const idempotencyTTL = 24 * time.Hour
+
+// idempotencyRecord 一次成功发布的记录
+type idempotencyRecord struct {
+	Response  json.RawMessage `json:"response"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// idempotencyStore 按账号记录发布请求的幂等键，持久化到账号目录下，重启后在 TTL 内仍然有效
+type idempotencyStore struct {
+	mu       sync.Mutex
+	inflight map[string]struct{}
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{inflight: make(map[string]struct{})}
+}
+
+// begin 查找幂等键对应的历史响应。
+// 命中时将响应写入 out 并返回 true；未命中时标记该键为进行中，调用方完成后必须调用 finish。
+func (st *idempotencyStore) begin(accountID, key string, out any) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	records, err := st.load(accountID)
+	if err != nil {
+		return false, err
+	}
+
+	if record, ok := records[key]; ok {
+		if err := json.Unmarshal(record.Response, out); err != nil {
+			return false, fmt.Errorf("failed to decode idempotency record: %w", err)
+		}
+		return true, nil
+	}
+
+	inflightKey := accountID + "/" + key
+	if _, ok := st.inflight[inflightKey]; ok {
+		return false, fmt.Errorf("idempotency_key %s 对应的发布正在进行中", key)
+	}
+	st.inflight[inflightKey] = struct{}{}
+
+	return false, nil
+}
+
+// finish 结束进行中的幂等键，resp 非空时记录为该键的响应
+func (st *idempotencyStore) finish(accountID, key string, resp any) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.inflight, accountID+"/"+key)
+
+	if resp == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	records, err := st.load(accountID)
+	if err != nil {
+		return err
+	}
+	records[key] = idempotencyRecord{Response: data, CreatedAt: time.Now()}
+
+	return st.save(accountID, records)
+}
+
+// load 读取账号的幂等记录并剔除过期项
+func (st *idempotencyStore) load(accountID string) (map[string]idempotencyRecord, error) {
+	path, err := accounts.IdempotencyPath(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]idempotencyRecord)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for key, record := range records {
+		if time.Since(record.CreatedAt) > idempotencyTTL {
+			delete(records, key)
+		}
+	}
+
+	return records, nil
+}
+
+func (st *idempotencyStore) save(accountID string, records map[string]idempotencyRecord) error {
+	path, err := accounts.IdempotencyPath(accountID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// idempotencyKey 为调用方提供的 key 加上操作前缀，避免不同发布类型之间冲突
+func idempotencyKey(kind, key string) string {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return ""
+	}
+	return kind + ":" + key
+}
+
+// withIdempotency 在 key 非空时为发布操作提供幂等保护：
+// 重复的 key 直接返回上次成功的响应，否则执行 fn 并在成功后记录响应。
+func withIdempotency[T any](st *idempotencyStore, accountID, key string, fn func() (*T, error)) (*T, error) {
+	if key == "" {
+		return fn()
+	}
+
+	var previous T
+	hit, err := st.begin(accountID, key, &previous)
+	if err != nil {
+		return nil, err
+	}
+	if hit {
+		logrus.WithField("account", accounts.DisplayName(accountID)).Infof("幂等键 %s 已处理过，返回上次结果", key)
+		return &previous, nil
+	}
+
+	response, err := fn()
+
+	var record any
+	if err == nil && response != nil {
+		record = response
+	}
+	if finishErr := st.finish(accountID, key, record); finishErr != nil {
+		logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("保存幂等记录失败: %v", finishErr)
+	}
+
+	return response, err
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// JobStatus 异步任务状态
+type JobStatus string
+
+const (
+	JobPending  JobStatus = "pending"
+	JobRunning  JobStatus = "running"
+	JobDone     JobStatus = "done"
+	JobFailed   JobStatus = "failed"
+	JobCanceled JobStatus = "canceled"
+)
+
+var (
+	// ErrJobNotFound 任务不存在或已过期
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobFinished 任务已结束，无法取消
+	ErrJobFinished = errors.New("job already finished")
+)
+
+// jobTTL 已结束任务的保留时间
+const jobTTL = 24 * time.Hour
+
+// Job 异步任务
+type Job struct {
+	ID        string          `json:"job_id"`
+	Kind      string          `json:"kind"`
+	AccountID string          `json:"account_id"`
+	Status    JobStatus       `json:"status"`
+	Progress  int             `json:"progress,omitempty"` // 视频上传进度百分比
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// jobManager 进程内异步任务管理，任务状态持久化到数据目录，服务重启后仍可查询。
+// 只在任务创建和状态变化时写文件，上传进度只保存在内存中
+type jobManager struct {
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	cancels map[string]context.CancelFunc // 未结束任务的取消函数
+	version uint64                        // 任务记录的修改序号，每次需要持久化时递增
+
+	saveMu       sync.Mutex // 串行化文件写入，写文件时不持有 mu
+	savedVersion uint64     // 已写入文件的最新序号，避免较旧的快照覆盖较新的记录
+}
+
+func newJobManager() *jobManager {
+	m := &jobManager{jobs: make(map[string]*Job), cancels: make(map[string]context.CancelFunc)}
+	if err := m.load(); err != nil {
+		logrus.Warnf("加载异步任务记录失败: %v", err)
+	}
+	return m
+}
+
+// submit 创建任务并在后台执行 fn，立即返回任务快照
+func (m *jobManager) submit(kind, accountID string, fn func(ctx context.Context) (any, error)) (Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:        id,
+		Kind:      kind,
+		AccountID: accountID,
+		Status:    JobPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// 任务不随提交请求结束而取消，使用独立的 job_timeout，不受 request_timeout 约束
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout := configs.GetJobTimeout(); timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+
+	m.mu.Lock()
+	m.jobs[id] = job
+	m.cancels[id] = cancel
+	data, version := m.snapshotLocked()
+	snapshot := *job
+	m.mu.Unlock()
+
+	m.save(data, version)
+
+	go m.run(ctx, id, fn)
+
+	return snapshot, nil
+}
+
+// get 查询任务快照
+func (m *jobManager) get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// cancel 取消未结束的任务，返回取消后的任务快照。任务已结束时返回 ErrJobFinished
+func (m *jobManager) cancel(id string) (Job, error) {
+	m.mu.Lock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return Job{}, ErrJobNotFound
+	}
+	cancel, ok := m.cancels[id]
+	if !ok {
+		snapshot := *job
+		m.mu.Unlock()
+		return snapshot, ErrJobFinished
+	}
+
+	cancel()
+	delete(m.cancels, id)
+	job.Status = JobCanceled
+	job.Error = "任务已取消"
+	job.UpdatedAt = time.Now()
+	data, version := m.snapshotLocked()
+	snapshot := *job
+	m.mu.Unlock()
+
+	m.save(data, version)
+	return snapshot, nil
+}
+
+func (m *jobManager) run(ctx context.Context, id string, fn func(ctx context.Context) (any, error)) {
+	defer m.finish(id)
+
+	// 视频上传进度只更新内存中的任务状态，不写文件
+	ctx = xiaohongshu.WithProgress(ctx, func(percent int) {
+		m.update(id, func(job *Job) { job.Progress = percent })
+	})
+
+	m.update(id, func(job *Job) {
+		if job.Status == JobPending {
+			job.Status = JobRunning
+		}
+	})
+
+	result, err := runJob(ctx, fn)
+
+	m.update(id, func(job *Job) {
+		// 已取消的任务保留取消状态，不被随后返回的 context canceled 错误覆盖
+		if job.Status == JobCanceled {
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			job.Status = JobFailed
+			job.Error = fmt.Sprintf("任务超时（超过 %s）: %v", configs.GetJobTimeout(), err)
+			return
+		}
+		if err != nil {
+			job.Status = JobFailed
+			job.Error = err.Error()
+			return
+		}
+
+		data, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			job.Status = JobFailed
+			job.Error = fmt.Sprintf("任务已完成，但序列化结果失败: %v", marshalErr)
+			return
+		}
+		job.Status = JobDone
+		job.Result = data
+	})
+}
+
+// runJob 执行任务函数，将 panic（如浏览器操作中的 Must 调用）转换为错误
+func runJob(ctx context.Context, fn func(ctx context.Context) (any, error)) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("任务异常: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// finish 释放任务的 context
+func (m *jobManager) finish(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.cancels[id]; ok {
+		cancel()
+		delete(m.cancels, id)
+	}
+}
+
+// update 修改任务状态，只有 Status 变化时才持久化
+func (m *jobManager) update(id string, mutate func(job *Job)) {
+	m.mu.Lock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	status := job.Status
+	mutate(job)
+	job.UpdatedAt = time.Now()
+	if job.Status == status {
+		m.mu.Unlock()
+		return
+	}
+	data, version := m.snapshotLocked()
+	m.mu.Unlock()
+
+	m.save(data, version)
+}
+
+// load 读取任务记录，剔除过期任务；重启前未结束的任务标记为失败
+func (m *jobManager) load() error {
+	path, err := accounts.JobsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var jobs map[string]*Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, job := range jobs {
+		if time.Since(job.UpdatedAt) > jobTTL {
+			continue
+		}
+		if job.Status == JobPending || job.Status == JobRunning {
+			job.Status = JobFailed
+			job.Error = "服务重启，任务中断"
+		}
+		m.jobs[id] = job
+	}
+
+	return nil
+}
+
+// snapshotLocked 剔除过期任务并序列化任务记录，返回数据和对应的修改序号，调用方需持有锁
+func (m *jobManager) snapshotLocked() ([]byte, uint64) {
+	for id, job := range m.jobs {
+		if job.Status != JobPending && job.Status != JobRunning && time.Since(job.UpdatedAt) > jobTTL {
+			delete(m.jobs, id)
+		}
+	}
+
+	m.version++
+	data, err := json.MarshalIndent(m.jobs, "", "  ")
+	if err != nil {
+		logrus.Warnf("保存异步任务记录失败: %v", err)
+		return nil, m.version
+	}
+	return data, m.version
+}
+
+// save 将 snapshotLocked 生成的数据写入文件，调用方不能持有 mu。
+// 并发保存时较旧的快照不会覆盖已写入的较新记录
+func (m *jobManager) save(data []byte, version uint64) {
+	if data == nil {
+		return
+	}
+
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+
+	if version <= m.savedVersion {
+		return
+	}
+
+	path, err := accounts.JobsPath()
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		logrus.Warnf("保存异步任务记录失败: %v", err)
+		return
+	}
+	m.savedVersion = version
+}
+
+func newJobID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
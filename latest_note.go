@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// LatestNoteRequest 获取用户最新笔记请求
+type LatestNoteRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+}
+
+// LatestNoteResponse 用户最新笔记响应，Note 中的 id 与 xsecToken 可直接用于详情和互动接口
+type LatestNoteResponse struct {
+	UserID      string            `json:"user_id"`
+	Found       bool              `json:"found"`
+	Note        *xiaohongshu.Feed `json:"note,omitempty"`
+	PublishTime int64             `json:"publish_time,omitempty"` // 毫秒时间戳，未能获取详情时为 0
+	Message     string            `json:"message,omitempty"`
+}
+
+// LatestNote 获取用户最近发布的一篇笔记。
+// 主页笔记按发布时间倒序排列但置顶笔记在前，因此打开候选笔记的详情按发布时间比较；
+// 详情均获取失败时退回到第一篇非置顶笔记。
+func (s *XiaohongshuService) LatestNote(ctx context.Context, accountID, userID, xsecToken string) (*LatestNoteResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	profile, err := xiaohongshu.NewUserProfileAction(page).UserProfile(ctx, userID, xsecToken)
+	if err != nil {
+		return nil, err
+	}
+	s.tokens.remember(accountID, profile.Feeds)
+
+	response := &LatestNoteResponse{UserID: userID}
+
+	candidates := xiaohongshu.LatestNoteCandidates(profile.Feeds)
+	if len(candidates) == 0 {
+		response.Message = "该用户还没有发布笔记"
+		return response, nil
+	}
+
+	detailAction := xiaohongshu.NewFeedDetailAction(page)
+	for i := range candidates {
+		feed := &candidates[i]
+		if feed.XsecToken == "" {
+			continue
+		}
+		detail, err := detailAction.GetFeedDetail(ctx, feed.ID, feed.XsecToken)
+		if err != nil {
+			logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("获取候选笔记详情失败 %s: %v", feed.ID, err)
+			continue
+		}
+		if detail.Note.Time > response.PublishTime {
+			response.Note = feed
+			response.PublishTime = detail.Note.Time
+		}
+	}
+
+	if response.Note == nil {
+		// 没有可比较的发布时间：有非置顶笔记时它是最后一个候选项，否则取第一篇置顶笔记
+		fallback := &candidates[len(candidates)-1]
+		if fallback.NoteCard.InteractInfo.Sticky {
+			fallback = &candidates[0]
+		}
+		response.Note = fallback
+		response.Message = "未能获取笔记发布时间，按主页排序返回"
+	}
+	response.Found = true
+
+	return response, nil
+}
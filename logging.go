@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// setupLogging 按配置设置日志级别和格式。
+// 发布流程中的部分步骤日志使用 slog，同样按级别过滤并在 JSON 模式下输出 JSON。
+func setupLogging() {
+	level := configs.GetLogLevel()
+	logrus.SetLevel(level)
+
+	opts := &slog.HandlerOptions{Level: slogLevel(level)}
+	if configs.IsLogJSON() {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, opts)))
+		return
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, opts)))
+}
+
+// slogLevel 将 logrus 级别映射为 slog 级别
+func slogLevel(level logrus.Level) slog.Level {
+	switch {
+	case level >= logrus.DebugLevel:
+		return slog.LevelDebug
+	case level == logrus.InfoLevel:
+		return slog.LevelInfo
+	case level == logrus.WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/browser"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// withLoginRetry 执行 fn，若页面被重定向到登录页（登录状态失效），从账号的 cookies 文件重新加载 cookies 后重试一次。
+// 用于其他进程（如重新扫码登录）已刷新 cookies 文件的场景，重新加载后仍未登录时返回 ErrNotLoggedIn。
+func (s *XiaohongshuService) withLoginRetry(accountID string, b *browser.Browser, fn func() error) error {
+	err := fn()
+	if !errors.Is(err, xiaohongshu.ErrNotLoggedIn) {
+		return err
+	}
+
+	log := logrus.WithField("account", accounts.DisplayName(accountID))
+	s.loginStatus.invalidate(accountID)
+
+	if reloadErr := b.ReloadCookies(); reloadErr != nil {
+		log.Warnf("检测到登录状态失效，重新加载 cookies 失败: %v", reloadErr)
+		s.loginStatus.set(accountID, false)
+		return err
+	}
+
+	log.Warn("检测到登录状态失效，已从 cookies 文件重新加载，重试一次")
+	err = fn()
+	if errors.Is(err, xiaohongshu.ErrNotLoggedIn) {
+		s.loginStatus.set(accountID, false)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// loginStatusCache 按账号缓存最近一次登录状态检查结果，避免每次检查都启动浏览器
+type loginStatusCache struct {
+	mu      sync.Mutex
+	entries map[string]loginStatusEntry
+}
+
+type loginStatusEntry struct {
+	isLoggedIn bool
+	checkedAt  time.Time
+}
+
+func newLoginStatusCache() *loginStatusCache {
+	return &loginStatusCache{entries: make(map[string]loginStatusEntry)}
+}
+
+// get 返回账号在 ttl 内的缓存结果，ttl<=0 或缓存过期时 ok 为 false
+func (c *loginStatusCache) get(accountID string, ttl time.Duration) (isLoggedIn, ok bool) {
+	if ttl <= 0 {
+		return false, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[accountID]
+	if !found || time.Since(entry.checkedAt) > ttl {
+		return false, false
+	}
+	return entry.isLoggedIn, true
+}
+
+func (c *loginStatusCache) set(accountID string, isLoggedIn bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[accountID] = loginStatusEntry{isLoggedIn: isLoggedIn, checkedAt: time.Now()}
+}
+
+func (c *loginStatusCache) invalidate(accountID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, accountID)
+}
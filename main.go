@@ -1,31 +1,20 @@
 package main
 
 import (
-	"flag"
-	"os"
-
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
 func main() {
-	var (
-		headless bool
-		binPath  string // 浏览器二进制文件路径
-	)
-	flag.BoolVar(&headless, "headless", true, "是否无头模式")
-	flag.StringVar(&binPath, "bin", "", "浏览器二进制文件路径")
-	flag.Parse()
-
-	if len(binPath) == 0 {
-		binPath = os.Getenv("ROD_BROWSER_BIN")
+	cfg, err := configs.Load()
+	if err != nil {
+		logrus.Fatalf("invalid config: %v", err)
 	}
-
-	configs.InitHeadless(headless)
-	configs.SetBinPath(binPath)
+	setupLogging()
+	logrus.WithFields(cfg.Summary()).Info("effective config")
 
 	// 初始化服务
-	xiaohongshuService := NewXiaohongshuService()
+	xiaohongshuService := NewXiaohongshuService(cfg, WithCookieFile(cfg.CookiesFile))
 
 	// 创建并启动应用服务器
 	appServer := NewAppServer(xiaohongshuService)
@@ -33,4 +22,3 @@ func main() {
 		logrus.Fatalf("failed to run server: %v", err)
 	}
 }
-
@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,22 +16,10 @@ import (
 
 // MCP 工具处理函数
 
+// accountIDFromArgs 解析工具参数中的账号，未提供时使用当前活跃账号
 func accountIDFromArgs(args map[string]interface{}) (string, error) {
-	if args == nil {
-		return "", accounts.ErrMissingAccountID
-	}
-
-	raw, ok := args["account_id"].(string)
-	if !ok {
-		return "", accounts.ErrMissingAccountID
-	}
-
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" {
-		return "", accounts.ErrMissingAccountID
-	}
-
-	return accounts.ResolveAccountID(trimmed)
+	raw, _ := args["account_id"].(string)
+	return accounts.ResolveAccountIDOrActive(raw)
 }
 
 func accountErrorResult(err error) *MCPToolResult {
@@ -42,6 +32,44 @@ func accountErrorResult(err error) *MCPToolResult {
 	}
 }
 
+// filterErrorResult 筛选参数错误时附带 INVALID_FILTER 结构化详情，列出所有不合法的字段
+func filterErrorResult(prefix string, err error) *MCPToolResult {
+	text := prefix + ": " + err.Error()
+
+	var filterErr *xiaohongshu.FilterError
+	if errors.As(err, &filterErr) {
+		if details, jsonErr := json.MarshalIndent(ErrorResponse{
+			Error:   "筛选参数不合法",
+			Code:    "INVALID_FILTER",
+			Details: filterErr.Fields,
+		}, "", "  "); jsonErr == nil {
+			text = prefix + ": " + string(details)
+		}
+	}
+
+	return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: text}}, IsError: true, err: err}
+}
+
+// errorResult 返回以 text 开头、附带错误信息的失败结果，保留 err 供 processToolCall 识别失败原因
+func errorResult(text string, err error) *MCPToolResult {
+	return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: text + err.Error()}}, IsError: true, err: err}
+}
+
+// successResult 以与 HTTP SuccessResponse 相同的结构（success、data、message）返回工具结果，
+// 客户端可以按统一的 JSON 结构解析，无需从文本中提取数据
+func successResult(data any, message string) *MCPToolResult {
+	return envelopeResult(true, data, message)
+}
+
+// envelopeResult 返回 SuccessResponse 结构的工具结果，success 为 false 时同时标记 IsError
+func envelopeResult(success bool, data any, message string) *MCPToolResult {
+	jsonData, err := json.MarshalIndent(SuccessResponse{Success: success, Data: data, Message: message}, "", "  ")
+	if err != nil {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: fmt.Sprintf("%s，但序列化失败: %v", message, err)}}, IsError: true}
+	}
+	return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: string(jsonData)}}, IsError: !success}
+}
+
 func stringFromArgs(args map[string]interface{}, key string) string {
 	if args == nil {
 		return ""
@@ -86,6 +114,46 @@ func stringSliceFromArgs(args map[string]interface{}, key string) []string {
 	return result
 }
 
+// stringMapFromArgs 读取对象参数，数字、布尔等值转换为字符串
+func stringMapFromArgs(args map[string]interface{}, key string) map[string]string {
+	if args == nil {
+		return nil
+	}
+	items, ok := args[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]string, len(items))
+	for k, v := range items {
+		switch v := v.(type) {
+		case string:
+			result[k] = v
+		case nil:
+			result[k] = ""
+		default:
+			result[k] = fmt.Sprint(v)
+		}
+	}
+	return result
+}
+
+func intFromArgs(args map[string]interface{}, key string) int {
+	if args == nil {
+		return 0
+	}
+	switch v := args[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
+
 // handleCheckLoginStatus 处理检查登录状态
 func (s *AppServer) handleCheckLoginStatus(ctx context.Context, args map[string]interface{}) *MCPToolResult {
 	accountID, err := accountIDFromArgs(args)
@@ -93,26 +161,16 @@ func (s *AppServer) handleCheckLoginStatus(ctx context.Context, args map[string]
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 检查登录状态")
+	force, _ := args["force"].(bool)
 
-	status, err := s.xiaohongshuService.CheckLoginStatus(ctx, accountID)
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 检查登录状态")
+
+	status, err := s.xiaohongshuService.CheckLoginStatus(ctx, accountID, force)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "检查登录状态失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return errorResult("检查登录状态失败: ", err)
 	}
 
-	resultText := fmt.Sprintf("账号 %s 登录状态检查成功: %+v", accountID, status)
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: resultText,
-		}},
-	}
+	return successResult(status, "检查登录状态成功")
 }
 
 // handleGetLoginQrcode 处理获取登录二维码请求。
@@ -123,20 +181,15 @@ func (s *AppServer) handleGetLoginQrcode(ctx context.Context, args map[string]in
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 获取登录扫码图片")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 获取登录扫码图片")
 
 	result, err := s.xiaohongshuService.GetLoginQrcode(ctx, accountID)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{Type: "text", Text: "获取登录扫码图片失败: " + err.Error()}},
-			IsError: true,
-		}
+		return errorResult("获取登录扫码图片失败: ", err)
 	}
 
 	if result.IsLoggedIn {
-		return &MCPToolResult{
-			Content: []MCPContent{{Type: "text", Text: fmt.Sprintf("账号 %s 当前已处于登录状态", accountID)}},
-		}
+		return successResult(result, fmt.Sprintf("账号 %s 当前已处于登录状态", accountID))
 	}
 
 	now := time.Now()
@@ -148,16 +201,21 @@ func (s *AppServer) handleGetLoginQrcode(ctx context.Context, args map[string]in
 		return now.Add(d).Format("2006-01-02 15:04:05")
 	}()
 
-	// 已登录：文本 + 图片
-	contents := []MCPContent{
-		{Type: "text", Text: fmt.Sprintf("请用小红书 App 在 %s 前扫码登录账号 %s 👇", deadline, accountID)},
-		{
-			Type:     "image",
-			MimeType: "image/png",
-			Data:     strings.TrimPrefix(result.Img, "data:image/png;base64,"),
-		},
-	}
-	return &MCPToolResult{Content: contents}
+	// 未登录：结构化结果 + 二维码图片，图片不重复放入 data
+	tool := successResult(map[string]any{
+		"timeout":      result.Timeout,
+		"is_logged_in": false,
+		"deadline":     deadline,
+	}, fmt.Sprintf("请用小红书 App 在 %s 前扫码登录账号 %s 👇", deadline, accountID))
+	if tool.IsError {
+		return tool
+	}
+	tool.Content = append(tool.Content, MCPContent{
+		Type:     "image",
+		MimeType: "image/png",
+		Data:     strings.TrimPrefix(result.Img, "data:image/png;base64,"),
+	})
+	return tool
 }
 
 // handlePublishContent 处理发布内容
@@ -167,7 +225,7 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 发布内容")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 发布内容")
 
 	title := stringFromArgs(args, "title")
 	content := stringFromArgs(args, "content")
@@ -183,7 +241,8 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 			IsError: true,
 		}
 	}
-	if content == "" {
+	template := stringFromArgs(args, "template")
+	if content == "" && template == "" {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
@@ -192,6 +251,15 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 			IsError: true,
 		}
 	}
+	if content != "" && template != "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "发布失败: content与template只能提供一个",
+			}},
+			IsError: true,
+		}
+	}
 	if len(imagePaths) == 0 {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -202,36 +270,66 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 		}
 	}
 
-	logrus.WithField("account", accountID).
+	logrus.WithField("account", accounts.DisplayName(accountID)).
 		Infof("MCP: 发布内容 - 标题: %s, 图片数量: %d, 标签数量: %d", title, len(imagePaths), len(tags))
 
 	// 构建发布请求
 	req := &PublishRequest{
-		Title:   title,
-		Content: content,
-		Images:  imagePaths,
-		Tags:    tags,
+		Title:          title,
+		Content:        content,
+		Images:         imagePaths,
+		Tags:           tags,
+		Template:       template,
+		Vars:           stringMapFromArgs(args, "vars"),
+		ProductIDs:     stringSliceFromArgs(args, "product_ids"),
+		FirstComment:   stringFromArgs(args, "first_comment"),
+		IdempotencyKey: stringFromArgs(args, "idempotency_key"),
 	}
 
 	// 执行发布
 	result, err := s.xiaohongshuService.PublishContent(ctx, accountID, req)
-	if err != nil {
+	var publishedErr *AlreadyPublishedError
+	if errors.As(err, &publishedErr) {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: "发布失败: " + err.Error(),
+				Text: fmt.Sprintf("ALREADY_PUBLISHED: 近期已发布过相同的笔记（%s，发布于 %s），已跳过本次发布",
+					publishedErr.FeedID, publishedErr.PublishedAt.Format("2006-01-02 15:04:05")),
 			}},
 			IsError: true,
 		}
 	}
+	if errors.Is(err, xiaohongshu.ErrProductPermission) {
+		return errorResult("发布失败: 账号没有商品（带货）权限，无法挂载商品，请去掉 product_ids 或先开通权限: ", err)
+	}
+	if err != nil {
+		return errorResult("发布失败: ", err)
+	}
 
-	resultText := fmt.Sprintf("内容发布成功: %+v", result)
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: resultText,
-		}},
+	if result.Unconfirmed {
+		return successResult(result, publishUnconfirmedStatus)
+	}
+	return successResult(result, "内容发布成功")
+}
+
+// handleUploadAsset 上传 base64 编码的图片，返回可在 publish_content 中引用的本地路径
+func (s *AppServer) handleUploadAsset(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
 	}
+
+	data := stringFromArgs(args, "data")
+	if data == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "上传图片失败: 缺少data参数"}}, IsError: true}
+	}
+
+	result, err := s.xiaohongshuService.UploadAsset(ctx, accountID, &UploadAssetRequest{Data: data})
+	if err != nil {
+		return errorResult("上传图片失败: ", err)
+	}
+
+	return successResult(result, "上传图片成功")
 }
 
 // handlePublishVideo 处理发布视频内容
@@ -241,7 +339,7 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 发布视频内容")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 发布视频内容")
 
 	title := stringFromArgs(args, "title")
 	content := stringFromArgs(args, "content")
@@ -259,40 +357,23 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 	}
 
 	req := &PublishVideoRequest{
-		Title:   title,
-		Content: content,
-		Video:   video,
-		Tags:    tags,
+		Title:          title,
+		Content:        content,
+		Video:          video,
+		Tags:           tags,
+		MusicQuery:     stringFromArgs(args, "music_query"),
+		IdempotencyKey: stringFromArgs(args, "idempotency_key"),
 	}
 
 	result, err := s.xiaohongshuService.PublishVideo(ctx, accountID, req)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "发布视频失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
-	}
-
-	data, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: fmt.Sprintf("发布视频成功，但序列化失败: %v", err),
-			}},
-			IsError: true,
-		}
+		return errorResult("发布视频失败: ", err)
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(data),
-		}},
+	if result.Unconfirmed {
+		return successResult(result, publishUnconfirmedStatus)
 	}
+	return successResult(result, "发布视频成功")
 }
 
 // handleListFeeds 处理获取账号推荐内容列表
@@ -302,257 +383,671 @@ func (s *AppServer) handleListFeeds(ctx context.Context, args map[string]interfa
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 获取推荐内容列表")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 获取推荐内容列表")
 
-	result, err := s.xiaohongshuService.ListFeeds(ctx, accountID)
+	dedupByAuthor, _ := args["dedup_by_author"].(bool)
+	result, err := s.xiaohongshuService.ListFeeds(ctx, accountID, stringFromArgs(args, "note_type"), dedupByAuthor,
+		strings.TrimSpace(stringFromArgs(args, "since_feed_id")))
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取推荐内容列表失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return filterErrorResult("获取推荐内容列表失败", err)
 	}
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	return successResult(result, "获取推荐内容列表成功")
+}
+
+// handleGetHotSearches 获取热搜词
+func (s *AppServer) handleGetHotSearches(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: fmt.Sprintf("获取推荐内容列表成功，但序列化失败: %v", err),
-			}},
-			IsError: true,
-		}
+		return accountErrorResult(err)
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 获取热搜")
+
+	result, err := s.xiaohongshuService.GetHotSearches(ctx, accountID)
+	if err != nil {
+		return errorResult("获取热搜失败: ", err)
 	}
+
+	return successResult(result, "获取热搜成功")
 }
 
-func (s *AppServer) handleListAccounts(ctx context.Context) *MCPToolResult {
-	infos, err := accounts.ListAccounts()
+// handleSearchUsers 按关键词搜索用户
+func (s *AppServer) handleSearchUsers(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取账号列表失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return accountErrorResult(err)
 	}
 
-	jsonData, err := json.MarshalIndent(infos, "", "  ")
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取账号列表成功，但序列化失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+	keyword := strings.TrimSpace(stringFromArgs(args, "keyword"))
+	if keyword == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "搜索用户失败: 缺少关键词参数"}}, IsError: true}
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 搜索用户 - 关键词: %s", keyword)
+
+	result, err := s.xiaohongshuService.SearchUsers(ctx, accountID, keyword)
+	if err != nil {
+		return errorResult("搜索用户失败: ", err)
 	}
+
+	return successResult(result, "搜索用户成功")
 }
 
-func (s *AppServer) handleSetAccountRemark(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+// handleGetNotifications 获取未读通知数量
+func (s *AppServer) handleGetNotifications(ctx context.Context, args map[string]interface{}) *MCPToolResult {
 	accountID, err := accountIDFromArgs(args)
 	if err != nil {
 		return accountErrorResult(err)
 	}
-	remark := stringFromArgs(args, "remark")
-	info, err := accounts.SetAccountRemark(accountID, remark)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 获取未读通知")
+
+	result, err := s.xiaohongshuService.GetNotifications(ctx, accountID)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "更新账号备注失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return errorResult("获取未读通知失败: ", err)
 	}
 
-	jsonData, err := json.MarshalIndent(info, "", "  ")
+	return successResult(result, "获取未读通知成功")
+}
+
+// handleListNotifications 获取最近的通知消息
+func (s *AppServer) handleListNotifications(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "更新账号备注成功，但序列化失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return accountErrorResult(err)
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
+	limit := intFromArgs(args, "limit")
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 获取通知列表 - limit: %d", limit)
+
+	result, err := s.xiaohongshuService.ListNotifications(ctx, accountID, limit)
+	if err != nil {
+		return errorResult("获取通知列表失败: ", err)
 	}
+
+	return successResult(result, "获取通知列表成功")
 }
 
-func (s *AppServer) handleLikeFeed(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+// handleMarkNotificationsRead 将所有未读通知标记为已读
+func (s *AppServer) handleMarkNotificationsRead(ctx context.Context, args map[string]interface{}) *MCPToolResult {
 	accountID, err := accountIDFromArgs(args)
 	if err != nil {
 		return accountErrorResult(err)
 	}
 
-	feedID := stringFromArgs(args, "feed_id")
-	if feedID == "" {
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "点赞失败: 缺少feed_id参数"}}, IsError: true}
-	}
-	xsecToken := stringFromArgs(args, "xsec_token")
-	if xsecToken == "" {
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "点赞失败: 缺少xsec_token参数"}}, IsError: true}
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 标记通知已读")
+
+	result, err := s.xiaohongshuService.MarkNotificationsRead(ctx, accountID)
+	if err != nil {
+		return errorResult("标记通知已读失败: ", err)
 	}
-	unlike, _ := args["unlike"].(bool)
 
-	logrus.WithField("account", accountID).
-		Infof("MCP: 点赞操作 - Feed ID: %s, unlike: %v", feedID, unlike)
+	return successResult(result, "标记通知已读成功")
+}
 
-	var result *ActionResult
-	if unlike {
-		result, err = s.xiaohongshuService.UnlikeFeed(ctx, accountID, feedID, xsecToken)
-	} else {
-		result, err = s.xiaohongshuService.LikeFeed(ctx, accountID, feedID, xsecToken)
+// handleValidateSession 处理会话校验请求，已登录时刷新 cookies 有效期
+func (s *AppServer) handleValidateSession(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
 	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 校验会话并刷新 cookies")
+
+	result, err := s.xiaohongshuService.ValidateSession(ctx, accountID)
 	if err != nil {
-		action := "点赞"
-		if unlike {
-			action = "取消点赞"
-		}
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: action + "失败: " + err.Error()}}, IsError: true}
+		return errorResult("校验会话失败: ", err)
 	}
 
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	return successResult(result, "校验会话成功")
+}
+
+func (s *AppServer) handleListAccounts(ctx context.Context) *MCPToolResult {
+	infos, err := accounts.ListAccounts()
 	if err != nil {
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: fmt.Sprintf("%s成功，但序列化失败: %v", result.Message, err)}}, IsError: true}
+		return errorResult("获取账号列表失败: ", err)
 	}
 
-	return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: string(jsonData)}}}
+	return successResult(map[string]any{"accounts": infos}, "获取账号列表成功")
 }
 
-func (s *AppServer) handleFavoriteFeed(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+func (s *AppServer) handleSetAccountRemark(ctx context.Context, args map[string]interface{}) *MCPToolResult {
 	accountID, err := accountIDFromArgs(args)
 	if err != nil {
 		return accountErrorResult(err)
 	}
-
-	feedID := stringFromArgs(args, "feed_id")
-	if feedID == "" {
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "收藏失败: 缺少feed_id参数"}}, IsError: true}
-	}
-	xsecToken := stringFromArgs(args, "xsec_token")
-	if xsecToken == "" {
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "收藏失败: 缺少xsec_token参数"}}, IsError: true}
+	remark := stringFromArgs(args, "remark")
+	info, err := accounts.SetAccountRemark(accountID, remark)
+	if err != nil {
+		return errorResult("更新账号备注失败: ", err)
 	}
-	unfavorite, _ := args["unfavorite"].(bool)
 
-	logrus.WithField("account", accountID).
-		Infof("MCP: 收藏操作 - Feed ID: %s, unfavorite: %v", feedID, unfavorite)
+	return successResult(info, "更新账号备注成功")
+}
 
-	var result *ActionResult
-	if unfavorite {
-		result, err = s.xiaohongshuService.UnfavoriteFeed(ctx, accountID, feedID, xsecToken)
-	} else {
-		result, err = s.xiaohongshuService.FavoriteFeed(ctx, accountID, feedID, xsecToken)
-	}
+// handleSetAccountProxy 设置账号代理
+func (s *AppServer) handleSetAccountProxy(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
-		action := "收藏"
-		if unfavorite {
-			action = "取消收藏"
-		}
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: action + "失败: " + err.Error()}}, IsError: true}
+		return accountErrorResult(err)
 	}
 
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	info, err := accounts.SetAccountProxy(accountID, stringFromArgs(args, "proxy"))
 	if err != nil {
-		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: fmt.Sprintf("%s成功，但序列化失败: %v", result.Message, err)}}, IsError: true}
+		return errorResult("更新账号代理失败: ", err)
 	}
 
-	return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: string(jsonData)}}}
+	return successResult(info, "更新账号代理成功")
 }
 
-// handleSearchFeeds 处理搜索Feeds
-func (s *AppServer) handleSearchFeeds(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+// handleSetAccountAlias 为账号设置别名
+func (s *AppServer) handleSetAccountAlias(ctx context.Context, args map[string]interface{}) *MCPToolResult {
 	accountID, err := accountIDFromArgs(args)
 	if err != nil {
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 搜索Feeds")
+	alias := stringFromArgs(args, "alias")
+	accountID, err = accounts.SetAccountAlias(alias, accountID)
+	if err != nil {
+		return errorResult("设置账号别名失败: ", err)
+	}
 
-	// 解析参数
-	keyword, ok := args["keyword"].(string)
-	if !ok || keyword == "" {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "搜索Feeds失败: 缺少关键词参数",
-			}},
-			IsError: true,
-		}
+	return successResult(&AccountAliasResponse{Alias: strings.TrimSpace(alias), AccountID: accountID}, "设置账号别名成功")
+}
+
+// handleRemoveAccountAlias 删除账号别名
+func (s *AppServer) handleRemoveAccountAlias(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	alias := stringFromArgs(args, "alias")
+	if err := accounts.RemoveAccountAlias(alias); err != nil {
+		return errorResult("删除账号别名失败: ", err)
 	}
 
-	logrus.WithField("account", accountID).Infof("MCP: 搜索Feeds - 关键词: %s", keyword)
+	return successResult(&AccountAliasResponse{Alias: strings.TrimSpace(alias)}, "删除账号别名成功")
+}
 
-	filters, err := xiaohongshu.NewSearchFilters(
-		stringFromArgs(args, "sort"),
-		stringFromArgs(args, "note_type"),
-		stringFromArgs(args, "publish_time"),
-		stringFromArgs(args, "search_scope"),
-		stringFromArgs(args, "distance"),
-	)
+// handleReplyComment 回复Feed下的评论
+func (s *AppServer) handleReplyComment(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "搜索Feeds失败: " + err.Error(),
-			}},
-			IsError: true,
+		return accountErrorResult(err)
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	xsecToken := stringFromArgs(args, "xsec_token")
+	commentID := stringFromArgs(args, "comment_id")
+	content := stringFromArgs(args, "content")
+	for _, required := range []struct{ name, value string }{
+		{"feed_id", feedID}, {"xsec_token", xsecToken}, {"comment_id", commentID}, {"content", content},
+	} {
+		if required.value == "" {
+			return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "回复评论失败: 缺少" + required.name + "参数"}}, IsError: true}
 		}
 	}
+	mentionAuthor, _ := args["mention_author"].(bool)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 回复评论 - Feed ID: %s, Comment ID: %s, 内容长度: %d", feedID, commentID, len(content))
 
-	result, err := s.xiaohongshuService.SearchFeeds(ctx, accountID, keyword, filters)
+	result, err := s.xiaohongshuService.ReplyToComment(ctx, accountID, feedID, xsecToken, commentID, content, mentionAuthor)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "搜索Feeds失败: " + err.Error(),
-			}},
-			IsError: true,
+		return errorResult("回复评论失败: ", err)
+	}
+
+	return successResult(result, result.Message)
+}
+
+// handleReplyRecentComments 批量回复Feed下的评论
+func (s *AppServer) handleReplyRecentComments(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	req := &ReplyRecentCommentsRequest{
+		FeedID:    stringFromArgs(args, "feed_id"),
+		XsecToken: stringFromArgs(args, "xsec_token"),
+		Template:  stringFromArgs(args, "template"),
+	}
+	if req.FeedID == "" || req.XsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "批量回复评论失败: 缺少feed_id或xsec_token参数"}}, IsError: true}
+	}
+	if raw, ok := args["replies"]; ok && raw != nil {
+		data, err := json.Marshal(raw)
+		if err == nil {
+			err = json.Unmarshal(data, &req.Replies)
+		}
+		if err != nil {
+			return errorResult("批量回复评论失败: replies 参数格式错误: ", err)
 		}
 	}
+	if limit, ok := args["limit"].(float64); ok {
+		req.Limit = int(limit)
+	}
+	if interval, ok := args["interval_seconds"].(float64); ok && interval > 0 {
+		req.IntervalSeconds = int(interval)
+	}
+	req.MentionAuthor, _ = args["mention_author"].(bool)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 批量回复评论 - Feed ID: %s, 指定回复: %d, 使用模板: %t", req.FeedID, len(req.Replies), req.Template != "")
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	result, err := s.xiaohongshuService.ReplyToRecentComments(ctx, accountID, req)
+	if err != nil {
+		return errorResult("批量回复评论失败: ", err)
+	}
+
+	return successResult(result, "批量回复评论完成")
+}
+
+// handleLikeComment 点赞或取消点赞评论
+func (s *AppServer) handleLikeComment(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	unlike, _ := args["unlike"].(bool)
+	action := "点赞评论"
+	if unlike {
+		action = "取消点赞评论"
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	xsecToken := stringFromArgs(args, "xsec_token")
+	commentID := stringFromArgs(args, "comment_id")
+	for _, required := range []struct{ name, value string }{
+		{"feed_id", feedID}, {"xsec_token", xsecToken}, {"comment_id", commentID},
+	} {
+		if required.value == "" {
+			return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: action + "失败: 缺少" + required.name + "参数"}}, IsError: true}
+		}
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: %s - Feed ID: %s, Comment ID: %s", action, feedID, commentID)
+
+	result, err := s.xiaohongshuService.LikeComment(ctx, accountID, feedID, xsecToken, commentID, unlike)
+	if errors.Is(err, xiaohongshu.ErrCommentNotFound) {
+		return errorResult(action+"失败: 评论不存在或已被删除，已滚动加载全部评论仍未找到: ", err)
+	}
+	if err != nil {
+		return errorResult(action+"失败: ", err)
+	}
+
+	return successResult(result, result.Message)
+}
+
+// handleSetAccountSearchDefaults 设置账号默认的搜索筛选项
+func (s *AppServer) handleSetAccountSearchDefaults(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	info, err := setAccountSearchDefaults(accountID, accounts.SearchDefaults{
+		Sort:        stringFromArgs(args, "sort"),
+		NoteType:    stringFromArgs(args, "note_type"),
+		PublishTime: stringFromArgs(args, "publish_time"),
+		SearchScope: stringFromArgs(args, "search_scope"),
+		Distance:    stringFromArgs(args, "distance"),
+	})
+	if err != nil {
+		return filterErrorResult("更新账号默认搜索条件失败", err)
+	}
+
+	return successResult(info, "更新账号默认搜索条件成功")
+}
+
+// handleClearImages 清空账号下载的图片缓存
+func (s *AppServer) handleClearImages(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	freed, err := accounts.ClearImages(accountID)
+	if err != nil {
+		return errorResult("清理图片缓存失败: ", err)
+	}
+
+	return successResult(ClearImagesResponse{AccountID: accountID, FreedBytes: freed}, "清理图片缓存成功")
+}
+
+// handleLatestNote 获取用户最近发布的笔记
+func (s *AppServer) handleLatestNote(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	userID := stringFromArgs(args, "user_id")
+	xsecToken := stringFromArgs(args, "xsec_token")
+	if userID == "" || xsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "获取用户最新笔记失败: 缺少user_id或xsec_token参数"}}, IsError: true}
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 获取用户最新笔记 - User ID: %s", userID)
+
+	result, err := s.xiaohongshuService.LatestNote(ctx, accountID, userID, xsecToken)
+	if err != nil {
+		return errorResult("获取用户最新笔记失败: ", err)
+	}
+
+	return successResult(result, "获取用户最新笔记成功")
+}
+
+// handleGetBrowserInfo 返回实际使用的浏览器路径、版本及启动参数
+func (s *AppServer) handleGetBrowserInfo(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil && !errors.Is(err, accounts.ErrMissingAccountID) {
+		return accountErrorResult(err)
+	}
+
+	info, err := s.xiaohongshuService.BrowserInfo(ctx, accountID)
+	if err != nil {
+		return errorResult("获取浏览器信息失败: ", err)
+	}
+
+	return successResult(info, "获取浏览器信息成功")
+}
+
+// handleGetJobStatus 查询异步任务状态
+func (s *AppServer) handleGetJobStatus(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	jobID := stringFromArgs(args, "job_id")
+	if jobID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "查询任务状态失败: 缺少job_id参数"}}, IsError: true}
+	}
+
+	job, ok := s.jobs.get(jobID)
+	if !ok {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "查询任务状态失败: 任务不存在或已过期"}}, IsError: true}
+	}
+
+	return successResult(job, "查询任务状态成功")
+}
+
+// handleCancelJob 取消未结束的异步任务
+func (s *AppServer) handleCancelJob(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	jobID := stringFromArgs(args, "job_id")
+	if jobID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "取消任务失败: 缺少job_id参数"}}, IsError: true}
+	}
+
+	job, err := s.jobs.cancel(jobID)
+	if errors.Is(err, ErrJobNotFound) {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "取消任务失败: 任务不存在或已过期"}}, IsError: true}
+	}
+	if errors.Is(err, ErrJobFinished) {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: fmt.Sprintf("取消任务失败: 任务已结束（%s）", job.Status)}}, IsError: true}
+	}
+
+	return successResult(job, "任务已取消")
+}
+
+// handlePinNote 置顶或取消置顶自己的笔记
+func (s *AppServer) handlePinNote(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	noteID := stringFromArgs(args, "note_id")
+	if noteID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "置顶失败: 缺少note_id参数"}}, IsError: true}
+	}
+	unpin, _ := args["unpin"].(bool)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 置顶操作 - Note ID: %s, unpin: %v", noteID, unpin)
+
+	action := "置顶"
+	if unpin {
+		action = "取消置顶"
+	}
+
+	result, err := s.xiaohongshuService.PinNote(ctx, accountID, noteID, !unpin)
+	if errors.Is(err, xiaohongshu.ErrPinLimitReached) {
+		return errorResult(action+"失败: 置顶笔记数量已达上限，请先取消其他笔记的置顶: ", err)
+	}
+	if err != nil {
+		return errorResult(action+"失败: ", err)
+	}
+
+	return successResult(result, result.Message)
+}
+
+func (s *AppServer) handleLikeFeed(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	if feedID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "点赞失败: 缺少feed_id参数"}}, IsError: true}
+	}
+	xsecToken := stringFromArgs(args, "xsec_token")
+	if xsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "点赞失败: 缺少xsec_token参数"}}, IsError: true}
+	}
+	unlike, _ := args["unlike"].(bool)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 点赞操作 - Feed ID: %s, unlike: %v", feedID, unlike)
+
+	var result *ActionResult
+	if unlike {
+		result, err = s.xiaohongshuService.UnlikeFeed(ctx, accountID, feedID, xsecToken)
+	} else {
+		result, err = s.xiaohongshuService.LikeFeed(ctx, accountID, feedID, xsecToken)
+	}
+	if err != nil {
+		action := "点赞"
+		if unlike {
+			action = "取消点赞"
+		}
+		return errorResult(action+"失败: ", err)
+	}
+
+	return successResult(result, result.Message)
+}
+
+func (s *AppServer) handleFavoriteFeed(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	if feedID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "收藏失败: 缺少feed_id参数"}}, IsError: true}
+	}
+	xsecToken := stringFromArgs(args, "xsec_token")
+	if xsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "收藏失败: 缺少xsec_token参数"}}, IsError: true}
+	}
+	unfavorite, _ := args["unfavorite"].(bool)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 收藏操作 - Feed ID: %s, unfavorite: %v", feedID, unfavorite)
+
+	var result *ActionResult
+	if unfavorite {
+		result, err = s.xiaohongshuService.UnfavoriteFeed(ctx, accountID, feedID, xsecToken)
+	} else {
+		result, err = s.xiaohongshuService.FavoriteFeed(ctx, accountID, feedID, xsecToken)
+	}
+	if err != nil {
+		action := "收藏"
+		if unfavorite {
+			action = "取消收藏"
+		}
+		return errorResult(action+"失败: ", err)
+	}
+
+	return successResult(result, result.Message)
+}
+
+// handleMarkNotInterested 处理将首页推荐的笔记标记为不感兴趣
+func (s *AppServer) handleMarkNotInterested(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	if feedID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "标记不感兴趣失败: 缺少feed_id参数"}}, IsError: true}
+	}
+	xsecToken := stringFromArgs(args, "xsec_token")
+	if xsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "标记不感兴趣失败: 缺少xsec_token参数"}}, IsError: true}
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 标记不感兴趣 - Feed ID: %s", feedID)
+
+	result, err := s.xiaohongshuService.MarkNotInterested(ctx, accountID, feedID, xsecToken)
+	if err != nil {
+		return errorResult("标记不感兴趣失败: ", err)
+	}
+
+	return envelopeResult(result.Success, result, result.Message)
+}
+
+// handleSearchFeeds 处理搜索Feeds
+func (s *AppServer) handleSearchFeeds(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 搜索Feeds")
+
+	// 解析参数
+	keyword, ok := args["keyword"].(string)
+	if !ok || keyword == "" {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: fmt.Sprintf("搜索Feeds成功，但序列化失败: %v", err),
+				Text: "搜索Feeds失败: 缺少关键词参数",
 			}},
 			IsError: true,
 		}
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 搜索Feeds - 关键词: %s", keyword)
+
+	filters, err := newAccountSearchFilters(accountID,
+		stringFromArgs(args, "sort"),
+		stringFromArgs(args, "note_type"),
+		stringFromArgs(args, "publish_time"),
+		stringFromArgs(args, "search_scope"),
+		stringFromArgs(args, "distance"),
+	)
+	if err != nil {
+		return filterErrorResult("搜索Feeds失败", err)
+	}
+
+	dedupByAuthor, _ := args["dedup_by_author"].(bool)
+	result, err := s.xiaohongshuService.SearchFeeds(ctx, accountID, keyword, filters, dedupByAuthor)
+	if errors.Is(err, xiaohongshu.ErrFilterUIChanged) {
+		return errorResult("搜索Feeds失败: 搜索筛选面板结构已变化，请去掉筛选条件重试: ", err)
+	}
+	if err != nil {
+		return errorResult("搜索Feeds失败: ", err)
+	}
+
+	return successResult(result, "搜索Feeds成功")
+}
+
+// handleSearchWithDetails 处理搜索并获取详情
+func (s *AppServer) handleSearchWithDetails(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	keyword := stringFromArgs(args, "keyword")
+	if keyword == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "搜索并获取详情失败: 缺少关键词参数"}}, IsError: true}
+	}
+	topN := intFromArgs(args, "top_n")
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 搜索并获取详情 - 关键词: %s, top_n: %d", keyword, topN)
+
+	filters, err := newAccountSearchFilters(accountID,
+		stringFromArgs(args, "sort"),
+		stringFromArgs(args, "note_type"),
+		stringFromArgs(args, "publish_time"),
+		stringFromArgs(args, "search_scope"),
+		stringFromArgs(args, "distance"),
+	)
+	if err != nil {
+		return filterErrorResult("搜索并获取详情失败", err)
+	}
+
+	result, err := s.xiaohongshuService.SearchWithDetails(ctx, accountID, keyword, filters, topN)
+	if err != nil {
+		return errorResult("搜索并获取详情失败: ", err)
+	}
+
+	return successResult(result, "搜索并获取详情成功")
+}
+
+// handleExportFeeds 处理导出笔记列表，feeds 为已有结果，未提供时按 keyword 重新搜索
+func (s *AppServer) handleExportFeeds(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	format := stringFromArgs(args, "format")
+	path := stringFromArgs(args, "path")
+
+	var feeds []xiaohongshu.Feed
+	if raw, ok := args["feeds"]; ok && raw != nil {
+		data, err := json.Marshal(raw)
+		if err == nil {
+			err = json.Unmarshal(data, &feeds)
+		}
+		if err != nil {
+			return errorResult("导出笔记失败: feeds 参数格式错误: ", err)
+		}
+	} else {
+		keyword := stringFromArgs(args, "keyword")
+		if keyword == "" {
+			return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "导出笔记失败: 需要提供 feeds 或 keyword 参数"}}, IsError: true}
+		}
+
+		filters, err := newAccountSearchFilters(accountID,
+			stringFromArgs(args, "sort"),
+			stringFromArgs(args, "note_type"),
+			stringFromArgs(args, "publish_time"),
+			stringFromArgs(args, "search_scope"),
+			stringFromArgs(args, "distance"),
+		)
+		if err != nil {
+			return filterErrorResult("导出笔记失败", err)
+		}
+
+		result, err := s.xiaohongshuService.SearchFeeds(ctx, accountID, keyword, filters, false)
+		if err != nil {
+			return errorResult("导出笔记失败: 搜索失败: ", err)
+		}
+		feeds = result.Feeds
 	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 导出笔记 - 数量: %d, 格式: %s", len(feeds), format)
+
+	result, err := s.xiaohongshuService.ExportFeeds(ctx, accountID, feeds, format, path)
+	if err != nil {
+		return errorResult("导出笔记失败: ", err)
+	}
+
+	return successResult(result, "导出笔记成功")
 }
 
 // handleGetFeedDetail 处理获取Feed详情
@@ -562,7 +1057,7 @@ func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 获取Feed详情")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 获取Feed详情")
 
 	// 解析参数
 	feedID, ok := args["feed_id"].(string)
@@ -576,48 +1071,120 @@ func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any
 		}
 	}
 
-	xsecToken, ok := args["xsec_token"].(string)
-	if !ok || xsecToken == "" {
+	// xsec_token 可选，未提供时自动查找
+	xsecToken, _ := args["xsec_token"].(string)
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 获取Feed详情 - Feed ID: %s", feedID)
+
+	debugHTML, _ := args["debug_html"].(bool)
+	includeComments, _ := args["include_comments"].(bool)
+
+	result, err := s.xiaohongshuService.GetFeedDetail(ctx, accountID, &FeedDetailRequest{
+		FeedID:          feedID,
+		XsecToken:       xsecToken,
+		Keyword:         stringFromArgs(args, "keyword"),
+		DebugHTML:       debugHTML,
+		IncludeComments: includeComments,
+		CommentLimit:    intFromArgs(args, "comment_limit"),
+	})
+	var debugErr *FeedDetailDebugError
+	if errors.As(err, &debugErr) {
 		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取Feed详情失败: 缺少xsec_token参数",
-			}},
+			Content: []MCPContent{
+				{Type: "text", Text: "获取Feed详情失败: " + debugErr.Error()},
+				{Type: "text", Text: debugErr.HTML},
+			},
 			IsError: true,
 		}
 	}
+	if err != nil {
+		return errorResult("获取Feed详情失败: ", err)
+	}
 
-	logrus.WithField("account", accountID).Infof("MCP: 获取Feed详情 - Feed ID: %s", feedID)
+	return successResult(result, "获取Feed详情成功")
+}
 
-	result, err := s.xiaohongshuService.GetFeedDetail(ctx, accountID, feedID, xsecToken)
+// handleCheckFeedExists 检查笔记是否存在
+func (s *AppServer) handleCheckFeedExists(ctx context.Context, args map[string]any) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取Feed详情失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return accountErrorResult(err)
 	}
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	feedID := stringFromArgs(args, "feed_id")
+	if feedID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "检查笔记状态失败: 缺少feed_id参数"}}, IsError: true}
+	}
+	xsecToken := stringFromArgs(args, "xsec_token")
+	if xsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "检查笔记状态失败: 缺少xsec_token参数"}}, IsError: true}
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 检查笔记状态 - Feed ID: %s", feedID)
+
+	exists, reason, err := s.xiaohongshuService.CheckFeedExists(ctx, accountID, feedID, xsecToken)
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: fmt.Sprintf("获取Feed详情成功，但序列化失败: %v", err),
-			}},
-			IsError: true,
-		}
+		return errorResult("检查笔记状态失败: ", err)
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
+	return successResult(&FeedExistsResponse{FeedID: feedID, Exists: exists, Reason: reason}, "检查笔记状态成功")
+}
+
+// handleGetComments 获取笔记评论，可按关键词和作者筛选
+func (s *AppServer) handleGetComments(ctx context.Context, args map[string]any) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	if feedID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "获取评论失败: 缺少feed_id参数"}}, IsError: true}
+	}
+
+	req := &CommentsRequest{
+		FeedID:    feedID,
+		XsecToken: stringFromArgs(args, "xsec_token"),
+		Keyword:   stringFromArgs(args, "keyword"),
+		Author:    stringFromArgs(args, "author"),
+		Limit:     intFromArgs(args, "limit"),
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 获取评论 - Feed ID: %s, keyword: %s, author: %s", feedID, req.Keyword, req.Author)
+
+	result, err := s.xiaohongshuService.GetComments(ctx, accountID, req)
+	if err != nil {
+		return errorResult("获取评论失败: ", err)
+	}
+
+	return successResult(result, "获取评论成功")
+}
+
+// handleGetShareLink 获取笔记分享链接
+func (s *AppServer) handleGetShareLink(ctx context.Context, args map[string]any) *MCPToolResult {
+	accountID, err := accountIDFromArgs(args)
+	if err != nil {
+		return accountErrorResult(err)
+	}
+
+	feedID := stringFromArgs(args, "feed_id")
+	if feedID == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "获取分享链接失败: 缺少feed_id参数"}}, IsError: true}
 	}
+	xsecToken := stringFromArgs(args, "xsec_token")
+	if xsecToken == "" {
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: "获取分享链接失败: 缺少xsec_token参数"}}, IsError: true}
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 获取分享链接 - Feed ID: %s", feedID)
+
+	result, err := s.xiaohongshuService.GetShareLink(ctx, accountID, feedID, xsecToken)
+	if err != nil {
+		return errorResult("获取分享链接失败: ", err)
+	}
+
+	return successResult(result, "获取分享链接成功")
 }
 
 // handleUserProfile 获取用户主页
@@ -627,7 +1194,7 @@ func (s *AppServer) handleUserProfile(ctx context.Context, args map[string]any)
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 获取用户主页")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 获取用户主页")
 
 	// 解析参数
 	userID, ok := args["user_id"].(string)
@@ -652,37 +1219,15 @@ func (s *AppServer) handleUserProfile(ctx context.Context, args map[string]any)
 		}
 	}
 
-	logrus.WithField("account", accountID).Infof("MCP: 获取用户主页 - User ID: %s", userID)
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("MCP: 获取用户主页 - User ID: %s", userID)
 
-	result, err := s.xiaohongshuService.UserProfile(ctx, accountID, userID, xsecToken)
+	result, err := s.xiaohongshuService.UserProfile(ctx, accountID, userID, xsecToken,
+		stringFromArgs(args, "device"), stringFromArgs(args, "note_type"), strings.TrimSpace(stringFromArgs(args, "since_feed_id")))
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取用户主页失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
-	}
-
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: fmt.Sprintf("获取用户主页，但序列化失败: %v", err),
-			}},
-			IsError: true,
-		}
+		return filterErrorResult("获取用户主页失败", err)
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
-	}
+	return successResult(result, "获取用户主页成功")
 }
 
 // handlePostComment 处理发表评论到Feed
@@ -692,7 +1237,7 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 		return accountErrorResult(err)
 	}
 
-	logrus.WithField("account", accountID).Info("MCP: 发表评论到Feed")
+	logrus.WithField("account", accounts.DisplayName(accountID)).Info("MCP: 发表评论到Feed")
 
 	// 解析参数
 	feedID, ok := args["feed_id"].(string)
@@ -717,38 +1262,25 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 		}
 	}
 
-	content, ok := args["content"].(string)
-	if !ok || content == "" {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "发表评论失败: 缺少content参数",
-			}},
-			IsError: true,
-		}
+	content, _ := args["content"].(string)
+	sticker, _ := args["sticker"].(string)
+	sticker, err = normalizeCommentInput(content, sticker)
+	if err != nil {
+		return errorResult("发表评论失败: ", err)
 	}
 
-	logrus.WithField("account", accountID).
-		Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d", feedID, len(content))
+	logrus.WithField("account", accounts.DisplayName(accountID)).
+		Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d, 表情: %s", feedID, len(content), sticker)
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, accountID, feedID, xsecToken, content)
+	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, accountID, feedID, xsecToken, content, sticker)
+	if errors.Is(err, xiaohongshu.ErrCommentTextMismatch) {
+		return errorResult("发表评论失败: 评论输入框中的内容与请求不一致，未提交评论: ", err)
+	}
 	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "发表评论失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+		return errorResult("发表评论失败: ", err)
 	}
 
-	// 返回成功结果，只包含feed_id
-	resultText := fmt.Sprintf("评论发表成功 - Feed ID: %s", result.FeedID)
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: resultText,
-		}},
-	}
+	// 返回成功结果，包含 feed_id、新评论的 comment_id 和实际发表的文本
+	return successResult(result, result.Message)
 }
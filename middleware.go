@@ -1,20 +1,41 @@
 package main
 
 import (
+	"compress/gzip"
+	"context"
+	"io"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
-// corsMiddleware CORS 中间件
+// corsMiddleware REST API 的 CORS 中间件，只允许 configs.SetCORSOrigins 配置的来源跨域访问，
+// 未配置时不返回任何 CORS 头（仅同源）。/mcp 端点自行处理 CORS，不受影响。
+// 需要注册为全局中间件，未注册 OPTIONS 路由的预检请求才能被处理。
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		origin := c.GetHeader("Origin")
+		if origin == "" || !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if !configs.IsCORSOriginAllowed(origin) {
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
 		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Max-Age", "600")
 
-		if c.Request.Method == "OPTIONS" {
+		if c.Request.Method == http.MethodOptions {
 			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
@@ -23,6 +44,82 @@ func corsMiddleware() gin.HandlerFunc {
 	}
 }
 
+// gzipMinSize 小于该大小的响应不压缩，压缩收益抵不过开销
+const gzipMinSize = 1024
+
+// gzipSkipPaths 不压缩的接口：登录二维码为 base64 图片，压缩收益很小
+var gzipSkipPaths = map[string]bool{
+	"/api/v1/login/qrcode": true,
+}
+
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// gzipMiddleware 客户端支持时对 REST API 的较大 JSON 响应做 gzip 压缩，
+// 已设置 Content-Encoding 的响应与 /mcp（SSE 流式响应）不处理。
+func gzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !configs.IsGzipEnabled() ||
+			!strings.HasPrefix(c.Request.URL.Path, "/api/") ||
+			gzipSkipPaths[c.Request.URL.Path] ||
+			!strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		w := &gzipResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		defer w.close()
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		c.Next()
+	}
+}
+
+// gzipResponseWriter 在首次写入时根据内容大小和类型决定是否压缩
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	gz      *gzip.Writer
+	decided bool
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+
+		header := w.Header()
+		if len(data) >= gzipMinSize && header.Get("Content-Encoding") == "" &&
+			strings.HasPrefix(header.Get("Content-Type"), "application/json") {
+			header.Set("Content-Encoding", "gzip")
+			header.Del("Content-Length")
+
+			w.gz = gzipWriterPool.Get().(*gzip.Writer)
+			w.gz.Reset(w.ResponseWriter)
+		}
+	}
+
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipResponseWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	if err := w.gz.Close(); err != nil {
+		logrus.Warnf("failed to flush gzip response: %v", err)
+	}
+	gzipWriterPool.Put(w.gz)
+	w.gz = nil
+}
+
 // errorHandlingMiddleware 错误处理中间件
 func errorHandlingMiddleware() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
@@ -32,3 +129,26 @@ func errorHandlingMiddleware() gin.HandlerFunc {
 			"服务器内部错误", recovered)
 	})
 }
+
+// requestTimeoutMiddleware 为每个请求设置整体超时，超时后取消浏览器操作。
+// SSE 长连接不受此限制。
+func requestTimeoutMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := configs.GetRequestTimeout()
+		if timeout <= 0 || isSSERequest(c.Request) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func isSSERequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
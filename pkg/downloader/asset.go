@@ -0,0 +1,69 @@
+package downloader
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/h2non/filetype"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidImageData 上传的数据不是合法的 base64 图片或超过大小上限
+var ErrInvalidImageData = errors.New("invalid image data")
+
+// DecodeBase64Image 解码 base64 图片数据，支持 data:image/png;base64, 前缀及 URL 安全编码，忽略空白字符
+func DecodeBase64Image(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if strings.HasPrefix(encoded, "data:") {
+		_, payload, ok := strings.Cut(encoded, ",")
+		if !ok {
+			return nil, errors.Wrap(ErrInvalidImageData, "malformed data url")
+		}
+		encoded = payload
+	}
+	encoded = strings.Join(strings.Fields(encoded), "")
+	if encoded == "" {
+		return nil, errors.Wrap(ErrInvalidImageData, "empty data")
+	}
+
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := enc.DecodeString(encoded); err == nil {
+			return data, nil
+		}
+	}
+	return nil, errors.Wrap(ErrInvalidImageData, "not valid base64")
+}
+
+// SaveImageData 校验图片数据并保存到 savePath，返回本地文件路径。
+// 文件名取内容的 SHA256，同样的图片重复保存时返回同一路径；maxSize<=0 表示不限制大小
+func SaveImageData(savePath string, data []byte, maxSize int64) (string, error) {
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return "", errors.Wrapf(ErrInvalidImageData, "image too large: %d bytes exceeds limit %d", len(data), maxSize)
+	}
+	if !filetype.IsImage(data) {
+		return "", errors.Wrap(ErrInvalidImageData, "data is not a valid image")
+	}
+	kind, err := filetype.Match(data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to detect file type")
+	}
+
+	if err := os.MkdirAll(savePath, 0755); err != nil {
+		return "", errors.Wrap(err, "failed to create save path")
+	}
+
+	hash := sha256.Sum256(data)
+	filePath := filepath.Join(savePath, fmt.Sprintf("asset_%x.%s", hash[:8], kind.Extension))
+	if _, err := os.Stat(filePath); err == nil {
+		return filePath, nil
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", errors.Wrap(err, "failed to save image")
+	}
+	return filePath, nil
+}
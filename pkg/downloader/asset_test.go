@@ -0,0 +1,64 @@
+package downloader
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBase64Image(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(pngHeader)
+
+	for _, input := range []string{
+		encoded,
+		"data:image/png;base64," + encoded,
+		encoded[:10] + "\n" + encoded[10:],
+		base64.RawURLEncoding.EncodeToString(pngHeader),
+	} {
+		data, err := DecodeBase64Image(input)
+		if err != nil {
+			t.Fatalf("DecodeBase64Image(%q) returned error: %v", input, err)
+		}
+		if !bytes.Equal(data, pngHeader) {
+			t.Errorf("DecodeBase64Image(%q) = %v, expected %v", input, data, pngHeader)
+		}
+	}
+
+	for _, input := range []string{"", "data:image/png;base64", "not base64!"} {
+		if _, err := DecodeBase64Image(input); !errors.Is(err, ErrInvalidImageData) {
+			t.Errorf("DecodeBase64Image(%q) error = %v, expected ErrInvalidImageData", input, err)
+		}
+	}
+}
+
+func TestSaveImageData(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := SaveImageData(dir, pngHeader, 0)
+	if err != nil {
+		t.Fatalf("SaveImageData returned error: %v", err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasSuffix(path, ".png") {
+		t.Errorf("SaveImageData path = %s, expected a .png file in %s", path, dir)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, pngHeader) {
+		t.Errorf("saved file content mismatch: %v", err)
+	}
+
+	// 同样的内容返回同一路径
+	again, err := SaveImageData(dir, pngHeader, 0)
+	if err != nil || again != path {
+		t.Errorf("SaveImageData again = %s, %v, expected %s", again, err, path)
+	}
+
+	if _, err := SaveImageData(dir, []byte("hello"), 0); !errors.Is(err, ErrInvalidImageData) {
+		t.Errorf("SaveImageData(non-image) error = %v, expected ErrInvalidImageData", err)
+	}
+	if _, err := SaveImageData(dir, pngHeader, 4); !errors.Is(err, ErrInvalidImageData) {
+		t.Errorf("SaveImageData(too large) error = %v, expected ErrInvalidImageData", err)
+	}
+}
@@ -1,6 +1,7 @@
 package downloader
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"io"
@@ -15,37 +16,73 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Limits 下载限制，防止响应缓慢或恶意的图片服务器拖住发布流程
+type Limits struct {
+	RequestTimeout time.Duration // 单张图片的下载超时，<=0 使用默认值
+	TotalTimeout   time.Duration // ProcessImages 下载全部图片的整体截止时间，<=0 表示不限制
+	MaxRedirects   int           // 最多跟随的重定向次数，0 表示不跟随重定向
+	MaxSize        int64         // 单张图片的最大字节数，<=0 表示不限制
+}
+
+// DefaultLimits 返回默认下载限制
+func DefaultLimits() Limits {
+	return Limits{
+		RequestTimeout: 30 * time.Second,
+		TotalTimeout:   2 * time.Minute,
+		MaxRedirects:   5,
+		MaxSize:        20 << 20,
+	}
+}
+
 // ImageDownloader 图片下载器
 type ImageDownloader struct {
 	savePath   string
 	httpClient *http.Client
+	maxSize    int64
 }
 
 // NewImageDownloader 创建图片下载器
-func NewImageDownloader(savePath string) *ImageDownloader {
+func NewImageDownloader(savePath string, limits Limits) *ImageDownloader {
 	// 确保保存目录存在
 	if err := os.MkdirAll(savePath, 0755); err != nil {
 		panic(fmt.Sprintf("failed to create save path: %v", err))
 	}
 
+	timeout := limits.RequestTimeout
+	if timeout <= 0 {
+		timeout = DefaultLimits().RequestTimeout
+	}
+	maxRedirects := limits.MaxRedirects
+
 	return &ImageDownloader{
 		savePath: savePath,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return nil
+			},
 		},
+		maxSize: limits.MaxSize,
 	}
 }
 
 // DownloadImage 下载图片
 // 返回本地文件路径
-func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
+func (d *ImageDownloader) DownloadImage(ctx context.Context, imageURL string) (string, error) {
 	// 验证URL格式
 	if !d.isValidImageURL(imageURL) {
 		return "", errors.New("invalid image URL format")
 	}
 
 	// 下载图片数据
-	resp, err := d.httpClient.Get(imageURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create request")
+	}
+	resp, err := d.httpClient.Do(req)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to download image")
 	}
@@ -54,12 +91,22 @@ func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("download failed with status: %d", resp.StatusCode)
 	}
+	if d.maxSize > 0 && resp.ContentLength > d.maxSize {
+		return "", fmt.Errorf("image too large: %d bytes exceeds limit %d", resp.ContentLength, d.maxSize)
+	}
 
-	// 读取图片数据
-	imageData, err := io.ReadAll(resp.Body)
+	// 读取图片数据，多读一个字节用于判断是否超过大小上限
+	body := io.Reader(resp.Body)
+	if d.maxSize > 0 {
+		body = io.LimitReader(resp.Body, d.maxSize+1)
+	}
+	imageData, err := io.ReadAll(body)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to read image data")
 	}
+	if d.maxSize > 0 && int64(len(imageData)) > d.maxSize {
+		return "", fmt.Errorf("image too large: exceeds limit %d bytes", d.maxSize)
+	}
 
 	// 检测图片格式
 	kind, err := filetype.Match(imageData)
@@ -89,12 +136,12 @@ func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
 }
 
 // DownloadImages 批量下载图片
-func (d *ImageDownloader) DownloadImages(imageURLs []string) ([]string, error) {
+func (d *ImageDownloader) DownloadImages(ctx context.Context, imageURLs []string) ([]string, error) {
 	var localPaths []string
 	var errs []error
 
 	for _, imageURL := range imageURLs {
-		localPath, err := d.DownloadImage(imageURL)
+		localPath, err := d.DownloadImage(ctx, imageURL)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to download %s: %w", imageURL, err))
 			continue
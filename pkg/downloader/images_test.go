@@ -35,7 +35,7 @@ func TestNewImageDownloader(t *testing.T) {
 	testPath := filepath.Join(tempDir, "test_downloader")
 	defer os.RemoveAll(testPath)
 
-	downloader := NewImageDownloader(testPath)
+	downloader := NewImageDownloader(testPath, DefaultLimits())
 
 	if downloader == nil {
 		t.Fatal("NewImageDownloader returned nil")
@@ -52,7 +52,7 @@ func TestNewImageDownloader(t *testing.T) {
 }
 
 func TestImageDownloader_isValidImageURL(t *testing.T) {
-	downloader := NewImageDownloader(os.TempDir())
+	downloader := NewImageDownloader(os.TempDir(), DefaultLimits())
 
 	tests := []struct {
 		url      string
@@ -76,7 +76,7 @@ func TestImageDownloader_isValidImageURL(t *testing.T) {
 }
 
 func TestImageDownloader_generateFileName(t *testing.T) {
-	downloader := NewImageDownloader(os.TempDir())
+	downloader := NewImageDownloader(os.TempDir(), DefaultLimits())
 
 	url := "https://example.com/image.jpg"
 	extension := "jpg"
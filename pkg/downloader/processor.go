@@ -1,51 +1,84 @@
 package downloader
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // ImageProcessor 图片处理器
 type ImageProcessor struct {
-	downloader *ImageDownloader
+	downloader   *ImageDownloader
+	totalTimeout time.Duration
 }
 
 // NewImageProcessor 创建图片处理器
-func NewImageProcessor(savePath string) *ImageProcessor {
+func NewImageProcessor(savePath string, limits Limits) *ImageProcessor {
 	if strings.TrimSpace(savePath) == "" {
 		panic("savePath is required")
 	}
 
 	return &ImageProcessor{
-		downloader: NewImageDownloader(savePath),
+		downloader:   NewImageDownloader(savePath, limits),
+		totalTimeout: limits.TotalTimeout,
 	}
 }
 
-// ProcessImages 处理图片列表，返回本地文件路径
+// FailedDownload 下载失败的图片
+type FailedDownload struct {
+	Index int    `json:"index"` // 在输入列表中的下标，从 0 开始
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// DownloadError 部分或全部 URL 图片下载失败，Failed 列出每张失败的图片及原因
+type DownloadError struct {
+	Failed []FailedDownload
+}
+
+func (e *DownloadError) Error() string {
+	parts := make([]string, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		parts = append(parts, fmt.Sprintf("#%d %s: %s", f.Index, f.URL, f.Error))
+	}
+	return fmt.Sprintf("failed to download %d image(s): %s", len(e.Failed), strings.Join(parts, "; "))
+}
+
+// ProcessImages 处理图片列表，返回本地文件路径，顺序与输入一致
 // 支持两种输入格式：
 // 1. URL格式 (http/https开头) - 自动下载到本地
 // 2. 本地文件路径 - 直接使用
-func (p *ImageProcessor) ProcessImages(images []string) ([]string, error) {
-	var localPaths []string
-	var urlsToDownload []string
-
-	// 分离URL和本地路径
-	for _, image := range images {
-		if IsImageURL(image) {
-			urlsToDownload = append(urlsToDownload, image)
-		} else {
+// 单张图片下载失败时继续下载其余图片，最后返回 *DownloadError 列出所有失败的图片；
+// 全部下载受 Limits.TotalTimeout 整体截止时间约束。
+func (p *ImageProcessor) ProcessImages(ctx context.Context, images []string) ([]string, error) {
+	if p.totalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.totalTimeout)
+		defer cancel()
+	}
+
+	localPaths := make([]string, 0, len(images))
+	var failed []FailedDownload
+
+	for i, image := range images {
+		if !IsImageURL(image) {
 			// 本地路径直接添加
 			localPaths = append(localPaths, image)
+			continue
 		}
-	}
 
-	// 批量下载URL图片
-	if len(urlsToDownload) > 0 {
-		downloadedPaths, err := p.downloader.DownloadImages(urlsToDownload)
+		// URL 图片原位下载，保持顺序
+		localPath, err := p.downloader.DownloadImage(ctx, image)
 		if err != nil {
-			return nil, fmt.Errorf("failed to download images: %w", err)
+			failed = append(failed, FailedDownload{Index: i, URL: image, Error: err.Error()})
+			continue
 		}
-		localPaths = append(localPaths, downloadedPaths...)
+		localPaths = append(localPaths, localPath)
+	}
+
+	if len(failed) > 0 {
+		return nil, &DownloadError{Failed: failed}
 	}
 
 	if len(localPaths) == 0 {
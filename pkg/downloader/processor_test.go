@@ -0,0 +1,114 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImageProcessor_ProcessImagesKeepsOrder(t *testing.T) {
+	testPath := filepath.Join(os.TempDir(), "test_processor")
+	defer os.RemoveAll(testPath)
+
+	processor := NewImageProcessor(testPath, DefaultLimits())
+
+	images := []string{"/tmp/b.jpg", "/tmp/a.jpg", "/tmp/c.jpg"}
+	paths, err := processor.ProcessImages(context.Background(), images)
+	if err != nil {
+		t.Fatalf("ProcessImages returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(paths, images) {
+		t.Errorf("ProcessImages = %v, expected %v", paths, images)
+	}
+}
+
+// pngHeader 足以被识别为 PNG 的文件头
+var pngHeader = []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A, 0, 0, 0, 0x0D, 'I', 'H', 'D', 'R'}
+
+func TestImageProcessor_ProcessImagesLimits(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pngHeader)
+	})
+	mux.HandleFunc("/large.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(append(pngHeader, make([]byte, 64)...))
+	})
+	mux.HandleFunc("/slow.png", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testPath := t.TempDir()
+	processor := NewImageProcessor(testPath, Limits{
+		RequestTimeout: 200 * time.Millisecond,
+		MaxRedirects:   2,
+		MaxSize:        32,
+	})
+
+	images := []string{
+		server.URL + "/ok.png",
+		"/tmp/local.jpg",
+		server.URL + "/large.png",
+		server.URL + "/slow.png",
+		server.URL + "/loop",
+	}
+	_, err := processor.ProcessImages(context.Background(), images)
+
+	var downloadErr *DownloadError
+	if !errors.As(err, &downloadErr) {
+		t.Fatalf("ProcessImages error = %v, expected *DownloadError", err)
+	}
+	if len(downloadErr.Failed) != 3 {
+		t.Fatalf("failed = %+v, expected 3 failures", downloadErr.Failed)
+	}
+	for i, want := range []struct {
+		index  int
+		reason string
+	}{
+		{2, "too large"},
+		{3, "Timeout"},
+		{4, "redirects"},
+	} {
+		got := downloadErr.Failed[i]
+		if got.Index != want.index || got.URL != images[want.index] || !strings.Contains(got.Error, want.reason) {
+			t.Errorf("failed[%d] = %+v, expected index %d with %q", i, got, want.index, want.reason)
+		}
+	}
+}
+
+func TestImageProcessor_ProcessImagesTotalTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	processor := NewImageProcessor(t.TempDir(), Limits{RequestTimeout: time.Minute, TotalTimeout: 200 * time.Millisecond})
+
+	start := time.Now()
+	_, err := processor.ProcessImages(context.Background(), []string{server.URL + "/a.png", server.URL + "/b.png"})
+	var downloadErr *DownloadError
+	if !errors.As(err, &downloadErr) || len(downloadErr.Failed) != 2 {
+		t.Fatalf("ProcessImages error = %v, expected both downloads to fail", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ProcessImages took %s, expected to stop at the total deadline", elapsed)
+	}
+}
@@ -0,0 +1,124 @@
+package moderation
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EnvWordListPath 敏感词文件路径的环境变量，未设置时不做检查
+const EnvWordListPath = "XHS_MCP_SENSITIVE_WORDS"
+
+// MatchError 内容命中敏感词
+type MatchError struct {
+	Terms []string
+}
+
+func (e *MatchError) Error() string {
+	return fmt.Sprintf("内容包含敏感词: %s", strings.Join(e.Terms, ", "))
+}
+
+// WordList 敏感词列表，匹配时忽略大小写
+type WordList struct {
+	words []string
+}
+
+// LoadWordList 从文件加载敏感词，每行一个，空行和 # 开头的行会被忽略
+func LoadWordList(path string) (*WordList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open sensitive word list failed")
+	}
+	defer f.Close()
+
+	var (
+		words []string
+		seen  = map[string]bool{}
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if key := strings.ToLower(word); !seen[key] {
+			seen[key] = true
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read sensitive word list failed")
+	}
+
+	return &WordList{words: words}, nil
+}
+
+// Match 返回 texts 中命中的敏感词，按词表顺序去重
+func (w *WordList) Match(texts ...string) []string {
+	if w == nil || len(w.words) == 0 {
+		return nil
+	}
+
+	joined := strings.ToLower(strings.Join(texts, "\n"))
+
+	var matched []string
+	for _, word := range w.words {
+		if strings.Contains(joined, strings.ToLower(word)) {
+			matched = append(matched, word)
+		}
+	}
+	return matched
+}
+
+var (
+	cacheMu     sync.Mutex
+	cachedPath  string
+	cachedMod   time.Time
+	cachedWords *WordList
+)
+
+// Check 使用环境变量配置的词表检查内容，未配置时直接通过；命中时返回 *MatchError。
+// 词表文件修改后会自动重新加载。
+func Check(texts ...string) error {
+	path := strings.TrimSpace(os.Getenv(EnvWordListPath))
+	if path == "" {
+		return nil
+	}
+
+	list, err := loadCached(path)
+	if err != nil {
+		return err
+	}
+
+	if matched := list.Match(texts...); len(matched) > 0 {
+		return &MatchError{Terms: matched}
+	}
+	return nil
+}
+
+func loadCached(path string) (*WordList, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "stat sensitive word list failed")
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if cachedWords != nil && cachedPath == path && cachedMod.Equal(info.ModTime()) {
+		return cachedWords, nil
+	}
+
+	list, err := LoadWordList(path)
+	if err != nil {
+		return nil, err
+	}
+	cachedPath, cachedMod, cachedWords = path, info.ModTime(), list
+
+	return list, nil
+}
@@ -0,0 +1,26 @@
+package moderation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# comment\n最好\n\nGuaranteed\n最好\n"), 0o644))
+
+	t.Setenv(EnvWordListPath, "")
+	assert.NoError(t, Check("全网最好"))
+
+	t.Setenv(EnvWordListPath, path)
+	assert.NoError(t, Check("普通标题", "普通内容"))
+
+	err := Check("全网最好", "100% guaranteed")
+	var matchErr *MatchError
+	require.ErrorAs(t, err, &matchErr)
+	assert.Equal(t, []string{"最好", "Guaranteed"}, matchErr.Terms)
+}
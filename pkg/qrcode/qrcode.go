@@ -0,0 +1,126 @@
+// Package qrcode 将登录二维码图片输出到终端或文件，便于在没有图形界面的服务器上扫码登录。
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// finderModules 二维码左上角定位图案的边长（模块数）
+const finderModules = 7
+
+// quietZone 终端输出时二维码四周保留的空白模块数，保证扫码软件能识别
+const quietZone = 2
+
+// DecodeDataURL 解析 data:image/...;base64,... 形式的图片地址，返回图片字节。
+func DecodeDataURL(src string) ([]byte, error) {
+	header, payload, ok := strings.Cut(src, ",")
+	if !ok || !strings.HasPrefix(header, "data:image/") || !strings.HasSuffix(header, ";base64") {
+		return nil, errors.New("qrcode image is not a base64 data url")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode qrcode image failed")
+	}
+	return data, nil
+}
+
+// RenderTerminal 将二维码图片以 ANSI 背景色块输出到终端，每个模块占两个字符宽度。
+// 使用显式的黑白背景色，深色或浅色主题的终端都可以扫码。
+func RenderTerminal(w io.Writer, data []byte) error {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "decode qrcode image failed")
+	}
+
+	modules, err := sampleModules(img)
+	if err != nil {
+		return err
+	}
+
+	const (
+		dark  = "\x1b[40m  "
+		light = "\x1b[47m  "
+		reset = "\x1b[0m"
+	)
+
+	size := len(modules) + 2*quietZone
+	var b strings.Builder
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			my, mx := y-quietZone, x-quietZone
+			if my >= 0 && my < len(modules) && mx >= 0 && mx < len(modules) && modules[my][mx] {
+				b.WriteString(dark)
+			} else {
+				b.WriteString(light)
+			}
+		}
+		b.WriteString(reset + "\n")
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// sampleModules 将二维码图片还原为模块矩阵（true 为深色）。
+// 根据深色区域的边界框定位二维码，以左上角定位图案顶边的长度（7 个模块）推算模块大小，
+// 再取每个模块中心点的颜色。
+func sampleModules(img image.Image) ([][]bool, error) {
+	bounds := img.Bounds()
+
+	minX, minY, maxX, maxY := bounds.Max.X, bounds.Max.Y, bounds.Min.X-1, bounds.Min.Y-1
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if isDark(img, x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < minX || maxY < minY {
+		return nil, errors.New("qrcode image is blank")
+	}
+
+	finder := 0
+	for x := minX; x <= maxX && isDark(img, x, minY); x++ {
+		finder++
+	}
+
+	width := float64(maxX - minX + 1)
+	count := int(math.Round(width * finderModules / float64(finder)))
+	if count < 21 {
+		return nil, fmt.Errorf("qrcode image is not recognized: %d modules", count)
+	}
+	moduleSize := width / float64(count)
+
+	modules := make([][]bool, count)
+	for my := range modules {
+		modules[my] = make([]bool, count)
+		y := minY + int(moduleSize*(float64(my)+0.5))
+		for mx := range modules[my] {
+			x := minX + int(moduleSize*(float64(mx)+0.5))
+			modules[my][mx] = isDark(img, x, y)
+		}
+	}
+	return modules, nil
+}
+
+// isDark 判断像素是否为深色，透明像素视为浅色背景
+func isDark(img image.Image, x, y int) bool {
+	r, g, b, a := img.At(x, y).RGBA()
+	if a < 0x8000 {
+		return false
+	}
+	lum := (299*r + 587*g + 114*b) / 1000
+	return lum < 0x8000
+}
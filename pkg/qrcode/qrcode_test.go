@@ -0,0 +1,111 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testModules 构造带三个定位图案的 21x21 模块矩阵
+func testModules() [][]bool {
+	const size = 21
+	modules := make([][]bool, size)
+	for y := range modules {
+		modules[y] = make([]bool, size)
+		for x := range modules[y] {
+			modules[y][x] = (x*7+y*3)%5 == 0
+		}
+	}
+
+	finder := func(ox, oy int) {
+		for y := -1; y <= 7; y++ {
+			for x := -1; x <= 7; x++ {
+				px, py := ox+x, oy+y
+				if px < 0 || py < 0 || px >= size || py >= size {
+					continue
+				}
+				ring := max(abs(x-3), abs(y-3))
+				modules[py][px] = ring != 2 && ring != 4
+			}
+		}
+	}
+	finder(0, 0)
+	finder(size-7, 0)
+	finder(0, size-7)
+	return modules
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func encodePNG(t *testing.T, modules [][]bool, scale, margin int) []byte {
+	size := len(modules)*scale + 2*margin
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	for my, row := range modules {
+		for mx, dark := range row {
+			if !dark {
+				continue
+			}
+			for y := 0; y < scale; y++ {
+				for x := 0; x < scale; x++ {
+					img.SetGray(margin+mx*scale+x, margin+my*scale+y, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDecodeDataURL(t *testing.T) {
+	data, err := DecodeDataURL("data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("png")))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+
+	_, err = DecodeDataURL("https://example.com/qrcode.png")
+	assert.Error(t, err)
+	_, err = DecodeDataURL("data:image/png;base64,!!!")
+	assert.Error(t, err)
+}
+
+func TestSampleModules(t *testing.T) {
+	modules := testModules()
+
+	for _, scale := range []int{3, 5} {
+		img, err := png.Decode(bytes.NewReader(encodePNG(t, modules, scale, 12)))
+		require.NoError(t, err)
+
+		got, err := sampleModules(img)
+		require.NoError(t, err)
+		assert.Equal(t, modules, got, "scale %d", scale)
+	}
+}
+
+func TestRenderTerminal(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, RenderTerminal(&out, encodePNG(t, testModules(), 4, 8)))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Len(t, lines, 21+2*quietZone)
+
+	blank := encodePNG(t, [][]bool{{false}}, 4, 8)
+	assert.Error(t, RenderTerminal(&out, blank))
+}
@@ -0,0 +1,161 @@
+// Package templating 展开发布内容模板。模板使用 text/template 语法，
+// 占位符可以写成 {{name}} 或 {{.name}}，执行前会检查引用的变量是否都已提供。
+package templating
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTemplate 模板语法错误或执行失败
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// MissingVarsError 模板引用了未提供的变量
+type MissingVarsError struct {
+	Vars []string
+}
+
+func (e *MissingVarsError) Error() string {
+	return fmt.Sprintf("模板缺少变量: %s", strings.Join(e.Vars, ", "))
+}
+
+// builtins text/template 的内置函数，模板中以这些名称调用时不视为变量
+var builtins = map[string]bool{
+	"and": true, "call": true, "html": true, "index": true, "slice": true, "js": true,
+	"len": true, "not": true, "or": true, "print": true, "printf": true, "println": true,
+	"urlquery": true, "eq": true, "ge": true, "gt": true, "le": true, "lt": true, "ne": true,
+}
+
+// Expand 用 vars 依次展开 texts 中的模板，返回展开后的文本。
+// 所有模板引用的变量都需要在 vars 中提供，缺少时返回 *MissingVarsError，列出全部缺少的变量。
+func Expand(vars map[string]string, texts ...string) ([]string, error) {
+	missing := map[string]bool{}
+	for i, text := range texts {
+		refs, err := referencedVars(fmt.Sprintf("template%d", i), text)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range refs {
+			if _, ok := vars[name]; !ok {
+				missing[name] = true
+			}
+		}
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, &MissingVarsError{Vars: names}
+	}
+
+	// 变量同时注册为无参函数，使 {{name}} 与 {{.name}} 等价
+	funcs := template.FuncMap{}
+	for name, value := range vars {
+		if isIdentifier(name) {
+			funcs[name] = func() string { return value }
+		}
+	}
+
+	out := make([]string, 0, len(texts))
+	for i, text := range texts {
+		tmpl, err := template.New(fmt.Sprintf("template%d", i)).
+			Option("missingkey=error").
+			Funcs(funcs).
+			Parse(text)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidTemplate, err.Error())
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, vars); err != nil {
+			return nil, errors.Wrap(ErrInvalidTemplate, err.Error())
+		}
+		out = append(out, sb.String())
+	}
+	return out, nil
+}
+
+// referencedVars 返回模板中引用的变量名：字段 {{.name}} 和非内置函数的标识符 {{name}}
+func referencedVars(name, text string) ([]string, error) {
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(text, "", "", map[string]*parse.Tree{}); err != nil {
+		return nil, errors.Wrap(ErrInvalidTemplate, err.Error())
+	}
+
+	var refs []string
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.ChainNode:
+			walk(n.Node)
+		case *parse.FieldNode:
+			refs = append(refs, n.Ident[0])
+		case *parse.IdentifierNode:
+			if !builtins[n.Ident] {
+				refs = append(refs, n.Ident)
+			}
+		}
+	}
+	walk(tree.Root)
+	return refs, nil
+}
+
+// isIdentifier 判断变量名能否作为模板函数名，与 text/template 对函数名的要求一致
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_':
+		case i == 0 && !unicode.IsLetter(r):
+			return false
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package templating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"city": "上海", "店名": "街角咖啡", "price": "38"}
+
+	out, err := Expand(vars, "{{city}}探店｜{{.店名}}", `人均 {{.price}} 元，{{if .city}}坐标{{city}}{{end}}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"上海探店｜街角咖啡", "人均 38 元，坐标上海"}, out)
+
+	out, err = Expand(nil, "没有占位符")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"没有占位符"}, out)
+
+	out, err = Expand(vars, `{{printf "%s-%s" city .price}}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"上海-38"}, out)
+}
+
+func TestExpandMissingVars(t *testing.T) {
+	_, err := Expand(map[string]string{"city": "上海"}, "{{city}}{{.shop}}", "{{date}} {{.shop}}")
+
+	var missing *MissingVarsError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"date", "shop"}, missing.Vars)
+}
+
+func TestExpandInvalidTemplate(t *testing.T) {
+	_, err := Expand(nil, "{{city")
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	_, err = Expand(nil, "{{end}}")
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+}
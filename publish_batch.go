@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+)
+
+// PublishBatchRequest 批量发布请求，按顺序逐篇发布，单次最多 20 篇
+type PublishBatchRequest struct {
+	Posts []PublishRequest `json:"posts" binding:"required,min=1,max=20,dive"`
+
+	// DelaySeconds 相邻两篇之间的间隔秒数，最长 1 小时
+	DelaySeconds int `json:"delay_seconds" binding:"min=0,max=3600"`
+}
+
+// estimatedPublishDuration 单篇图文发布（打开编辑器、上传图片、等待发布结果）的预估耗时，用于估算批次总时长
+const estimatedPublishDuration = 2 * time.Minute
+
+// ErrBatchTooLong 批次的预估总耗时超过超时限制，执行到一半剩余的项会被取消
+var ErrBatchTooLong = errors.New("batch is estimated to exceed the timeout")
+
+// checkBatchDuration 估算按顺序执行 count 项、每项约 each、相邻间隔 interval 的总耗时，
+// 超过 timeout 时返回 ErrBatchTooLong。timeout<=0 表示不限制
+func checkBatchDuration(count int, each, interval, timeout time.Duration) error {
+	if timeout <= 0 || count <= 0 {
+		return nil
+	}
+	estimated := time.Duration(count)*each + time.Duration(count-1)*interval
+	if estimated > timeout {
+		return fmt.Errorf("%w: %d items take about %s, more than %s", ErrBatchTooLong, count, estimated, timeout)
+	}
+	return nil
+}
+
+// checkDuration 检查批次能否在 timeout 内完成
+func (r *PublishBatchRequest) checkDuration(timeout time.Duration) error {
+	return checkBatchDuration(len(r.Posts), estimatedPublishDuration, time.Duration(r.DelaySeconds)*time.Second, timeout)
+}
+
+// PublishBatchItemResult 单篇发布结果
+type PublishBatchItemResult struct {
+	Index   int              `json:"index"`
+	Title   string           `json:"title"`
+	Success bool             `json:"success"`
+	Result  *PublishResponse `json:"result,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// PublishBatchResponse 批量发布响应
+type PublishBatchResponse struct {
+	Results   []PublishBatchItemResult `json:"results"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	// Unconfirmed 已点击发布但未能确认结果的篇数，这些笔记可能已发布，不计入成功或失败
+	Unconfirmed int `json:"unconfirmed"`
+}
+
+// PublishBatch 为同一账号按顺序发布多篇图文，单篇失败不影响后续发布。
+// 请求被取消后剩余的帖子不再发布，并标记为失败；调用方应先用 checkDuration 拒绝无法在超时内完成的批次。
+func (s *XiaohongshuService) PublishBatch(ctx context.Context, accountID string, req *PublishBatchRequest) *PublishBatchResponse {
+	delay := time.Duration(req.DelaySeconds) * time.Second
+	response := &PublishBatchResponse{
+		Results: make([]PublishBatchItemResult, 0, len(req.Posts)),
+	}
+
+	for i := range req.Posts {
+		post := &req.Posts[i]
+		item := PublishBatchItemResult{Index: i, Title: post.Title}
+
+		if i > 0 && delay > 0 && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			item.Error = "已取消: " + err.Error()
+		} else if result, err := s.PublishContent(ctx, accountID, post); err != nil {
+			logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("批量发布第 %d 篇失败: %v", i+1, err)
+			item.Error = err.Error()
+		} else {
+			item.Success = !result.Unconfirmed
+			item.Result = result
+		}
+
+		switch {
+		case item.Success:
+			response.Succeeded++
+		case item.Result != nil:
+			response.Unconfirmed++
+		default:
+			response.Failed++
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	return response
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// maxDedupNotes 重复检查时最多比较主页中的前几篇笔记
+const maxDedupNotes = 10
+
+// AlreadyPublishedError 开启发布重复检查（-publish_dedup）时，时间窗口内已发布过相同的笔记，本次发布被跳过
+type AlreadyPublishedError struct {
+	FeedID      string    `json:"feed_id"`
+	Title       string    `json:"title"`
+	PublishedAt time.Time `json:"published_at"`
+	Mode        string    `json:"mode"` // 匹配方式：title/content
+}
+
+func (e *AlreadyPublishedError) Error() string {
+	return fmt.Sprintf("already published: note %s with the same %s was published at %s",
+		e.FeedID, e.Mode, e.PublishedAt.Format(time.RFC3339))
+}
+
+// checkAlreadyPublished 按 configs.GetPublishDedup() 配置的方式检查账号主页中最近的笔记，
+// 时间窗口内已发布过相同标题（title）或相同标题和正文（content）的笔记时返回 *AlreadyPublishedError。
+// 未开启时直接返回 nil；检查失败时返回错误，不继续发布。
+func (s *XiaohongshuService) checkAlreadyPublished(ctx context.Context, accountID, title, content string) error {
+	mode := configs.GetPublishDedup()
+	if mode == configs.PublishDedupOff {
+		return nil
+	}
+	since := time.Now().Add(-configs.GetPublishDedupWindow())
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	var notes []xiaohongshu.Feed
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		notes, err = xiaohongshu.NewUserProfileAction(page).OwnNotes(ctx)
+		return err
+	}); err != nil {
+		return fmt.Errorf("发布前重复检查失败: %w", err)
+	}
+	s.tokens.remember(accountID, notes)
+
+	log := logrus.WithField("account", accounts.DisplayName(accountID))
+	fingerprint := xiaohongshu.NoteFingerprint(title, content)
+	detailAction := xiaohongshu.NewFeedDetailAction(page)
+
+	for _, note := range xiaohongshu.RecentNotesWithTitle(notes, title, maxDedupNotes) {
+		publishedAt := note.PublishedAt
+
+		// 卡片没有发布时间或需要比较正文时打开详情页
+		if publishedAt.IsZero() || mode == configs.PublishDedupContent {
+			detail, err := detailAction.GetFeedDetail(ctx, note.ID, note.XsecToken)
+			if err != nil {
+				log.Warnf("重复检查时获取笔记详情失败 %s: %v", note.ID, err)
+				continue
+			}
+			if detail.Note.Time > 0 {
+				publishedAt = time.UnixMilli(detail.Note.Time)
+			}
+			if mode == configs.PublishDedupContent && xiaohongshu.NoteFingerprint(detail.Note.Title, detail.Note.Desc) != fingerprint {
+				continue
+			}
+		}
+
+		if publishedAt.IsZero() {
+			log.Warnf("重复检查时未能获取笔记 %s 的发布时间，忽略", note.ID)
+			continue
+		}
+		if publishedAt.Before(since) {
+			continue
+		}
+
+		return &AlreadyPublishedError{
+			FeedID:      note.ID,
+			Title:       title,
+			PublishedAt: publishedAt,
+			Mode:        mode,
+		}
+	}
+
+	return nil
+}
@@ -16,10 +16,15 @@ func setupRoutes(appServer *AppServer) *gin.Engine {
 	// 添加中间件
 	router.Use(errorHandlingMiddleware())
 	router.Use(corsMiddleware())
+	router.Use(gzipMiddleware())
+	router.Use(requestTimeoutMiddleware())
 
 	// 健康检查
 	router.GET("/health", healthHandler)
 
+	// 服务能力，供客户端做功能探测
+	router.GET("/api/capabilities", capabilitiesHandler)
+
 	// MCP 端点 - 使用 Streamable HTTP 协议
 	mcpHandler := appServer.StreamableHTTPHandler()
 	router.Any("/mcp", gin.WrapH(mcpHandler))
@@ -30,15 +35,48 @@ func setupRoutes(appServer *AppServer) *gin.Engine {
 	{
 		api.GET("/login/status", appServer.checkLoginStatusHandler)
 		api.GET("/login/qrcode", appServer.getLoginQrcodeHandler)
+		api.POST("/warmup", appServer.warmupHandler)
 		api.POST("/publish", appServer.publishHandler)
+		api.POST("/publish/batch", appServer.publishBatchHandler)
+		api.POST("/publish/batch/async", appServer.publishBatchAsyncHandler)
+		api.POST("/publish/async", appServer.publishAsyncHandler)
 		api.POST("/publish_video", appServer.publishVideoHandler)
+		api.POST("/publish_video/async", appServer.publishVideoAsyncHandler)
+		api.POST("/assets/upload", appServer.uploadAssetHandler)
+		api.GET("/jobs/:id", appServer.getJobHandler)
+		api.POST("/jobs/:id/cancel", appServer.cancelJobHandler)
 		api.GET("/feeds/list", appServer.listFeedsHandler)
 		api.GET("/feeds/search", appServer.searchFeedsHandler)
+		api.GET("/feeds/search/stream", appServer.searchStreamHandler)
+		api.GET("/search/hot", appServer.hotSearchesHandler)
+		api.GET("/search/users", appServer.searchUsersHandler)
+		api.GET("/notifications", appServer.notificationsHandler)
 		api.POST("/feeds/detail", appServer.getFeedDetailHandler)
+		api.POST("/feeds/exists", appServer.checkFeedExistsHandler)
+		api.POST("/feeds/not_interested", appServer.markNotInterestedHandler)
+		api.POST("/feeds/share_link", appServer.shareLinkHandler)
+		api.GET("/feeds/comments", appServer.commentsHandler)
 		api.POST("/user/profile", appServer.userProfileHandler)
+		api.POST("/user/latest_note", appServer.latestNoteHandler)
 		api.POST("/feeds/comment", appServer.postCommentHandler)
+		api.POST("/feeds/comment/reply", appServer.replyCommentHandler)
+		api.POST("/feeds/comment/reply/batch", appServer.replyRecentCommentsHandler)
 		api.GET("/accounts", appServer.listAccountsHandler)
 		api.POST("/accounts/remark", appServer.setAccountRemarkHandler)
+		api.POST("/accounts/proxy", appServer.setAccountProxyHandler)
+		api.POST("/accounts/search_defaults", appServer.setAccountSearchDefaultsHandler)
+		api.POST("/accounts/alias", appServer.setAccountAliasHandler)
+		api.DELETE("/accounts/alias/:alias", appServer.removeAccountAliasHandler)
+		api.GET("/accounts/active", appServer.getActiveAccountHandler)
+		api.POST("/accounts/active", appServer.setActiveAccountHandler)
+		api.DELETE("/accounts/:id/images", appServer.clearImagesHandler)
+	}
+
+	// 调试路由组
+	debug := router.Group("/api/debug")
+	{
+		debug.GET("/browser-info", appServer.browserInfoHandler)
+		debug.POST("/query", appServer.debugQueryHandler)
 	}
 
 	return router
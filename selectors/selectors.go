@@ -0,0 +1,201 @@
+// Package selectors 集中管理页面操作使用的 CSS 选择器，以及按文本查找元素时使用的标签文本。
+// 站点改版导致选择器或文本失效时，可通过选择器覆盖文件（JSON/YAML）在运行时替换默认值，无需重新编译。
+package selectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 选择器名称，覆盖文件中使用同样的名称
+const (
+	LikeButton    = "like_button"    // 详情页点赞按钮
+	CollectButton = "collect_button" // 详情页收藏按钮
+
+	CommentInputTrigger = "comment_input_trigger" // 详情页评论框占位，点击后展开输入框
+	CommentInput        = "comment_input"         // 详情页评论输入框
+	CommentSubmit       = "comment_submit"        // 详情页评论发送按钮
+	CommentLikeButton   = "comment_like_button"   // 评论元素内的点赞按钮
+	CommentReplyButton  = "comment_reply_button"  // 评论元素内的回复按钮
+	MentionItem         = "mention_item"          // @ 联想下拉框中的用户
+
+	CreatorTab      = "creator_tab"       // 发布页的上传图文/上传视频标签
+	ImageTabLabel   = "image_tab_label"   // 发布页“上传图文”标签的文本，按包含匹配
+	VideoTabLabel   = "video_tab_label"   // 发布页“上传视频”标签的文本，按包含匹配
+	UploadContent   = "upload_content"    // 发布页上传区域
+	UploadInput     = "upload_input"      // 首张图片的上传输入框
+	UploadInputMore = "upload_input_more" // 图文编辑器追加图片区域中的上传输入框（不含视频和封面的上传框）
+	ImagePreview    = "image_preview"     // 已上传图片的预览
+	TitleInput      = "title_input"       // 发布页标题输入框
+	ContentEditor   = "content_editor"    // 发布页正文编辑器
+	TopicContainer  = "topic_container"   // 话题联想列表
+	SubmitButton    = "submit_button"     // 图文发布按钮
+	VideoSubmit     = "video_submit"      // 视频发布按钮
+	MusicItem       = "music_item"        // 视频发布页配乐搜索结果中的曲目
+
+	SearchInput        = "search_input"         // 搜索框
+	SearchFilterButton = "search_filter_button" // 搜索结果页的筛选按钮
+	SearchFilterPanel  = "search_filter_panel"  // 搜索筛选面板
+	SearchResults      = "search_results"       // 搜索结果页的笔记列表容器
+	SearchChannel      = "search_channel"       // 搜索结果页的分类标签（全部、图文、视频、用户）
+	UserCard           = "user_card"            // 搜索结果页“用户”标签下的用户卡片
+	UserList           = "user_list"            // 搜索结果页“用户”标签下的用户列表容器
+	SearchEmpty        = "search_empty"         // 搜索结果页没有匹配结果时的空状态提示
+
+	LoginStatus = "login_status" // 登录后才会出现的侧边栏元素
+	LoginQrcode = "login_qrcode" // 登录弹窗中的二维码图片
+
+	NotificationBadge    = "notification_badge"    // 侧边栏“通知”入口的未读角标
+	NotificationScroller = "notification_scroller" // 通知页的消息列表滚动容器
+	NotificationTab      = "notification_tab"      // 通知页的分类标签（评论和@、赞和收藏、新增关注）
+
+	FeedCard         = "feed_card"           // 首页推荐列表中的笔记卡片
+	FeedCardMenu     = "feed_card_menu"      // 笔记卡片悬停后出现的更多菜单按钮
+	FeedCardMenuItem = "feed_card_menu_item" // 笔记卡片菜单中的选项（如“不感兴趣”）
+
+	FeedError = "feed_error" // 笔记详情页不可见时的错误提示容器（已删除、仅作者可见等）
+
+	NoteCard     = "note_card"     // 笔记管理页中的笔记卡片
+	Dialog       = "dialog"        // 页面上可能的弹窗容器
+	DialogButton = "dialog_button" // 弹窗内可能的按钮元素
+	Captcha      = "captcha"       // 滑块等人机验证的容器或 iframe
+)
+
+// defaults 默认选择器
+var defaults = map[string]string{
+	LikeButton:    ".interact-container .left .like-lottie",
+	CollectButton: ".interact-container .left .reds-icon.collect-icon",
+
+	CommentInputTrigger: "div.input-box div.content-edit span",
+	CommentInput:        "div.input-box div.content-edit p.content-input",
+	CommentSubmit:       "div.bottom button.submit",
+	CommentLikeButton:   ".interactions .like",
+	CommentReplyButton:  ".interactions .reply",
+	MentionItem:         ".mention-container .mention-item",
+
+	CreatorTab:      "div.creator-tab",
+	ImageTabLabel:   "上传图文",
+	VideoTabLabel:   "上传视频",
+	UploadContent:   "div.upload-content",
+	UploadInput:     ".upload-input",
+	UploadInputMore: `.img-preview-area input[type="file"], [class*="img-upload-area"] input[type="file"], [class*="add-img"] input[type="file"]`,
+	ImagePreview:    ".img-preview-area .pr",
+	TitleInput:      "div.d-input input",
+	ContentEditor:   "div.ql-editor",
+	TopicContainer:  "#creator-editor-topic-container",
+	SubmitButton:    "div.submit div.d-button-content",
+	VideoSubmit:     "button.publishBtn",
+	MusicItem:       `[class*="music-item"], [class*="song-item"], [class*="music-list"] [class*="item"]`,
+
+	SearchInput:        "#search-input",
+	SearchFilterButton: "div.filter",
+	SearchFilterPanel:  "div.filter-panel",
+	SearchResults:      "div#app .feeds-container",
+	SearchChannel:      `#search-type .channel, .channel-list .channel, [class*="search-tab"] [class*="tab"]`,
+	UserCard:           `.user-list-item, [class*="user-item"], .search-user-list section`,
+	UserList:           `.search-user-list, [class*="user-list"]`,
+	SearchEmpty:        `.search-empty, [class*="no-result"], [class*="empty-result"]`,
+
+	LoginStatus: ".main-container .user .link-wrapper .channel",
+	LoginQrcode: ".login-container .qrcode-img",
+
+	NotificationBadge:    `a[href*="/notification"] .badge, a[href*="/notification"] [class*="count"]`,
+	NotificationScroller: ".notification-page .tabs-content-container, .notification-page",
+	NotificationTab:      ".notification-page .reds-tab-item, .notification-page .tab-item",
+
+	FeedCard:         "section.note-item",
+	FeedCardMenu:     `[class*="more"], [class*="dislike"]`,
+	FeedCardMenuItem: `[role="menuitem"], [class*="dropdown"] [class*="item"], [class*="menu"] [class*="item"], [class*="dislike"] [class*="item"]`,
+
+	FeedError: `.error-container, .error-page, .not-found, [class*="note-error"], [class*="error-tips"], [class*="not-found"]`,
+
+	NoteCard:     `div.note, [class*="note-item"]`,
+	Dialog:       `[role="dialog"], .reds-modal, .d-modal, .el-dialog, .modal`,
+	DialogButton: `button, [role="button"], a, [class*="btn"], [class*="button"]`,
+	Captcha:      `#red-captcha, [class*="red-captcha"], [class*="captcha-container"], [class*="captcha-modal"], iframe[src*="captcha"]`,
+}
+
+// overrides 覆盖文件中的选择器
+var overrides = map[string]string{}
+
+// Get 返回选择器，覆盖文件中配置了该名称时使用覆盖值
+func Get(name string) string {
+	if s, ok := overrides[name]; ok {
+		return s
+	}
+	if s, ok := defaults[name]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("unknown selector %q", name))
+}
+
+// Names 返回所有选择器名称
+func Names() []string {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseFile 读取并校验选择器覆盖文件，.yaml/.yml 按 YAML 解析，其余按 JSON 解析。
+// 文件内容为选择器名称到 CSS 选择器的映射，名称不存在或选择器为空时返回错误。
+func ParseFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]string{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &m)
+	default:
+		err = json.Unmarshal(data, &m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	var unknown []string
+	for name, s := range m {
+		if _, ok := defaults[name]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if strings.TrimSpace(s) == "" {
+			return nil, fmt.Errorf("selector %q is empty", name)
+		}
+		m[name] = strings.TrimSpace(s)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown selectors %s, expected one of %s", strings.Join(unknown, ", "), strings.Join(Names(), ", "))
+	}
+	return m, nil
+}
+
+// SetOverrides 设置覆盖的选择器，nil 表示全部使用默认值
+func SetOverrides(m map[string]string) {
+	if m == nil {
+		m = map[string]string{}
+	}
+	overrides = m
+}
+
+// Overrides 返回当前覆盖的选择器名称
+func Overrides() []string {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+package selectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestParseFile(t *testing.T) {
+	m, err := ParseFile(writeFile(t, "selectors.json", `{"like_button": " .like-wrapper "}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{LikeButton: ".like-wrapper"}, m)
+
+	m, err = ParseFile(writeFile(t, "selectors.yaml", "collect_button: .collect-wrapper\nsubmit_button: 'button.publish'\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{CollectButton: ".collect-wrapper", SubmitButton: "button.publish"}, m)
+
+	_, err = ParseFile(writeFile(t, "selectors.json", `{"like_btn": ".like", "like_button": ".like"}`))
+	assert.ErrorContains(t, err, `unknown selectors like_btn`)
+
+	_, err = ParseFile(writeFile(t, "selectors.json", `{"like_button": "  "}`))
+	assert.ErrorContains(t, err, `selector "like_button" is empty`)
+
+	_, err = ParseFile(writeFile(t, "selectors.json", `like_button: .like`))
+	assert.ErrorContains(t, err, "parse")
+
+	_, err = ParseFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestGetWithOverrides(t *testing.T) {
+	t.Cleanup(func() { SetOverrides(nil) })
+
+	assert.Equal(t, defaults[LikeButton], Get(LikeButton))
+
+	SetOverrides(map[string]string{LikeButton: ".like-wrapper"})
+	assert.Equal(t, ".like-wrapper", Get(LikeButton))
+	assert.Equal(t, defaults[CollectButton], Get(CollectButton))
+	assert.Equal(t, []string{LikeButton}, Overrides())
+
+	assert.Panics(t, func() { Get("missing") })
+}
+
+func TestNamesHaveDefaults(t *testing.T) {
+	for _, name := range Names() {
+		assert.NotEmpty(t, Get(name), name)
+	}
+}
@@ -3,41 +3,91 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
 	"github.com/mattn/go-runewidth"
 	"github.com/sirupsen/logrus"
-	"github.com/xpzouying/headless_browser"
 	"github.com/xpzouying/xiaohongshu-mcp/accounts"
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
 	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/moderation"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templating"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
 // XiaohongshuService 小红书业务服务
-type XiaohongshuService struct{}
+type XiaohongshuService struct {
+	cfg         configs.Config
+	idempotency *idempotencyStore
+	tokens      *xsecTokenCache
+	loginStatus *loginStatusCache
+	browsers    *browserLimiter
+}
+
+// ServiceOption 创建服务时的可选配置
+type ServiceOption func(*XiaohongshuService)
+
+// WithCookieFile 单账号部署时让默认账号直接读写 path 指定的 cookies 文件，不再使用账号目录；
+// 请求未提供 account_id 且没有活跃账号时使用默认账号。path 为空时不生效，其他账号不受影响
+func WithCookieFile(path string) ServiceOption {
+	return func(*XiaohongshuService) {
+		if path != "" {
+			accounts.SetCookieFile(path)
+		}
+	}
+}
 
 // NewXiaohongshuService 创建小红书服务实例
-func NewXiaohongshuService() *XiaohongshuService {
-	return &XiaohongshuService{}
+func NewXiaohongshuService(cfg configs.Config, opts ...ServiceOption) *XiaohongshuService {
+	s := &XiaohongshuService{
+		cfg:         cfg,
+		idempotency: newIdempotencyStore(),
+		tokens:      newXsecTokenCache(),
+		loginStatus: newLoginStatusCache(),
+		browsers:    newBrowserLimiter(cfg.MaxBrowsers),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // PublishRequest 发布请求
 type PublishRequest struct {
 	Title   string   `json:"title" binding:"required"`
-	Content string   `json:"content" binding:"required"`
+	Content string   `json:"content" binding:"required_without=Template,excluded_with=Template"`
 	Images  []string `json:"images" binding:"required,min=1"`
 	Tags    []string `json:"tags,omitempty"`
+
+	// Template 可选，正文模板，与 content 二选一。占位符写作 {{name}} 或 {{.name}}，
+	// 发布前用 Vars 展开为正文，标题中的占位符同时展开
+	Template string            `json:"template,omitempty"`
+	Vars     map[string]string `json:"vars,omitempty"`
+
+	// ProductIDs 可选，挂载到笔记的商品 ID，需要账号具备商品（带货）权限
+	ProductIDs []string `json:"product_ids,omitempty"`
+
+	// FirstComment 可选，发布成功后在新笔记下自动发表的首条评论（自动首评）
+	FirstComment string `json:"first_comment,omitempty"`
+
+	// IdempotencyKey 可选，重试时携带相同的 key 将直接返回上次的发布结果，避免重复发布
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // LoginStatusResponse 登录状态响应
 type LoginStatusResponse struct {
 	IsLoggedIn bool   `json:"is_logged_in"`
 	Username   string `json:"username,omitempty"`
+
+	// Cached 为 true 表示结果来自缓存，未重新打开浏览器检查
+	Cached bool `json:"cached"`
 }
 
 // LoginQrcodeResponse 登录扫码二维码
@@ -47,13 +97,36 @@ type LoginQrcodeResponse struct {
 	Img        string `json:"img,omitempty"`
 }
 
+// WarmupResponse 账号预热响应
+type WarmupResponse struct {
+	AccountID  string `json:"account_id"`
+	IsLoggedIn bool   `json:"is_logged_in"`
+	ElapsedMs  int64  `json:"elapsed_ms"`
+	Message    string `json:"message,omitempty"`
+}
+
 // PublishResponse 发布响应
 type PublishResponse struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Images  int    `json:"images"`
-	Status  string `json:"status"`
-	PostID  string `json:"post_id,omitempty"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Images    int    `json:"images"`
+	Status    string `json:"status"`
+	PostID    string `json:"post_id,omitempty"`
+	XsecToken string `json:"xsec_token,omitempty"`
+
+	// Unconfirmed 已点击发布但未能确认结果，笔记可能已发布，重试前应先在主页确认
+	Unconfirmed bool `json:"unconfirmed,omitempty"`
+
+	// FirstComment 请求了自动首评时的评论结果，评论失败不影响发布结果
+	FirstComment *FirstCommentResult `json:"first_comment,omitempty"`
+}
+
+// FirstCommentResult 自动首评结果
+type FirstCommentResult struct {
+	Success   bool   `json:"success"`
+	CommentID string `json:"comment_id,omitempty"`
+	Content   string `json:"content"`
+	Error     string `json:"error,omitempty"`
 }
 
 // PublishVideoRequest 发布视频请求（仅支持本地单个视频文件）
@@ -62,6 +135,12 @@ type PublishVideoRequest struct {
 	Content string   `json:"content" binding:"required"`
 	Video   string   `json:"video" binding:"required"`
 	Tags    []string `json:"tags,omitempty"`
+
+	// MusicQuery 可选，背景音乐搜索关键词（曲名或歌手）；没有匹配的曲目时不带配乐继续发布
+	MusicQuery string `json:"music_query,omitempty"`
+
+	// IdempotencyKey 可选，语义同 PublishRequest.IdempotencyKey
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // PublishVideoResponse 发布视频响应
@@ -71,8 +150,14 @@ type PublishVideoResponse struct {
 	Video   string `json:"video"`
 	Status  string `json:"status"`
 	PostID  string `json:"post_id,omitempty"`
+
+	// Unconfirmed 已点击发布但未能确认结果，笔记可能已发布，重试前应先在主页确认
+	Unconfirmed bool `json:"unconfirmed,omitempty"`
 }
 
+// publishUnconfirmedStatus 发布结果未确认时的状态说明
+const publishUnconfirmedStatus = "已提交发布，但未能确认结果，笔记可能已发布，请先在主页确认后再决定是否重试"
+
 // ActionResult 通用操作响应
 type ActionResult struct {
 	FeedID  string `json:"feed_id"`
@@ -84,6 +169,64 @@ type ActionResult struct {
 type FeedsListResponse struct {
 	Feeds []xiaohongshu.Feed `json:"feeds"`
 	Count int                `json:"count"`
+
+	// SinceFound 仅在请求指定 since_feed_id 时返回，false 表示滚动加载后仍未找到该笔记，
+	// 此时 feeds 为已加载的全部笔记，与上次获取的结果之间可能有遗漏
+	SinceFound *bool `json:"since_found,omitempty"`
+}
+
+const (
+	defaultSearchDetailsTopN = 5
+	maxSearchDetailsTopN     = 20
+
+	defaultDetailCommentLimit = 50
+	maxDetailCommentLimit     = 200
+
+	// 流式搜索默认与最多返回的结果数
+	defaultSearchStreamLimit = 100
+	maxSearchStreamLimit     = 500
+)
+
+// FeedWithDetail 搜索结果及其详情，获取详情失败时 Error 非空
+type FeedWithDetail struct {
+	Feed   xiaohongshu.Feed                `json:"feed"`
+	Detail *xiaohongshu.FeedDetailResponse `json:"detail,omitempty"`
+	Error  string                          `json:"error,omitempty"`
+}
+
+const (
+	defaultNotificationsLimit = 20
+	maxNotificationsLimit     = 100
+)
+
+// NotificationListResponse 通知列表响应
+type NotificationListResponse struct {
+	Notifications []xiaohongshu.Notification `json:"notifications"`
+	Count         int                        `json:"count"`
+}
+
+// NotificationsResponse 未读通知响应
+type NotificationsResponse struct {
+	xiaohongshu.NotificationCounts
+	HasNew bool `json:"has_new"` // 是否有未读通知
+}
+
+// HotSearchesResponse 热搜词响应
+type HotSearchesResponse struct {
+	Items []xiaohongshu.HotSearchItem `json:"items"`
+	Count int                         `json:"count"`
+}
+
+// SearchUsersResponse 搜索用户响应
+type SearchUsersResponse struct {
+	Users []xiaohongshu.UserResult `json:"users"`
+	Count int                      `json:"count"`
+}
+
+// SearchWithDetailsResponse 搜索并获取详情响应
+type SearchWithDetailsResponse struct {
+	Feeds []FeedWithDetail `json:"feeds"`
+	Count int              `json:"count"`
 }
 
 // UserProfileResponse 用户主页响应
@@ -91,17 +234,31 @@ type UserProfileResponse struct {
 	UserBasicInfo xiaohongshu.UserBasicInfo      `json:"userBasicInfo"`
 	Interactions  []xiaohongshu.UserInteractions `json:"interactions"`
 	Feeds         []xiaohongshu.Feed             `json:"feeds"`
+
+	// SinceFound 语义同 FeedsListResponse.SinceFound
+	SinceFound *bool `json:"since_found,omitempty"`
 }
 
-// CheckLoginStatus 检查登录状态
-func (s *XiaohongshuService) CheckLoginStatus(ctx context.Context, accountID string) (*LoginStatusResponse, error) {
-	b, err := s.newBrowser(accountID)
+// CheckLoginStatus 检查登录状态。
+// 结果按账号缓存 LoginStatusCacheTTL，force 为 true 时跳过缓存重新检查。
+func (s *XiaohongshuService) CheckLoginStatus(ctx context.Context, accountID string, force bool) (*LoginStatusResponse, error) {
+	if !force {
+		if isLoggedIn, ok := s.loginStatus.get(accountID, s.cfg.LoginStatusCacheTTL); ok {
+			return &LoginStatusResponse{
+				IsLoggedIn: isLoggedIn,
+				Username:   configs.Username,
+				Cached:     true,
+			}, nil
+		}
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	loginAction := xiaohongshu.NewLogin(page)
@@ -110,6 +267,7 @@ func (s *XiaohongshuService) CheckLoginStatus(ctx context.Context, accountID str
 	if err != nil {
 		return nil, err
 	}
+	s.loginStatus.set(accountID, isLoggedIn)
 
 	response := &LoginStatusResponse{
 		IsLoggedIn: isLoggedIn,
@@ -119,9 +277,46 @@ func (s *XiaohongshuService) CheckLoginStatus(ctx context.Context, accountID str
 	return response, nil
 }
 
+// Warmup 预热账号：打开首页检查登录状态，已登录时用浏览器中的 cookies 刷新账号的 cookies 文件。
+// 浏览器不会常驻，预热只是预先创建账号独立的 Chrome 配置目录（连同首页资源缓存），后续请求仍会启动新的浏览器。
+func (s *XiaohongshuService) Warmup(ctx context.Context, accountID string) (*WarmupResponse, error) {
+	start := time.Now()
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	isLoggedIn, err := xiaohongshu.NewLogin(page).CheckLoginStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.loginStatus.set(accountID, isLoggedIn)
+	if isLoggedIn {
+		if err := saveCookies(accountID, page); err != nil {
+			logrus.Warnf("预热时刷新账号 %s 的 cookies 失败: %v", accountID, err)
+		}
+	}
+
+	response := &WarmupResponse{
+		AccountID:  accountID,
+		IsLoggedIn: isLoggedIn,
+		ElapsedMs:  time.Since(start).Milliseconds(),
+	}
+	if !isLoggedIn {
+		response.Message = "账号未登录，请先扫码登录"
+	}
+
+	return response, nil
+}
+
 // GetLoginQrcode 获取登录的扫码二维码
 func (s *XiaohongshuService) GetLoginQrcode(ctx context.Context, accountID string) (*LoginQrcodeResponse, error) {
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +336,7 @@ func (s *XiaohongshuService) GetLoginQrcode(ctx context.Context, accountID strin
 	if err != nil {
 		return nil, err
 	}
+	s.loginStatus.set(accountID, loggedIn)
 
 	timeout := 4 * time.Minute
 
@@ -154,6 +350,7 @@ func (s *XiaohongshuService) GetLoginQrcode(ctx context.Context, accountID strin
 				if er := saveCookies(account, page); er != nil {
 					logrus.Errorf("failed to save cookies for account %s: %v", account, er)
 				}
+				s.loginStatus.set(account, true)
 			}
 		}(accountID)
 	}
@@ -172,6 +369,17 @@ func (s *XiaohongshuService) GetLoginQrcode(ctx context.Context, accountID strin
 
 // PublishContent 发布内容
 func (s *XiaohongshuService) PublishContent(ctx context.Context, accountID string, req *PublishRequest) (*PublishResponse, error) {
+	return withIdempotency(s.idempotency, accountID, idempotencyKey("publish", req.IdempotencyKey), func() (*PublishResponse, error) {
+		return s.publishImageContent(ctx, accountID, req)
+	})
+}
+
+func (s *XiaohongshuService) publishImageContent(ctx context.Context, accountID string, req *PublishRequest) (*PublishResponse, error) {
+	req, err := req.expandTemplate()
+	if err != nil {
+		return nil, err
+	}
+
 	// 验证标题长度
 	// 小红书限制：最大40个单位长度
 	// 中文/日文/韩文占2个单位，英文/数字占1个单位
@@ -179,8 +387,18 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, accountID strin
 		return nil, fmt.Errorf("标题长度超过限制")
 	}
 
+	// 敏感词预检，在启动浏览器前拦截明显会被拒绝的内容
+	if err := moderation.Check(append([]string{req.Title, req.Content, req.FirstComment}, req.Tags...)...); err != nil {
+		return nil, err
+	}
+
+	// 开启重复检查时，近期已发布过相同笔记则跳过
+	if err := s.checkAlreadyPublished(ctx, accountID, req.Title, req.Content); err != nil {
+		return nil, err
+	}
+
 	// 处理图片：下载URL图片或使用本地路径
-	imagePaths, err := s.processImages(accountID, req.Images)
+	imagePaths, err := s.processImages(ctx, accountID, req.Images)
 	if err != nil {
 		return nil, err
 	}
@@ -191,84 +409,178 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, accountID strin
 		Content:    req.Content,
 		Tags:       req.Tags,
 		ImagePaths: imagePaths,
+		ProductIDs: xiaohongshu.NormalizeProductIDs(req.ProductIDs),
 	}
 
 	// 执行发布
-	if err := s.publishContent(ctx, accountID, content); err != nil {
+	publishedAt := time.Now()
+	unconfirmed, err := publishOutcome(accountID, s.publishContent(ctx, accountID, content))
+	if err != nil {
 		return nil, err
 	}
 
 	response := &PublishResponse{
-		Title:   req.Title,
-		Content: req.Content,
-		Images:  len(imagePaths),
-		Status:  "发布完成",
+		Title:       req.Title,
+		Content:     req.Content,
+		Images:      len(imagePaths),
+		Status:      "发布完成",
+		Unconfirmed: unconfirmed,
+	}
+	if unconfirmed {
+		response.Status = publishUnconfirmedStatus
+	}
+
+	if strings.TrimSpace(req.FirstComment) != "" {
+		feed, result := s.postFirstComment(ctx, accountID, req.Title, req.FirstComment, publishedAt)
+		if feed != nil {
+			response.PostID, response.XsecToken = feed.ID, feed.XsecToken
+		}
+		response.FirstComment = result
 	}
 
 	return response, nil
 }
 
+// expandTemplate 设置了正文模板时，返回标题和正文展开后的请求副本；未设置时原样返回
+func (r *PublishRequest) expandTemplate() (*PublishRequest, error) {
+	if r.Template == "" {
+		return r, nil
+	}
+
+	out, err := templating.Expand(r.Vars, r.Title, r.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(out[1]) == "" {
+		return nil, fmt.Errorf("%w: 模板展开后正文为空", templating.ErrInvalidTemplate)
+	}
+
+	expanded := *r
+	expanded.Title = strings.TrimSpace(out[0])
+	expanded.Content = out[1]
+	expanded.Template = ""
+	expanded.Vars = nil
+	return &expanded, nil
+}
+
 // PublishVideo 发布视频内容
 func (s *XiaohongshuService) PublishVideo(ctx context.Context, accountID string, req *PublishVideoRequest) (*PublishVideoResponse, error) {
-	b, err := s.newBrowser(accountID)
+	return withIdempotency(s.idempotency, accountID, idempotencyKey("publish_video", req.IdempotencyKey), func() (*PublishVideoResponse, error) {
+		return s.publishVideoContent(ctx, accountID, req)
+	})
+}
+
+func (s *XiaohongshuService) publishVideoContent(ctx context.Context, accountID string, req *PublishVideoRequest) (*PublishVideoResponse, error) {
+	if err := moderation.Check(append([]string{req.Title, req.Content}, req.Tags...)...); err != nil {
+		return nil, err
+	}
+
+	// 启动浏览器前校验视频，避免无效文件等待上传超时
+	if err := xiaohongshu.CheckUploadPath(req.Video); err != nil {
+		return nil, err
+	}
+	if err := xiaohongshu.ValidateVideoFile(req.Video); err != nil {
+		return nil, err
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
-	action, err := xiaohongshu.NewPublishVideoAction(page)
-	if err != nil {
+	var action *xiaohongshu.PublishAction
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		action, err = xiaohongshu.NewPublishVideoAction(page)
+		return err
+	}); err != nil {
 		return nil, err
 	}
 
 	content := xiaohongshu.PublishVideoContent{
-		Title:     req.Title,
-		Content:   req.Content,
-		Tags:      req.Tags,
-		VideoPath: req.Video,
+		Title:      req.Title,
+		Content:    req.Content,
+		Tags:       req.Tags,
+		VideoPath:  req.Video,
+		MusicQuery: req.MusicQuery,
 	}
 
-	if err := action.PublishVideo(ctx, content); err != nil {
+	unconfirmed, err := publishOutcome(accountID, action.PublishVideo(ctx, content))
+	if err != nil {
 		return nil, err
 	}
 
 	response := &PublishVideoResponse{
-		Title:   req.Title,
-		Content: req.Content,
-		Video:   req.Video,
-		Status:  "发布完成",
+		Title:       req.Title,
+		Content:     req.Content,
+		Video:       req.Video,
+		Status:      "发布完成",
+		Unconfirmed: unconfirmed,
+	}
+	if unconfirmed {
+		response.Status = publishUnconfirmedStatus
 	}
 
 	return response, nil
 }
 
+// publishOutcome 区分发布结果：未确认（xiaohongshu.ErrPublishUnconfirmed）不视为失败，
+// 返回 unconfirmed=true，避免调用方重试导致重复发布
+func publishOutcome(accountID string, err error) (unconfirmed bool, _ error) {
+	if errors.Is(err, xiaohongshu.ErrPublishUnconfirmed) {
+		logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("发布结果未确认: %v", err)
+		return true, nil
+	}
+	return false, err
+}
+
 // processImages 处理图片列表，支持URL下载和本地路径
-func (s *XiaohongshuService) processImages(accountID string, images []string) ([]string, error) {
+func (s *XiaohongshuService) processImages(ctx context.Context, accountID string, images []string) ([]string, error) {
 	imageDir, err := accounts.ImagesDir(accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	processor := downloader.NewImageProcessor(imageDir)
-	return processor.ProcessImages(images)
+	// 配置了上传根目录时，下载前先拒绝根目录以外的本地路径；upload_asset 保存在账号图片目录中的文件除外
+	for _, image := range images {
+		if downloader.IsImageURL(image) {
+			continue
+		}
+		if err := xiaohongshu.CheckUploadPath(image, imageDir); err != nil {
+			return nil, err
+		}
+	}
+
+	processor := downloader.NewImageProcessor(imageDir, downloader.Limits{
+		RequestTimeout: configs.GetImageDownloadTimeout(),
+		TotalTimeout:   configs.GetImageDownloadTotalTimeout(),
+		MaxRedirects:   configs.GetImageDownloadMaxRedirects(),
+		MaxSize:        configs.GetImageDownloadMaxSize(),
+	})
+	return processor.ProcessImages(ctx, images)
 }
 
 // publishContent 执行内容发布
 func (s *XiaohongshuService) publishContent(ctx context.Context, accountID string, content xiaohongshu.PublishImageContent) error {
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
-	action, err := xiaohongshu.NewPublishImageAction(page)
-	if err != nil {
+	// 只有进入发布页阶段会因登录失效失败，此时尚未填写内容，可以安全重试
+	var action *xiaohongshu.PublishAction
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		action, err = xiaohongshu.NewPublishImageAction(page)
+		return err
+	}); err != nil {
 		return err
 	}
 
@@ -276,19 +588,45 @@ func (s *XiaohongshuService) publishContent(ctx context.Context, accountID strin
 	return action.Publish(ctx, content)
 }
 
+// PinNote 在创作者中心置顶或取消置顶当前账号自己的笔记
+func (s *XiaohongshuService) PinNote(ctx context.Context, accountID, noteID string, pin bool) (*ActionResult, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	if err := s.withLoginRetry(accountID, b, func() error {
+		return xiaohongshu.NewPinAction(page).SetPinned(ctx, noteID, pin)
+	}); err != nil {
+		return nil, err
+	}
+
+	message := "置顶成功或已置顶"
+	if !pin {
+		message = "取消置顶成功或未置顶"
+	}
+	return &ActionResult{FeedID: noteID, Success: true, Message: message}, nil
+}
+
 // LikeFeed 点赞笔记
 func (s *XiaohongshuService) LikeFeed(ctx context.Context, accountID, feedID, xsecToken string) (*ActionResult, error) {
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	action := xiaohongshu.NewLikeAction(page)
-	if err := action.Like(ctx, feedID, xsecToken); err != nil {
+	if err := s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, func(token string) error {
+		return action.Like(ctx, feedID, token)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -297,17 +635,19 @@ func (s *XiaohongshuService) LikeFeed(ctx context.Context, accountID, feedID, xs
 
 // UnlikeFeed 取消点赞
 func (s *XiaohongshuService) UnlikeFeed(ctx context.Context, accountID, feedID, xsecToken string) (*ActionResult, error) {
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	action := xiaohongshu.NewLikeAction(page)
-	if err := action.Unlike(ctx, feedID, xsecToken); err != nil {
+	if err := s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, func(token string) error {
+		return action.Unlike(ctx, feedID, token)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -316,17 +656,19 @@ func (s *XiaohongshuService) UnlikeFeed(ctx context.Context, accountID, feedID,
 
 // FavoriteFeed 收藏笔记
 func (s *XiaohongshuService) FavoriteFeed(ctx context.Context, accountID, feedID, xsecToken string) (*ActionResult, error) {
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	action := xiaohongshu.NewFavoriteAction(page)
-	if err := action.Favorite(ctx, feedID, xsecToken); err != nil {
+	if err := s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, func(token string) error {
+		return action.Favorite(ctx, feedID, token)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -335,32 +677,74 @@ func (s *XiaohongshuService) FavoriteFeed(ctx context.Context, accountID, feedID
 
 // UnfavoriteFeed 取消收藏
 func (s *XiaohongshuService) UnfavoriteFeed(ctx context.Context, accountID, feedID, xsecToken string) (*ActionResult, error) {
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	action := xiaohongshu.NewFavoriteAction(page)
-	if err := action.Unfavorite(ctx, feedID, xsecToken); err != nil {
+	if err := s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, func(token string) error {
+		return action.Unfavorite(ctx, feedID, token)
+	}); err != nil {
 		return nil, err
 	}
 
 	return &ActionResult{FeedID: feedID, Success: true, Message: "取消收藏成功或未收藏"}, nil
 }
 
+// MarkNotInterested 在首页推荐列表中将笔记标记为不感兴趣，用于调整推荐内容。
+// 笔记不在当前推荐列表或卡片没有“不感兴趣”菜单时返回 Success 为 false 的结果；
+// xsecToken 过期导致无法确认笔记状态时通过 withTokenRefresh 刷新后重试
+func (s *XiaohongshuService) MarkNotInterested(ctx context.Context, accountID, feedID, xsecToken string) (*ActionResult, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	err = s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, func(token string) error {
+		action, err := xiaohongshu.NewFeedsListAction(page)
+		if err != nil {
+			return err
+		}
+		return action.MarkNotInterested(ctx, feedID, token)
+	})
+	switch {
+	case errors.Is(err, xiaohongshu.ErrFeedNotOnHome):
+		return &ActionResult{FeedID: feedID, Success: false, Message: "首页推荐列表中没有该笔记，只能对当前推荐的笔记标记不感兴趣"}, nil
+	case errors.Is(err, xiaohongshu.ErrNotInterestedUnavailable):
+		return &ActionResult{FeedID: feedID, Success: false, Message: "笔记卡片上没有“不感兴趣”菜单"}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return &ActionResult{FeedID: feedID, Success: true, Message: "已标记为不感兴趣"}, nil
+}
+
 // ListFeeds 获取指定账号的推荐内容列表
-func (s *XiaohongshuService) ListFeeds(ctx context.Context, accountID string) (*FeedsListResponse, error) {
-	b, err := s.newBrowser(accountID)
+// noteType 可选 all/video/image，为空时不过滤
+// ListFeeds 获取首页推荐列表。sinceFeedID 不为空时只返回排在该笔记之前的内容，
+// 当前列表中没有该笔记时滚动加载更多，用于增量轮询
+func (s *XiaohongshuService) ListFeeds(ctx context.Context, accountID, noteType string, dedupByAuthor bool, sinceFeedID string) (*FeedsListResponse, error) {
+	typeFilter, err := xiaohongshu.ParseNoteTypeFilter(noteType)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	// 创建 Feeds 列表 action
@@ -370,35 +754,92 @@ func (s *XiaohongshuService) ListFeeds(ctx context.Context, accountID string) (*
 	}
 
 	// 获取 Feeds 列表
-	feeds, err := action.GetFeedsList(ctx)
-	if err != nil {
+	var (
+		feeds      []xiaohongshu.Feed
+		sinceFound *bool
+	)
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		if sinceFeedID == "" {
+			feeds, err = action.GetFeedsList(ctx)
+			return err
+		}
+		var found bool
+		feeds, found, err = action.GetFeedsSince(ctx, sinceFeedID)
+		sinceFound = &found
+		return err
+	}); err != nil {
 		return nil, err
 	}
+	s.tokens.remember(accountID, feeds)
+	feeds = xiaohongshu.FilterFeedsByNoteType(feeds, typeFilter)
+	if dedupByAuthor {
+		feeds = xiaohongshu.DedupFeedsByAuthor(feeds)
+	}
 
 	response := &FeedsListResponse{
-		Feeds: feeds,
-		Count: len(feeds),
+		Feeds:      feeds,
+		Count:      len(feeds),
+		SinceFound: sinceFound,
 	}
 
 	return response, nil
 }
 
-func (s *XiaohongshuService) SearchFeeds(ctx context.Context, accountID, keyword string, filters *xiaohongshu.SearchFilters) (*FeedsListResponse, error) {
-	b, err := s.newBrowser(accountID)
+// newAccountSearchFilters 构建搜索筛选器，请求未指定的筛选项使用账号配置的默认值
+func newAccountSearchFilters(accountID, sort, noteType, publishTime, searchScope, distance string) (*xiaohongshu.SearchFilters, error) {
+	defaults, err := accounts.AccountSearchDefaults(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	pick := func(value, fallback string) string {
+		if value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	return xiaohongshu.NewSearchFilters(
+		pick(sort, defaults.Sort),
+		pick(noteType, defaults.NoteType),
+		pick(publishTime, defaults.PublishTime),
+		pick(searchScope, defaults.SearchScope),
+		pick(distance, defaults.Distance),
+	)
+}
+
+// setAccountSearchDefaults 校验并保存账号默认的搜索筛选项，全部为空时清除
+func setAccountSearchDefaults(accountID string, defaults accounts.SearchDefaults) (*accounts.AccountInfo, error) {
+	if _, err := xiaohongshu.NewSearchFilters(defaults.Sort, defaults.NoteType,
+		defaults.PublishTime, defaults.SearchScope, defaults.Distance); err != nil {
+		return nil, err
+	}
+	return accounts.SetAccountSearchDefaults(accountID, defaults)
+}
+
+func (s *XiaohongshuService) SearchFeeds(ctx context.Context, accountID, keyword string, filters *xiaohongshu.SearchFilters, dedupByAuthor bool) (*FeedsListResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	action := xiaohongshu.NewSearchAction(page)
 
-	feeds, err := action.Search(ctx, keyword, filters)
-	if err != nil {
+	var feeds []xiaohongshu.Feed
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		feeds, err = action.Search(ctx, keyword, filters)
+		return err
+	}); err != nil {
 		return nil, err
 	}
+	s.tokens.remember(accountID, feeds)
+	if dedupByAuthor {
+		feeds = xiaohongshu.DedupFeedsByAuthor(feeds)
+	}
 
 	response := &FeedsListResponse{
 		Feeds: feeds,
@@ -408,55 +849,398 @@ func (s *XiaohongshuService) SearchFeeds(ctx context.Context, accountID, keyword
 	return response, nil
 }
 
-// GetFeedDetail 获取Feed详情
-func (s *XiaohongshuService) GetFeedDetail(ctx context.Context, accountID, feedID, xsecToken string) (*FeedDetailResponse, error) {
-	b, err := s.newBrowser(accountID)
+// SearchFeedsStream 搜索并滚动加载更多结果，每加载出一批新结果调用一次 onBatch，最多返回 limit 条
+func (s *XiaohongshuService) SearchFeedsStream(ctx context.Context, accountID, keyword string, filters *xiaohongshu.SearchFilters, limit int, onBatch func([]xiaohongshu.Feed) error) error {
+	if limit <= 0 {
+		limit = defaultSearchStreamLimit
+	}
+	if limit > maxSearchStreamLimit {
+		limit = maxSearchStreamLimit
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewSearchAction(page)
+
+	// 登录失效只会发生在打开搜索页阶段，此时尚未推送任何结果，可以安全重试
+	return s.withLoginRetry(accountID, b, func() error {
+		return action.SearchStream(ctx, keyword, filters, limit, func(feeds []xiaohongshu.Feed) error {
+			s.tokens.remember(accountID, feeds)
+			return onBatch(feeds)
+		})
+	})
+}
+
+// SearchUsers 按关键词搜索用户，页面显示无结果提示时返回空列表
+func (s *XiaohongshuService) SearchUsers(ctx context.Context, accountID, keyword string) (*SearchUsersResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewSearchAction(page)
+
+	var users []xiaohongshu.UserResult
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		users, err = action.SearchUsers(ctx, keyword)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &SearchUsersResponse{
+		Users: users,
+		Count: len(users),
+	}, nil
+}
+
+// GetHotSearches 获取当前热搜词
+func (s *XiaohongshuService) GetHotSearches(ctx context.Context, accountID string) (*HotSearchesResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	items, err := xiaohongshu.NewHotSearchAction(page).GetHotSearches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HotSearchesResponse{
+		Items: items,
+		Count: len(items),
+	}, nil
+}
+
+// GetNotifications 获取账号的未读通知数量（评论和@、赞和收藏、新增关注）
+func (s *XiaohongshuService) GetNotifications(ctx context.Context, accountID string) (*NotificationsResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	var counts xiaohongshu.NotificationCounts
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		counts, err = xiaohongshu.NewNotificationsAction(page).GetNotificationCounts(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &NotificationsResponse{
+		NotificationCounts: counts,
+		HasNew:             counts.Total > 0,
+	}, nil
+}
+
+// MarkNotificationsRead 将账号的所有未读通知标记为已读，没有未读通知时返回 marked 为 0
+func (s *XiaohongshuService) MarkNotificationsRead(ctx context.Context, accountID string) (*xiaohongshu.MarkReadResult, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	var result xiaohongshu.MarkReadResult
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		result, err = xiaohongshu.NewNotificationsAction(page).MarkAllRead(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListNotifications 获取账号最近的 limit 条通知消息（评论、@、点赞等），按页面顺序去重
+func (s *XiaohongshuService) ListNotifications(ctx context.Context, accountID string, limit int) (*NotificationListResponse, error) {
+	if limit <= 0 {
+		limit = defaultNotificationsLimit
+	}
+	if limit > maxNotificationsLimit {
+		limit = maxNotificationsLimit
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	var notifications []xiaohongshu.Notification
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		notifications, err = xiaohongshu.NewNotificationsAction(page).GetNotifications(ctx, limit)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &NotificationListResponse{
+		Notifications: notifications,
+		Count:         len(notifications),
+	}, nil
+}
+
+// SearchWithDetails 搜索并获取前 topN 条结果的详情，复用同一个页面，单条详情失败不影响其余结果
+func (s *XiaohongshuService) SearchWithDetails(ctx context.Context, accountID, keyword string, filters *xiaohongshu.SearchFilters, topN int) (*SearchWithDetailsResponse, error) {
+	if topN <= 0 {
+		topN = defaultSearchDetailsTopN
+	}
+	if topN > maxSearchDetailsTopN {
+		topN = maxSearchDetailsTopN
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	feeds, err := xiaohongshu.NewSearchAction(page).Search(ctx, keyword, filters)
+	if err != nil {
+		return nil, err
+	}
+	s.tokens.remember(accountID, feeds)
+
+	detailAction := xiaohongshu.NewFeedDetailAction(page)
+
+	items := make([]FeedWithDetail, 0, topN)
+	for _, feed := range feeds {
+		if len(items) >= topN {
+			break
+		}
+		// 跳过热门搜索词等非笔记卡片
+		if feed.ID == "" || feed.XsecToken == "" {
+			continue
+		}
+
+		item := FeedWithDetail{Feed: feed}
+		detail, err := detailAction.GetFeedDetail(ctx, feed.ID, feed.XsecToken)
+		if err != nil {
+			logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("获取Feed详情失败 %s: %v", feed.ID, err)
+			item.Error = err.Error()
+		} else {
+			item.Detail = detail
+			if item.Feed.PublishedAt.IsZero() && detail.Note.Time > 0 {
+				// 列表中没有发布时间时使用详情页的时间戳
+				item.Feed.PublishedAt = time.UnixMilli(detail.Note.Time)
+			}
+		}
+		items = append(items, item)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return &SearchWithDetailsResponse{
+		Feeds: items,
+		Count: len(items),
+	}, nil
+}
+
+// ErrDebugDisabled 请求了调试数据但服务未开启调试
+var ErrDebugDisabled = errors.New("debug is disabled, start the server with -debug or XHS_MCP_DEBUG=true")
+
+// FeedDetailDebugError 获取详情失败时附带详情页原始 HTML
+type FeedDetailDebugError struct {
+	Err  error
+	HTML string
+}
+
+func (e *FeedDetailDebugError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FeedDetailDebugError) Unwrap() error {
+	return e.Err
+}
+
+// GetFeedDetail 获取Feed详情。
+// req.DebugHTML 为 true 时返回详情页原始 HTML：成功时放在 HTML 字段，失败时通过 *FeedDetailDebugError 返回；
+// req.IncludeComments 为 true 时在同一页面滚动加载评论，最多 req.CommentLimit 条一级评论。
+func (s *XiaohongshuService) GetFeedDetail(ctx context.Context, accountID string, req *FeedDetailRequest) (*FeedDetailResponse, error) {
+	feedID, debugHTML := req.FeedID, req.DebugHTML
+	if debugHTML && !s.cfg.Debug {
+		return nil, ErrDebugDisabled
+	}
+
+	commentLimit := req.CommentLimit
+	if commentLimit <= 0 {
+		commentLimit = defaultDetailCommentLimit
+	}
+	if commentLimit > maxDetailCommentLimit {
+		commentLimit = maxDetailCommentLimit
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	// 创建 Feed 详情 action
 	action := xiaohongshu.NewFeedDetailAction(page)
 
-	// 获取 Feed 详情
-	result, err := action.GetFeedDetail(ctx, feedID, xsecToken)
+	// 获取 Feed 详情，未提供 xsec_token 时自动查找，过期时自动刷新重试一次
+	var result *xiaohongshu.FeedDetailResponse
+	var usedToken string
+	err = s.withResolvedToken(ctx, accountID, b, feedID, req.XsecToken, req.Keyword, func(token string) error {
+		usedToken = token
+		result, err = action.GetFeedDetail(ctx, feedID, token)
+		return err
+	})
+	if err == nil && req.IncludeComments {
+		var comments xiaohongshu.CommentList
+		if comments, err = action.LoadComments(ctx, feedID, commentLimit); err == nil {
+			result.Comments = comments
+		}
+	}
+
+	var html string
+	if debugHTML {
+		var htmlErr error
+		if html, htmlErr = action.PageHTML(); htmlErr != nil {
+			logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("获取详情页 HTML 失败 %s: %v", feedID, htmlErr)
+		}
+	}
+
 	if err != nil {
+		if debugHTML {
+			return nil, &FeedDetailDebugError{Err: err, HTML: html}
+		}
 		return nil, err
 	}
 
 	response := &FeedDetailResponse{
-		FeedID: feedID,
-		Data:   result,
+		FeedID:    feedID,
+		XsecToken: usedToken,
+		Data:      result,
+		HTML:      html,
 	}
 
 	return response, nil
 }
 
-// UserProfile 获取用户信息
-func (s *XiaohongshuService) UserProfile(ctx context.Context, accountID, userID, xsecToken string) (*UserProfileResponse, error) {
-	b, err := s.newBrowser(accountID)
+// CheckFeedExists 检查笔记是否存在，reason 为 found/deleted/blocked/private 之一
+func (s *XiaohongshuService) CheckFeedExists(ctx context.Context, accountID, feedID, xsecToken string) (bool, string, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return false, "", err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewFeedDetailAction(page)
+	return action.CheckFeedExists(ctx, feedID, xsecToken)
+}
+
+// GetShareLink 获取笔记分享链接。网页端不支持转发到个人主页，因此只提供分享链接
+func (s *XiaohongshuService) GetShareLink(ctx context.Context, accountID, feedID, xsecToken string) (*ShareLinkResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
-	action := xiaohongshu.NewUserProfileAction(page)
+	shareURL, err := xiaohongshu.NewShareAction(page).GetShareLink(ctx, feedID, xsecToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareLinkResponse{
+		FeedID:     feedID,
+		ShareURL:   shareURL,
+		Capability: xiaohongshu.ShareCapabilityLink,
+		Note:       "网页端不支持转发笔记到个人主页，仅提供分享链接",
+	}, nil
+}
+
+// UserProfile 获取用户信息，device 非空时使用对应的移动端设备模拟访问（部分字段在移动端更完整），
+// noteType 可选 all/video/image，用于过滤主页笔记；sinceFeedID 不为空时只返回比该笔记更新的笔记
+func (s *XiaohongshuService) UserProfile(ctx context.Context, accountID, userID, xsecToken, device, noteType, sinceFeedID string) (*UserProfileResponse, error) {
+	typeFilter, err := xiaohongshu.ParseNoteTypeFilter(noteType)
+	if err != nil {
+		return nil, err
+	}
+	if device != "" {
+		if _, err := browser.LookupDevice(device); err != nil {
+			return nil, err
+		}
+	}
 
-	result, err := action.UserProfile(ctx, userID, xsecToken)
+	b, err := s.newBrowser(ctx, accountID, browser.WithDevice(device))
 	if err != nil {
 		return nil, err
 	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	if err := browser.EmulateDevice(page, device); err != nil {
+		return nil, err
+	}
+
+	action := xiaohongshu.NewUserProfileAction(page)
+
+	var (
+		result     *xiaohongshu.UserProfileResponse
+		sinceFound *bool
+	)
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		if sinceFeedID == "" {
+			result, err = action.UserProfile(ctx, userID, xsecToken)
+			return err
+		}
+		var found bool
+		result, found, err = action.UserProfileSince(ctx, userID, xsecToken, sinceFeedID)
+		sinceFound = &found
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	s.tokens.remember(accountID, result.Feeds)
 	response := &UserProfileResponse{
 		UserBasicInfo: result.UserBasicInfo,
 		Interactions:  result.Interactions,
-		Feeds:         result.Feeds,
+		Feeds:         xiaohongshu.FilterFeedsByNoteType(result.Feeds, typeFilter),
+		SinceFound:    sinceFound,
 	}
 
 	return response, nil
@@ -464,49 +1248,202 @@ func (s *XiaohongshuService) UserProfile(ctx context.Context, accountID, userID,
 }
 
 // PostCommentToFeed 发表评论到Feed
-func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, accountID, feedID, xsecToken, content string) (*PostCommentResponse, error) {
+func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, accountID, feedID, xsecToken, content, sticker string) (*PostCommentResponse, error) {
 	// 使用非无头模式以便查看操作过程
-	b, err := s.newBrowser(accountID)
+	b, err := s.newBrowser(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 	defer b.Close()
 
-	page := b.NewPage()
+	page := b.NewPage().Context(ctx)
 	defer page.Close()
 
 	// 创建 Feed 评论 action
 	action := xiaohongshu.NewCommentFeedAction(page)
 
-	// 发表评论
-	if err := action.PostComment(ctx, feedID, xsecToken, content); err != nil {
+	// 发表评论，登录失效只会发生在打开详情页阶段，此时尚未提交评论
+	var posted *xiaohongshu.PostedComment
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		posted, err = action.PostComment(ctx, feedID, xsecToken, content, sticker)
+		return err
+	}); err != nil {
 		return nil, err
 	}
 
 	response := &PostCommentResponse{
-		FeedID:  feedID,
-		Success: true,
-		Message: "评论发表成功",
+		FeedID:    feedID,
+		CommentID: posted.ID,
+		Content:   posted.Content,
+		Success:   true,
+		Message:   "评论发表成功",
+	}
+	if posted.ID == "" {
+		response.Message = "评论发表成功，但未能获取评论ID"
+	}
+
+	return response, nil
+}
+
+// ReplyToComment 回复 Feed 下的评论，mentionAuthor 为 true 时自动 @ 评论作者
+func (s *XiaohongshuService) ReplyToComment(ctx context.Context, accountID, feedID, xsecToken, commentID, content string, mentionAuthor bool) (*ReplyCommentResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewCommentFeedAction(page)
+
+	var replyID string
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		replyID, err = action.ReplyToComment(ctx, feedID, xsecToken, commentID, content, mentionAuthor)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	response := &ReplyCommentResponse{
+		FeedID:    feedID,
+		CommentID: commentID,
+		ReplyID:   replyID,
+		Success:   true,
+		Message:   "回复评论成功",
+	}
+	if replyID == "" {
+		response.Message = "回复评论成功，但未能获取回复ID"
 	}
 
 	return response, nil
 }
 
-func (s *XiaohongshuService) newBrowser(accountID string) (*headless_browser.Browser, error) {
+// LikeComment 点赞或取消点赞笔记下的指定评论
+func (s *XiaohongshuService) LikeComment(ctx context.Context, accountID, feedID, xsecToken, commentID string, unlike bool) (*LikeCommentResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action := xiaohongshu.NewCommentFeedAction(page)
+	if err := s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, func(token string) error {
+		return action.LikeComment(ctx, feedID, token, commentID, unlike)
+	}); err != nil {
+		return nil, err
+	}
+
+	message := "点赞评论成功或已点赞"
+	if unlike {
+		message = "取消点赞评论成功或未点赞"
+	}
+	return &LikeCommentResponse{FeedID: feedID, CommentID: commentID, Success: true, Message: message}, nil
+}
+
+// BrowserInfo 启动浏览器并返回实际使用的浏览器路径、版本及启动参数。
+// accountID 为空时不加载账号配置，直接以全局配置启动。
+func (s *XiaohongshuService) BrowserInfo(ctx context.Context, accountID string) (*browser.Info, error) {
+	var (
+		b   *browser.Browser
+		err error
+	)
+	if accountID == "" {
+		b, err = s.launchBrowser(ctx)
+	} else {
+		b, err = s.newBrowser(ctx, accountID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.Info()
+}
+
+// QuerySelector 以账号的登录态打开小红书页面并查询 CSS 选择器，仅在开启调试时可用。
+func (s *XiaohongshuService) QuerySelector(ctx context.Context, accountID, rawURL, selector string) (*xiaohongshu.QueryResult, error) {
+	if !s.cfg.Debug {
+		return nil, ErrDebugDisabled
+	}
+	if err := xiaohongshu.ValidateQueryURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	return xiaohongshu.NewQuerySelector(page).Query(ctx, rawURL, selector)
+}
+
+func (s *XiaohongshuService) newBrowser(ctx context.Context, accountID string, extra ...browser.Option) (*browser.Browser, error) {
 	cookiePath, err := accounts.CookiesPath(accountID)
 	if err != nil {
 		return nil, err
 	}
 
+	// 每个账号使用独立的 Chrome 配置目录，避免 localStorage/缓存在账号间串用
+	profileDir, err := accounts.ChromeProfileDir(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := accounts.AccountProxy(accountID)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := []browser.Option{
 		browser.WithCookiesPath(cookiePath),
+		browser.WithUserDataDir(profileDir),
+		browser.WithProxy(proxy),
 	}
+	opts = append(opts, extra...)
+
+	return s.launchBrowser(ctx, opts...)
+}
 
-	if bin := configs.GetBinPath(); bin != "" {
-		opts = append(opts, browser.WithBinPath(bin))
+// launchBrowser 在全局浏览器并发限制内启动浏览器，浏览器关闭时归还名额。
+// 在 ctx 结束前拿不到名额，或账号的 Chrome 配置目录一直被占用时返回 ErrBrowserBusy。
+func (s *XiaohongshuService) launchBrowser(ctx context.Context, opts ...browser.Option) (*browser.Browser, error) {
+	release, err := s.browsers.acquire(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return browser.NewBrowser(configs.IsHeadless(), opts...), nil
+	if bin := s.cfg.BinPath; bin != "" {
+		opts = append([]browser.Option{browser.WithBinPath(bin)}, opts...)
+	}
+	opts = append([]browser.Option{
+		browser.WithLocale(s.cfg.BrowserLocale),
+		browser.WithTimezone(s.cfg.BrowserTimezone),
+		browser.WithStealth(s.cfg.Stealth),
+	}, opts...)
+	opts = append(opts, browser.WithOnClose(release))
+
+	b, err := browser.Launch(ctx, s.cfg.Headless, opts...)
+	if err != nil {
+		// 启动失败时归还名额，release 可重复调用
+		release()
+		if errors.Is(err, browser.ErrProfileBusy) {
+			return nil, fmt.Errorf("%w: %v", ErrBrowserBusy, err)
+		}
+		return nil, err
+	}
+	return b, nil
 }
 
 func saveCookies(accountID string, page *rod.Page) error {
@@ -515,6 +1452,11 @@ func saveCookies(accountID string, page *rod.Page) error {
 		return err
 	}
 
+	return writeCookies(accountID, cks)
+}
+
+// writeCookies 将 cookies 写入账号的 cookies 文件
+func writeCookies(accountID string, cks []*proto.NetworkCookie) error {
 	data, err := json.Marshal(cks)
 	if err != nil {
 		return err
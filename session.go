@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// sessionCookieName 小红书网页端登录会话 cookie
+const sessionCookieName = "web_session"
+
+// ValidateSessionResponse 会话校验响应
+type ValidateSessionResponse struct {
+	AccountID  string     `json:"account_id"`
+	IsLoggedIn bool       `json:"is_logged_in"`
+	Refreshed  bool       `json:"refreshed"`            // 是否已重新保存 cookies
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // 刷新后登录会话 cookie 的过期时间，会话级 cookie 时为空
+	Message    string     `json:"message,omitempty"`
+}
+
+// ValidateSession 检查账号登录状态，已登录时把浏览器当前的 cookies 重新写回 cookies 文件以延长有效期。
+// 未登录或检查失败时不写入，避免用失效的 cookies 覆盖文件中仍可用的 cookies。
+func (s *XiaohongshuService) ValidateSession(ctx context.Context, accountID string) (*ValidateSessionResponse, error) {
+	b, err := s.newBrowser(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	isLoggedIn, err := xiaohongshu.NewLogin(page).CheckLoginStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.loginStatus.set(accountID, isLoggedIn)
+
+	response := &ValidateSessionResponse{
+		AccountID:  accountID,
+		IsLoggedIn: isLoggedIn,
+	}
+	if !isLoggedIn {
+		response.Message = "账号未登录，未更新 cookies，请重新扫码登录"
+		return response, nil
+	}
+
+	cks, err := page.Browser().GetCookies()
+	if err != nil {
+		return nil, fmt.Errorf("读取浏览器 cookies 失败: %w", err)
+	}
+	if len(cks) == 0 {
+		response.Message = "浏览器中没有 cookies，未更新 cookies 文件"
+		return response, nil
+	}
+	if err := writeCookies(accountID, cks); err != nil {
+		return nil, fmt.Errorf("保存 cookies 失败: %w", err)
+	}
+
+	response.Refreshed = true
+	response.ExpiresAt = sessionExpiry(cks)
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("已刷新 cookies，过期时间: %v", response.ExpiresAt)
+
+	return response, nil
+}
+
+// sessionExpiry 返回登录会话 cookie 的过期时间；没有 web_session 时取小红书域名下最早过期的持久 cookie，
+// 都是会话级 cookie 时返回 nil
+func sessionExpiry(cks []*proto.NetworkCookie) *time.Time {
+	var earliest *time.Time
+	for _, c := range cks {
+		if c.Session || c.Expires <= 0 {
+			continue
+		}
+		t := c.Expires.Time()
+		if c.Name == sessionCookieName {
+			return &t
+		}
+		if strings.HasSuffix(c.Domain, "xiaohongshu.com") && (earliest == nil || t.Before(*earliest)) {
+			earliest = &t
+		}
+	}
+	return earliest
+}
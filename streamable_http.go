@@ -3,12 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
 // StreamableHTTPHandler 处理 Streamable HTTP 协议的 MCP 请求
@@ -121,6 +123,10 @@ func (s *AppServer) processJSONRPCRequest(request *JSONRPCRequest, ctx context.C
 		return s.processToolsList(request)
 	case "tools/call":
 		return s.processToolCall(ctx, request)
+	case "resources/list":
+		return s.processResourcesList(request)
+	case "resources/read":
+		return s.processResourcesRead(request)
 	default:
 		return &JSONRPCResponse{
 			JSONRPC: "2.0",
@@ -138,11 +144,12 @@ func (s *AppServer) processInitialize(request *JSONRPCRequest) *JSONRPCResponse
 	result := map[string]interface{}{
 		"protocolVersion": "2025-03-26", // 使用新的协议版本
 		"capabilities": map[string]interface{}{
-			"tools": map[string]interface{}{},
+			"tools":     map[string]interface{}{},
+			"resources": map[string]interface{}{},
 		},
 		"serverInfo": map[string]interface{}{
-			"name":    "xiaohongshu-mcp",
-			"version": "2.0.0",
+			"name":    serverName,
+			"version": serverVersion,
 		},
 	}
 
@@ -155,19 +162,48 @@ func (s *AppServer) processInitialize(request *JSONRPCRequest) *JSONRPCResponse
 
 // processToolsList 处理工具列表请求
 func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
-	tools := []map[string]interface{}{
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"tools": mcpTools(),
+		},
+		ID: request.ID,
+	}
+}
+
+// mcpTools 返回所有 MCP 工具的定义，tools/list 与服务能力查询共用这一份注册表
+func mcpTools() []map[string]interface{} {
+	return []map[string]interface{}{
 		{
 			"name":        "check_login_status",
-			"description": "检查小红书登录状态",
+			"description": "检查小红书登录状态（结果会短时间缓存，返回中的 cached 表示是否来自缓存）",
 			"inputSchema": map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"force": map[string]interface{}{
+						"type":        "boolean",
+						"description": "是否跳过缓存，重新打开浏览器检查登录状态",
 					},
 				},
-				"required": []string{"account_id"},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "validate_session",
+			"description": "校验账号登录会话：已登录时重新保存浏览器当前 cookies 以延长有效期，返回刷新后的过期时间；未登录时不会覆盖 cookies 文件。适合定期调用保持会话",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+				},
+				"required": []string{},
 			},
 		},
 		{
@@ -178,10 +214,28 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 				},
-				"required": []string{"account_id"},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "upload_asset",
+			"description": "上传 base64 编码的图片并保存到账号的图片目录，返回本地路径 path，之后可在 publish_content 的 images 中直接引用，避免在发布请求中重复发送大图片",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"data": map[string]interface{}{
+						"type":        "string",
+						"description": "base64 编码的图片数据，可带 data:image/png;base64, 前缀；支持 jpg、png、webp、gif 等常见格式",
+					},
+				},
+				"required": []string{"data"},
 			},
 		},
 		{
@@ -192,7 +246,7 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"title": map[string]interface{}{
 						"type":        "string",
@@ -200,11 +254,20 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 					},
 					"content": map[string]interface{}{
 						"type":        "string",
-						"description": "正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可",
+						"description": "正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可；与 template 二选一",
+					},
+					"template": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，正文模板，与 content 二选一。占位符写作 {{name}} 或 {{.name}}（Go text/template 语法），发布前用 vars 展开，title 中的占位符同时展开",
+					},
+					"vars": map[string]interface{}{
+						"type":                 "object",
+						"description":          "模板变量，如 {\"city\": \"上海\"}；模板引用的变量必须全部提供",
+						"additionalProperties": map[string]interface{}{"type": "string"},
 					},
 					"images": map[string]interface{}{
 						"type":        "array",
-						"description": "图片路径列表（至少需要1张图片）。支持两种方式：1. HTTP/HTTPS图片链接（自动下载）；2. 本地图片绝对路径（推荐，如:/Users/user/image.jpg）",
+						"description": "图片路径列表（至少需要1张图片），按顺序上传，第一张为封面。支持两种方式：1. HTTP/HTTPS图片链接（自动下载）；2. 本地图片绝对路径（推荐，如:/Users/user/image.jpg）",
 						"items": map[string]interface{}{
 							"type": "string",
 						},
@@ -217,8 +280,23 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 							"type": "string",
 						},
 					},
+					"product_ids": map[string]interface{}{
+						"type":        "array",
+						"description": "挂载到笔记的商品 ID 列表（可选），需要账号具备商品（带货）权限，无权限时发布失败",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"first_comment": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，自动首评：发布成功后在新笔记下发表的第一条评论，结果在 first_comment 中返回，评论失败不影响发布结果",
+					},
+					"idempotency_key": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，幂等键。重试时传入相同的值将直接返回上次的发布结果，避免重复发布",
+					},
 				},
-				"required": []string{"account_id", "title", "content", "images"},
+				"required": []string{"title", "images"},
 			},
 		},
 		{
@@ -229,7 +307,7 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"title": map[string]interface{}{
 						"type":        "string",
@@ -250,22 +328,72 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 							"type": "string",
 						},
 					},
+					"music_query": map[string]interface{}{
+						"type":        "string",
+						"description": "背景音乐搜索关键词（可选），如曲名或歌手，选用第一首匹配的曲目；没有匹配时不带配乐继续发布",
+					},
+					"idempotency_key": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，幂等键。重试时传入相同的值将直接返回上次的发布结果，避免重复发布",
+					},
 				},
-				"required": []string{"account_id", "title", "content", "video"},
+				"required": []string{"title", "content", "video"},
 			},
 		},
 		{
 			"name":        "list_feeds",
-			"description": "获取指定账号的推荐内容列表",
+			"description": "获取指定账号的推荐内容列表，每条 Feed 均包含 id 和 xsecToken，可直接用于详情、点赞、收藏、评论",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"note_type": map[string]interface{}{
+						"type":        "string",
+						"description": "笔记类型过滤，可选：all(默认)、video、image",
+					},
+					"dedup_by_author": map[string]interface{}{
+						"type":        "boolean",
+						"description": "每个作者只保留第一条笔记，减少同一作者刷屏，默认 false 返回全部结果",
+					},
+					"since_feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，增量获取：只返回排在该笔记之前的内容，列表中没有该笔记时自动滚动加载；返回的 since_found 为 false 表示未找到，结果可能与上次之间有遗漏",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "like_comment",
+			"description": "点赞或取消点赞笔记下的指定评论，评论不在首屏时会滚动评论区查找",
 			"inputSchema": map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从Feed列表的xsecToken字段获取",
+					},
+					"comment_id": map[string]interface{}{
+						"type":        "string",
+						"description": "评论ID，从笔记详情的评论列表获取",
+					},
+					"unlike": map[string]interface{}{
+						"type":        "boolean",
+						"description": "是否取消点赞，true 为取消点赞",
 					},
 				},
-				"required": []string{"account_id"},
+				"required": []string{"feed_id", "xsec_token", "comment_id"},
 			},
 		},
 		{
@@ -276,7 +404,7 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"feed_id": map[string]interface{}{
 						"type":        "string",
@@ -291,7 +419,29 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 						"description": "是否取消点赞，true 为取消点赞",
 					},
 				},
-				"required": []string{"account_id", "feed_id", "xsec_token"},
+				"required": []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			"name":        "pin_note",
+			"description": "在创作者中心置顶或取消置顶当前账号自己发布的笔记；置顶数量已达上限或笔记不支持置顶时返回错误",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"note_id": map[string]interface{}{
+						"type":        "string",
+						"description": "自己发布的笔记ID",
+					},
+					"unpin": map[string]interface{}{
+						"type":        "boolean",
+						"description": "是否取消置顶，true 为取消置顶",
+					},
+				},
+				"required": []string{"note_id"},
 			},
 		},
 		{
@@ -302,7 +452,7 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"feed_id": map[string]interface{}{
 						"type":        "string",
@@ -317,23 +467,169 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 						"description": "是否取消收藏，true 为取消收藏",
 					},
 				},
-				"required": []string{"account_id", "feed_id", "xsec_token"},
+				"required": []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			"name":        "mark_not_interested",
+			"description": "将首页推荐列表中的笔记标记为“不感兴趣”，用于调整账号的推荐内容。笔记需在当前推荐列表中（如 list_feeds 的结果），否则返回 success 为 false",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从推荐列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从推荐列表的xsecToken字段获取；过期时自动刷新",
+					},
+				},
+				"required": []string{"feed_id", "xsec_token"},
 			},
 		},
 		{
 			"name":        "search_feeds",
-			"description": "用指定账号搜索小红书内容，可附加筛选条件",
+			"description": "用指定账号搜索小红书内容，可附加筛选条件。每条结果均包含 id 和 xsecToken，可直接用于后续操作",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"keyword": map[string]interface{}{
+						"type":        "string",
+						"description": "搜索关键词",
+					},
+					"sort": map[string]interface{}{
+						"type":        "string",
+						"description": "排序方式，可选：comprehensive(默认)、latest、most_likes、most_comments、most_favorites",
+					},
+					"note_type": map[string]interface{}{
+						"type":        "string",
+						"description": "笔记类型，可选：all(默认)、video、image",
+					},
+					"publish_time": map[string]interface{}{
+						"type":        "string",
+						"description": "发布时间范围，可选：all(默认)、day、week、half_year",
+					},
+					"search_scope": map[string]interface{}{
+						"type":        "string",
+						"description": "搜索范围，可选：all(默认)、seen、unseen、followed",
+					},
+					"distance": map[string]interface{}{
+						"type":        "string",
+						"description": "位置距离，可选：all(默认)、same_city、nearby",
+					},
+					"dedup_by_author": map[string]interface{}{
+						"type":        "boolean",
+						"description": "每个作者只保留第一条笔记，减少同一作者刷屏，默认 false 返回全部结果",
+					},
+				},
+				"required": []string{"keyword"},
+			},
+		},
+		{
+			"name":        "search_users",
+			"description": "按关键词搜索小红书用户（如查找品牌官方账号），返回用户 ID、昵称、小红书号、粉丝数和 xsecToken，可直接用于 user_profile；页面显示无结果时返回空列表，结果未加载出来时返回错误",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"keyword": map[string]interface{}{
+						"type":        "string",
+						"description": "用户昵称或小红书号等关键词",
+					},
+				},
+				"required": []string{"keyword"},
+			},
+		},
+		{
+			"name":        "get_hot_searches",
+			"description": "获取小红书当前热搜词，返回排名 rank、关键词 keyword 和热度 heat/heat_value；页面没有热搜面板时返回空列表",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "get_notifications",
+			"description": "获取账号的未读通知数量：评论和@ comments_and_mentions、赞和收藏 likes_and_collects、新增关注 new_followers 及总数 total；没有新通知时均为 0。只读取数量，不会把通知标记为已读",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "list_notifications",
+			"description": "获取账号通知页中最近的消息（默认“评论和@”分类），每条包含类型 type、发起用户 user、相关笔记 note（含 id 与 xsec_token）、评论 comment_id、内容 snippet 和时间 time，可用于找出有新评论的笔记并回复",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "返回的消息数量，默认 20，最多 100；列表不足时会滚动加载更多",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "mark_notifications_read",
+			"description": "将账号的所有未读通知标记为已读，避免重复处理同一批通知。返回 marked（标记数量）、remaining（仍未读数量）和 known（标记前能否读取到未读数）；没有未读通知时 marked 为 0",
 			"inputSchema": map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "search_with_details",
+			"description": "搜索小红书内容并一次性获取前 top_n 条结果的笔记详情，单条详情失败时在该条的 error 字段说明",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"keyword": map[string]interface{}{
 						"type":        "string",
 						"description": "搜索关键词",
 					},
+					"top_n": map[string]interface{}{
+						"type":        "integer",
+						"description": "获取详情的结果数量，默认 5，最多 20",
+					},
 					"sort": map[string]interface{}{
 						"type":        "string",
 						"description": "排序方式，可选：comprehensive(默认)、latest、most_likes、most_comments、most_favorites",
@@ -355,7 +651,58 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 						"description": "位置距离，可选：all(默认)、same_city、nearby",
 					},
 				},
-				"required": []string{"account_id", "keyword"},
+				"required": []string{"keyword"},
+			},
+		},
+		{
+			"name":        "export_feeds",
+			"description": "将笔记列表导出为 JSON 或 CSV 文件（保存在账号的 exports 目录），可传入已有的 feeds 结果，或提供 keyword 重新搜索后导出；计数已转换为整数并附带笔记链接",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "导出格式，可选：json(默认)、csv",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "导出文件名或相对路径（相对账号 exports 目录，不能越出该目录），默认按时间戳生成",
+					},
+					"feeds": map[string]interface{}{
+						"type":        "array",
+						"description": "list_feeds / search_feeds 返回的 feeds 数组，提供时直接导出",
+						"items":       map[string]interface{}{"type": "object"},
+					},
+					"keyword": map[string]interface{}{
+						"type":        "string",
+						"description": "未提供 feeds 时按该关键词重新搜索并导出",
+					},
+					"sort": map[string]interface{}{
+						"type":        "string",
+						"description": "重新搜索时的排序方式，可选：comprehensive(默认)、latest、most_likes、most_comments、most_favorites",
+					},
+					"note_type": map[string]interface{}{
+						"type":        "string",
+						"description": "重新搜索时的笔记类型，可选：all(默认)、video、image",
+					},
+					"publish_time": map[string]interface{}{
+						"type":        "string",
+						"description": "重新搜索时的发布时间范围，可选：all(默认)、day、week、half_year",
+					},
+					"search_scope": map[string]interface{}{
+						"type":        "string",
+						"description": "重新搜索时的搜索范围，可选：all(默认)、seen、unseen、followed",
+					},
+					"distance": map[string]interface{}{
+						"type":        "string",
+						"description": "重新搜索时的位置距离，可选：all(默认)、same_city、nearby",
+					},
+				},
+				"required": []string{},
 			},
 		},
 		{
@@ -366,7 +713,45 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，访问令牌，从Feed列表的xsecToken字段获取；只有笔记ID时可省略，将自动从推荐列表或搜索结果中查找",
+					},
+					"keyword": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，未提供 xsec_token 时用于搜索该笔记的关键词（如笔记标题）",
+					},
+					"debug_html": map[string]interface{}{
+						"type":        "boolean",
+						"description": "调试用：同时返回详情页原始 HTML，需服务以 -debug 启动",
+					},
+					"include_comments": map[string]interface{}{
+						"type":        "boolean",
+						"description": "可选，为 true 时滚动评论区加载更多评论一并返回，默认只返回首屏已加载的评论",
+					},
+					"comment_limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "include_comments 为 true 时最多返回的一级评论数量，默认 50，最多 200",
+					},
+				},
+				"required": []string{"feed_id"},
+			},
+		},
+		{
+			"name":        "check_feed_exists",
+			"description": "检查小红书笔记是否仍然存在，返回 exists 及原因（found/deleted/blocked/private）",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"feed_id": map[string]interface{}{
 						"type":        "string",
@@ -377,7 +762,63 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 						"description": "访问令牌，从Feed列表的xsecToken字段获取",
 					},
 				},
-				"required": []string{"account_id", "feed_id", "xsec_token"},
+				"required": []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			"name":        "get_share_link",
+			"description": "获取小红书笔记的分享链接（网页端不支持转发到个人主页，返回的 capability 固定为 share_link）",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从Feed列表的xsecToken字段获取",
+					},
+				},
+				"required": []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			"name":        "get_comments",
+			"description": "滚动加载笔记评论并按关键词或作者筛选，适合在热门笔记中找出需要回答的问题或需要处理的垃圾评论。返回匹配的评论（一级评论匹配或有子评论匹配时保留，子评论只保留匹配的）以及扫描数 scanned 和匹配数 matched",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从Feed列表的xsecToken字段获取；未提供时自动查找",
+					},
+					"keyword": map[string]interface{}{
+						"type":        "string",
+						"description": "只返回内容包含该关键词的评论，不区分大小写",
+					},
+					"author": map[string]interface{}{
+						"type":        "string",
+						"description": "只返回该作者的评论：用户 ID 完全匹配或昵称包含",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "最多扫描的一级评论数量，默认 50，最多 200",
+					},
+				},
+				"required": []string{"feed_id"},
 			},
 		},
 		{
@@ -388,7 +829,41 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"user_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书用户ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从Feed列表的xsecToken字段获取",
+					},
+					"device": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，使用移动端设备模拟访问：iphone、iphone_se、ipad、pixel、galaxy，默认桌面端",
+					},
+					"note_type": map[string]interface{}{
+						"type":        "string",
+						"description": "主页笔记类型过滤，可选：all(默认)、video、image",
+					},
+					"since_feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，增量获取：只返回比该笔记更新的笔记（置顶笔记除外），已加载的笔记中没有该笔记时自动滚动加载；返回的 since_found 为 false 表示未找到",
+					},
+				},
+				"required": []string{"user_id", "xsec_token"},
+			},
+		},
+		{
+			"name":        "latest_note",
+			"description": "获取小红书用户最近发布的一篇笔记（已考虑置顶笔记），返回的 id 和 xsecToken 可直接用于详情、点赞、收藏等工具；用户没有笔记时 found 为 false",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"user_id": map[string]interface{}{
 						"type":        "string",
@@ -399,7 +874,7 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 						"description": "访问令牌，从Feed列表的xsecToken字段获取",
 					},
 				},
-				"required": []string{"account_id", "user_id", "xsec_token"},
+				"required": []string{"user_id", "xsec_token"},
 			},
 		},
 		{
@@ -410,7 +885,7 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"feed_id": map[string]interface{}{
 						"type":        "string",
@@ -422,10 +897,91 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 					},
 					"content": map[string]interface{}{
 						"type":        "string",
-						"description": "评论内容",
+						"description": "评论内容，可直接包含 emoji；与 sticker 至少填一个",
+					},
+					"sticker": map[string]interface{}{
+						"type":        "string",
+						"description": "可选，平台表情代码，如 \"[笑哭R]\" 或 \"笑哭R\"，追加在评论内容之后，发表后显示为表情图片",
+					},
+				},
+				"required": []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			"name":        "reply_comment_in_feed",
+			"description": "回复小红书笔记下的指定评论，可自动 @ 评论作者",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从Feed列表的xsecToken字段获取",
+					},
+					"comment_id": map[string]interface{}{
+						"type":        "string",
+						"description": "要回复的评论ID，从笔记详情的评论列表获取",
+					},
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "回复内容",
+					},
+					"mention_author": map[string]interface{}{
+						"type":        "boolean",
+						"description": "是否自动 @ 被回复评论的作者，默认 false；联想列表中找不到该用户时以纯文本 @",
+					},
+				},
+				"required": []string{"feed_id", "xsec_token", "comment_id", "content"},
+			},
+		},
+		{
+			"name":        "reply_recent_comments",
+			"description": "批量回复笔记下的评论：按评论 ID 指定各自的回复内容，或用同一模板回复最近的评论；相邻回复之间自动间隔，返回每条评论的结果",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"feed_id": map[string]interface{}{
+						"type":        "string",
+						"description": "小红书笔记ID，从Feed列表获取",
+					},
+					"xsec_token": map[string]interface{}{
+						"type":        "string",
+						"description": "访问令牌，从Feed列表的xsecToken字段获取",
+					},
+					"replies": map[string]interface{}{
+						"type":                 "object",
+						"description":          fmt.Sprintf("评论ID到回复内容的映射，最多 %d 条；与 template 二选一", maxReplyBatchSize),
+						"additionalProperties": map[string]interface{}{"type": "string"},
+					},
+					"template": map[string]interface{}{
+						"type":        "string",
+						"description": "回复最近一级评论使用的内容，{nickname} 替换为评论作者昵称，跳过当前账号自己发表或已回复过的评论；与 replies 二选一",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("使用 template 时回复最近的评论数，默认且最多 %d", maxReplyBatchSize),
+					},
+					"mention_author": map[string]interface{}{
+						"type":        "boolean",
+						"description": "是否自动 @ 被回复评论的作者，默认 false",
+					},
+					"interval_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("相邻两条回复的间隔秒数，默认 %d，最少 %d，最多 %d；按条数估算的总耗时不能超过请求超时", int(defaultReplyInterval.Seconds()), int(minReplyInterval.Seconds()), int(maxReplyInterval.Seconds())),
 					},
 				},
-				"required": []string{"account_id", "feed_id", "xsec_token", "content"},
+				"required": []string{"feed_id", "xsec_token"},
 			},
 		},
 		{
@@ -444,24 +1000,156 @@ func (s *AppServer) processToolsList(request *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"account_id": map[string]interface{}{
 						"type":        "string",
-						"description": "账号标识，用于区分 cookies 会话",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
 					},
 					"remark": map[string]interface{}{
 						"type":        "string",
 						"description": "备注内容（可为空，表示清除备注）",
 					},
 				},
-				"required": []string{"account_id"},
+				"required": []string{},
 			},
 		},
-	}
-
-	return &JSONRPCResponse{
-		JSONRPC: "2.0",
-		Result: map[string]interface{}{
-			"tools": tools,
+		{
+			"name":        "set_account_proxy",
+			"description": "设置账号使用的代理，之后该账号的浏览器都通过此代理访问",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"proxy": map[string]interface{}{
+						"type":        "string",
+						"description": "代理地址，格式 http://[user:pass@]host:port、https://... 或 socks5://host:port（可为空，表示清除代理）",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "set_account_alias",
+			"description": "为账号设置别名（如“我的品牌号”），之后所有工具的 account_id 都可以直接传别名；别名不能与已有账号标识重名",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "别名指向的账号标识；未提供时使用当前活跃账号",
+					},
+					"alias": map[string]interface{}{
+						"type":        "string",
+						"description": "别名，最多 64 个字符",
+					},
+				},
+				"required": []string{"alias"},
+			},
+		},
+		{
+			"name":        "remove_account_alias",
+			"description": "删除账号别名",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"alias": map[string]interface{}{
+						"type":        "string",
+						"description": "要删除的别名",
+					},
+				},
+				"required": []string{"alias"},
+			},
+		},
+		{
+			"name":        "set_account_search_defaults",
+			"description": "设置账号默认的搜索筛选条件，搜索请求未指定对应筛选项时使用；全部为空时清除",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+					"sort": map[string]interface{}{
+						"type":        "string",
+						"description": "默认排序方式，可选：comprehensive、latest、most_likes、most_comments、most_favorites",
+					},
+					"note_type": map[string]interface{}{
+						"type":        "string",
+						"description": "默认笔记类型，可选：all、video、image",
+					},
+					"publish_time": map[string]interface{}{
+						"type":        "string",
+						"description": "默认发布时间范围，可选：all、day、week、half_year",
+					},
+					"search_scope": map[string]interface{}{
+						"type":        "string",
+						"description": "默认搜索范围，可选：all、seen、unseen、followed",
+					},
+					"distance": map[string]interface{}{
+						"type":        "string",
+						"description": "默认位置距离，可选：all、same_city、nearby",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "clear_images",
+			"description": "清空账号下载的图片缓存目录，返回释放的字节数",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，用于区分 cookies 会话；未提供时使用当前活跃账号",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "get_browser_info",
+			"description": "调试用：返回实际使用的浏览器路径、Chrome 版本、是否无头模式及启动参数",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"account_id": map[string]interface{}{
+						"type":        "string",
+						"description": "账号标识，按该账号的代理和配置目录启动浏览器；未提供时使用当前活跃账号，均未设置时使用全局配置",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			"name":        "get_job_status",
+			"description": "查询异步任务（如 POST /api/v1/publish/async 提交的发布）的状态：pending/running/done/failed/canceled，完成后返回结果",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"job_id": map[string]interface{}{
+						"type":        "string",
+						"description": "提交异步任务时返回的 job_id",
+					},
+				},
+				"required": []string{"job_id"},
+			},
+		},
+		{
+			"name":        "cancel_job",
+			"description": "取消未结束（pending/running）的异步任务，任务状态变为 canceled；已点击发布的笔记可能仍会发布",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"job_id": map[string]interface{}{
+						"type":        "string",
+						"description": "提交异步任务时返回的 job_id",
+					},
+				},
+				"required": []string{"job_id"},
+			},
 		},
-		ID: request.ID,
 	}
 }
 
@@ -488,8 +1176,12 @@ func (s *AppServer) processToolCall(ctx context.Context, request *JSONRPCRequest
 	switch toolName {
 	case "check_login_status":
 		result = s.handleCheckLoginStatus(ctx, toolArgs)
+	case "validate_session":
+		result = s.handleValidateSession(ctx, toolArgs)
 	case "get_login_qrcode":
 		result = s.handleGetLoginQrcode(ctx, toolArgs)
+	case "upload_asset":
+		result = s.handleUploadAsset(ctx, toolArgs)
 	case "publish_content":
 		result = s.handlePublishContent(ctx, toolArgs)
 	case "publish_video":
@@ -498,20 +1190,68 @@ func (s *AppServer) processToolCall(ctx context.Context, request *JSONRPCRequest
 		result = s.handleListFeeds(ctx, toolArgs)
 	case "search_feeds":
 		result = s.handleSearchFeeds(ctx, toolArgs)
+	case "search_users":
+		result = s.handleSearchUsers(ctx, toolArgs)
+	case "get_hot_searches":
+		result = s.handleGetHotSearches(ctx, toolArgs)
+	case "get_notifications":
+		result = s.handleGetNotifications(ctx, toolArgs)
+	case "list_notifications":
+		result = s.handleListNotifications(ctx, toolArgs)
+	case "mark_notifications_read":
+		result = s.handleMarkNotificationsRead(ctx, toolArgs)
+	case "search_with_details":
+		result = s.handleSearchWithDetails(ctx, toolArgs)
+	case "export_feeds":
+		result = s.handleExportFeeds(ctx, toolArgs)
 	case "get_feed_detail":
 		result = s.handleGetFeedDetail(ctx, toolArgs)
+	case "check_feed_exists":
+		result = s.handleCheckFeedExists(ctx, toolArgs)
+	case "get_share_link":
+		result = s.handleGetShareLink(ctx, toolArgs)
+	case "get_comments":
+		result = s.handleGetComments(ctx, toolArgs)
 	case "user_profile":
 		result = s.handleUserProfile(ctx, toolArgs)
+	case "latest_note":
+		result = s.handleLatestNote(ctx, toolArgs)
 	case "post_comment_to_feed":
 		result = s.handlePostComment(ctx, toolArgs)
+	case "reply_recent_comments":
+		result = s.handleReplyRecentComments(ctx, toolArgs)
+	case "reply_comment_in_feed":
+		result = s.handleReplyComment(ctx, toolArgs)
+	case "like_comment":
+		result = s.handleLikeComment(ctx, toolArgs)
 	case "like_feed":
 		result = s.handleLikeFeed(ctx, toolArgs)
 	case "favorite_feed":
 		result = s.handleFavoriteFeed(ctx, toolArgs)
+	case "mark_not_interested":
+		result = s.handleMarkNotInterested(ctx, toolArgs)
+	case "pin_note":
+		result = s.handlePinNote(ctx, toolArgs)
 	case "list_accounts":
 		result = s.handleListAccounts(ctx)
 	case "set_account_remark":
 		result = s.handleSetAccountRemark(ctx, toolArgs)
+	case "set_account_proxy":
+		result = s.handleSetAccountProxy(ctx, toolArgs)
+	case "set_account_alias":
+		result = s.handleSetAccountAlias(ctx, toolArgs)
+	case "remove_account_alias":
+		result = s.handleRemoveAccountAlias(ctx, toolArgs)
+	case "set_account_search_defaults":
+		result = s.handleSetAccountSearchDefaults(ctx, toolArgs)
+	case "clear_images":
+		result = s.handleClearImages(ctx, toolArgs)
+	case "get_browser_info":
+		result = s.handleGetBrowserInfo(ctx, toolArgs)
+	case "cancel_job":
+		result = s.handleCancelJob(ctx, toolArgs)
+	case "get_job_status":
+		result = s.handleGetJobStatus(ctx, toolArgs)
 	default:
 		return &JSONRPCResponse{
 			JSONRPC: "2.0",
@@ -523,6 +1263,10 @@ func (s *AppServer) processToolCall(ctx context.Context, request *JSONRPCRequest
 		}
 	}
 
+	if result != nil && result.IsError {
+		result = classifyToolResult(ctx, result)
+	}
+
 	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		Result:  result,
@@ -530,6 +1274,29 @@ func (s *AppServer) processToolCall(ctx context.Context, request *JSONRPCRequest
 	}
 }
 
+// classifyToolResult 失败原因能被 classifyError 识别时（如验证码、登录失效），以错误码和提示开头改写结果，
+// 保留原始错误中的 ID 和页面提示；请求整体超时导致的失败统一标记为 REQUEST_TIMEOUT
+func classifyToolResult(ctx context.Context, result *MCPToolResult) *MCPToolResult {
+	if class, ok := classifyError(result.err); ok {
+		text := class.code + ": " + class.message
+		if len(result.Content) > 0 {
+			text += "\n" + result.Content[0].Text
+		}
+		return &MCPToolResult{Content: []MCPContent{{Type: "text", Text: text}}, IsError: true, err: result.err}
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("REQUEST_TIMEOUT: 请求超时（超过 %s），操作已取消", configs.GetRequestTimeout()),
+			}},
+			IsError: true,
+		}
+	}
+	return result
+}
+
 // isStreamableMethod 判断方法是否支持流式响应
 func (s *AppServer) isStreamableMethod(_ string) bool {
 	// 目前我们的方法都不需要流式响应
@@ -53,6 +53,8 @@ type MCPToolCall struct {
 type MCPToolResult struct {
 	Content []MCPContent `json:"content"`
 	IsError bool         `json:"isError,omitempty"`
+
+	err error // 失败时的原始错误，用于按 errors.Is 识别失败原因，不输出
 }
 
 // MCPContent MCP 内容
@@ -65,32 +67,112 @@ type MCPContent struct {
 
 // FeedDetailRequest Feed详情请求
 type FeedDetailRequest struct {
-	FeedID    string `json:"feed_id" binding:"required"`
-	XsecToken string `json:"xsec_token" binding:"required"`
+	FeedID string `json:"feed_id" binding:"required"`
+
+	// XsecToken 为空时（例如只有旧链接中的笔记 ID）自动从缓存、推荐列表或搜索结果中查找；
+	// Keyword 为可选的搜索关键词（如笔记标题），用于在搜索结果中查找该笔记
+	XsecToken string `json:"xsec_token,omitempty"`
+	Keyword   string `json:"keyword,omitempty"`
+
+	// DebugHTML 为 true 时同时返回详情页原始 HTML，仅在开启调试（-debug）时可用
+	DebugHTML bool `json:"debug_html,omitempty"`
+
+	// IncludeComments 为 true 时滚动评论区加载更多评论，最多返回 CommentLimit 条一级评论；
+	// 默认只返回详情页首屏已加载的评论
+	IncludeComments bool `json:"include_comments,omitempty"`
+	CommentLimit    int  `json:"comment_limit,omitempty" binding:"min=0"`
 }
 
 // FeedDetailResponse Feed详情响应
 type FeedDetailResponse struct {
+	FeedID    string `json:"feed_id"`
+	XsecToken string `json:"xsec_token,omitempty"`
+	Data      any    `json:"data"`
+	HTML      string `json:"html,omitempty"`
+}
+
+// FeedExistsResponse 笔记存在性检查响应
+type FeedExistsResponse struct {
 	FeedID string `json:"feed_id"`
-	Data   any    `json:"data"`
+	Exists bool   `json:"exists"`
+	Reason string `json:"reason"`
+}
+
+// ActiveAccountResponse 活跃账号响应，account_id 为空表示未设置
+type ActiveAccountResponse struct {
+	AccountID string `json:"account_id"`
+}
+
+// AccountAliasResponse 账号别名响应，删除别名时 account_id 为空
+type AccountAliasResponse struct {
+	Alias     string `json:"alias"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+// ClearImagesResponse 清理图片缓存响应
+type ClearImagesResponse struct {
+	AccountID  string `json:"account_id"`
+	FreedBytes int64  `json:"freed_bytes"`
+}
+
+// ShareLinkResponse 笔记分享响应，capability 说明实际提供的分享能力
+type ShareLinkResponse struct {
+	FeedID     string `json:"feed_id"`
+	ShareURL   string `json:"share_url"`
+	Capability string `json:"capability"`
+	Note       string `json:"note"`
 }
 
 // PostCommentRequest 发表评论请求
 type PostCommentRequest struct {
 	FeedID    string `json:"feed_id" binding:"required"`
 	XsecToken string `json:"xsec_token" binding:"required"`
-	Content   string `json:"content" binding:"required"`
+	Content   string `json:"content"`           // 评论内容，可包含 emoji，与 sticker 至少填一个
+	Sticker   string `json:"sticker,omitempty"` // 可选，平台表情代码，如 "[笑哭R]"，追加在内容之后
 }
 
 // PostCommentResponse 发表评论响应
 type PostCommentResponse struct {
-	FeedID  string `json:"feed_id"`
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	FeedID    string `json:"feed_id"`
+	CommentID string `json:"comment_id"`
+	Content   string `json:"content"` // 实际发表的评论文本
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// ReplyCommentRequest 回复评论请求
+type ReplyCommentRequest struct {
+	FeedID        string `json:"feed_id" binding:"required"`
+	XsecToken     string `json:"xsec_token" binding:"required"`
+	CommentID     string `json:"comment_id" binding:"required"`
+	Content       string `json:"content" binding:"required"`
+	MentionAuthor bool   `json:"mention_author,omitempty"` // 可选，自动 @ 被回复评论的作者
+}
+
+// ReplyCommentResponse 回复评论响应
+type ReplyCommentResponse struct {
+	FeedID    string `json:"feed_id"`
+	CommentID string `json:"comment_id"`
+	ReplyID   string `json:"reply_id"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// LikeCommentResponse 点赞评论响应
+type LikeCommentResponse struct {
+	FeedID    string `json:"feed_id"`
+	CommentID string `json:"comment_id"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
 }
 
 // UserProfileRequest 用户主页请求
 type UserProfileRequest struct {
 	UserID    string `json:"user_id" binding:"required"`
 	XsecToken string `json:"xsec_token" binding:"required"`
+	Device    string `json:"device,omitempty"`    // 可选，移动端设备模拟，如 iphone
+	NoteType  string `json:"note_type,omitempty"` // 可选，按笔记类型过滤主页笔记：all/video/image
+
+	// SinceFeedID 可选，只返回排在该笔记之前（更新）的笔记，用于增量轮询
+	SinceFeedID string `json:"since_feed_id,omitempty"`
 }
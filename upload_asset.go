@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+)
+
+// UploadAssetRequest 上传图片请求，data 为 base64 编码的图片，可带 data:image/...;base64, 前缀
+type UploadAssetRequest struct {
+	Data string `json:"data" binding:"required"`
+}
+
+// UploadAssetResponse 上传图片响应，path 可直接用于发布请求的 images
+type UploadAssetResponse struct {
+	Path string `json:"path"`
+	Size int    `json:"size"` // 字节数
+}
+
+// UploadAsset 解码 base64 图片并保存到账号的图片目录，返回本地路径供之后的发布请求引用。
+// 同样的图片重复上传时返回同一路径；大小上限与 URL 图片下载相同（-image_download_max_size_mb）
+func (s *XiaohongshuService) UploadAsset(ctx context.Context, accountID string, req *UploadAssetRequest) (*UploadAssetResponse, error) {
+	data, err := downloader.DecodeBase64Image(req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	imageDir, err := accounts.ImagesDir(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := downloader.SaveImageData(imageDir, data, configs.GetImageDownloadMaxSize())
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithField("account", accounts.DisplayName(accountID)).Infof("已保存上传的图片: %s (%d 字节)", path, len(data))
+	return &UploadAssetResponse{Path: path, Size: len(data)}, nil
+}
@@ -0,0 +1,188 @@
+package xiaohongshu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// ErrCaptchaRequired 页面出现滑块等人机验证且未在等待时间内完成，操作无法继续。
+// 可用 errors.Is 判断，errors.As 到 *CaptchaError 获取验证码类型
+var ErrCaptchaRequired = errors.New("captcha verification required")
+
+// 验证码类型
+const (
+	CaptchaSlider  = "slider"  // 拖动滑块
+	CaptchaRotate  = "rotate"  // 旋转图片
+	CaptchaClick   = "click"   // 按提示点选文字或图标
+	CaptchaUnknown = "unknown" // 无法识别具体类型
+)
+
+// CaptchaError 验证码的详细信息
+type CaptchaError struct {
+	Type    string // 验证码类型，见 Captcha* 常量
+	PageURL string // 出现验证码的页面地址
+}
+
+func (e *CaptchaError) Error() string {
+	return fmt.Sprintf("%v: %s captcha on %s", ErrCaptchaRequired, e.Type, e.PageURL)
+}
+
+func (e *CaptchaError) Is(target error) bool {
+	return target == ErrCaptchaRequired
+}
+
+// captchaTypeHints 按顺序匹配验证码元素的 class、id、iframe 地址和提示文本，判断验证码类型
+var captchaTypeHints = []struct {
+	captchaType string
+	keywords    []string
+}{
+	{CaptchaSlider, []string{"slide", "slider", "滑块", "拖动", "向右滑动"}},
+	{CaptchaRotate, []string{"rotate", "旋转"}},
+	{CaptchaClick, []string{"click", "点选", "依次点击", "请点击"}},
+}
+
+// captchaType 根据验证码元素的特征文本判断验证码类型
+func captchaType(hints string) string {
+	hints = strings.ToLower(hints)
+	for _, h := range captchaTypeHints {
+		for _, kw := range h.keywords {
+			if strings.Contains(hints, kw) {
+				return h.captchaType
+			}
+		}
+	}
+	return CaptchaUnknown
+}
+
+// isCaptchaURL 判断页面地址是否为小红书的验证码页（如 /website-login/captcha）
+func isCaptchaURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || !isXiaohongshuHost(u.Hostname()) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(u.Path), "captcha")
+}
+
+// detectCaptchaExpr 查找可见的验证码元素，返回其 class、id、iframe 地址和文本作为类型判断的依据
+const detectCaptchaExpr = `(selector) => {
+	const visible = (el) => {
+		const style = window.getComputedStyle(el);
+		const rect = el.getBoundingClientRect();
+		return style.display !== 'none' && style.visibility !== 'hidden' && rect.width > 0 && rect.height > 0;
+	};
+	for (const el of document.querySelectorAll(selector)) {
+		if (!visible(el)) continue;
+		const cls = typeof el.className === 'string' ? el.className : '';
+		return JSON.stringify({
+			found: true,
+			hints: [cls, el.id || '', el.src || '', (el.innerText || '').slice(0, 200)].join(' '),
+		});
+	}
+	return JSON.stringify({found: false, hints: ''});
+}`
+
+// detectCaptcha 检查页面是否出现验证码，没有时返回 nil
+func detectCaptcha(page *rod.Page) *CaptchaError {
+	info, err := page.Info()
+	if err != nil || info == nil {
+		return nil
+	}
+
+	res, err := page.Evaluate(&rod.EvalOptions{
+		JS:      detectCaptchaExpr,
+		JSArgs:  []interface{}{selectors.Get(selectors.Captcha)},
+		ByValue: true,
+	})
+	var found struct {
+		Found bool   `json:"found"`
+		Hints string `json:"hints"`
+	}
+	if err == nil && res != nil {
+		_ = json.Unmarshal([]byte(res.Value.Str()), &found)
+	}
+
+	if !found.Found && !isCaptchaURL(info.URL) {
+		return nil
+	}
+	return &CaptchaError{Type: captchaType(found.Hints), PageURL: info.URL}
+}
+
+// handleCaptcha 页面出现验证码时记录类型并通知 -captcha_callback_url。非无头模式或配置了回调地址时，
+// 在 -captcha_solve_timeout 内等待手动（或外部）完成验证，完成后返回 nil；
+// 无头模式且没有回调地址、不等待或等待超时时返回 *CaptchaError。
+func handleCaptcha(page *rod.Page) error {
+	captcha := detectCaptcha(page)
+	if captcha == nil {
+		return nil
+	}
+
+	logrus.Warnf("页面出现%s验证码: %s", captcha.Type, captcha.PageURL)
+	callbackURL := configs.GetCaptchaCallbackURL()
+	if callbackURL != "" {
+		go notifyCaptcha(callbackURL, captcha)
+	}
+
+	timeout := configs.GetCaptchaSolveTimeout()
+	if timeout <= 0 || (configs.IsHeadless() && callbackURL == "") {
+		return captcha
+	}
+
+	logrus.Infof("等待完成验证，最长 %s", timeout)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if err := page.GetContext().Err(); err != nil {
+			return err
+		}
+		time.Sleep(1 * time.Second)
+		if detectCaptcha(page) == nil {
+			logrus.Infof("验证已完成，继续操作")
+			return nil
+		}
+	}
+
+	logrus.Warnf("等待 %s 后验证仍未完成", timeout)
+	return captcha
+}
+
+// captchaNotification 发送到验证码回调地址的内容
+type captchaNotification struct {
+	Event      string    `json:"event"`
+	Type       string    `json:"type"`
+	PageURL    string    `json:"page_url"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// notifyCaptcha 向回调地址 POST 验证码信息，失败时只记录日志
+func notifyCaptcha(callbackURL string, captcha *CaptchaError) {
+	body, err := json.Marshal(captchaNotification{
+		Event:      "captcha_required",
+		Type:       captcha.Type,
+		PageURL:    captcha.PageURL,
+		DetectedAt: time.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(callbackURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		logrus.Warnf("通知验证码回调地址失败: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		logrus.Warnf("验证码回调地址返回 %s", resp.Status)
+	}
+}
@@ -0,0 +1,33 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptchaType(t *testing.T) {
+	assert.Equal(t, CaptchaSlider, captchaType("red-captcha-slider 请拖动滑块完成拼图"))
+	assert.Equal(t, CaptchaSlider, captchaType(" 向右滑动完成验证"))
+	assert.Equal(t, CaptchaRotate, captchaType("captcha-rotate-img"))
+	assert.Equal(t, CaptchaClick, captchaType("请依次点击：春 天"))
+	assert.Equal(t, CaptchaUnknown, captchaType("red-captcha https://www.xiaohongshu.com/captcha"))
+}
+
+func TestIsCaptchaURL(t *testing.T) {
+	assert.True(t, isCaptchaURL("https://www.xiaohongshu.com/website-login/captcha?redirectPath=%2Fexplore"))
+	assert.False(t, isCaptchaURL("https://www.xiaohongshu.com/explore/abc"))
+	assert.False(t, isCaptchaURL("https://example.com/captcha"))
+	assert.False(t, isCaptchaURL("https://evilxiaohongshu.com/website-login/captcha"))
+}
+
+func TestCaptchaError(t *testing.T) {
+	err := errors.Wrap(&CaptchaError{Type: CaptchaSlider, PageURL: "https://www.xiaohongshu.com/explore"}, "open page")
+	assert.ErrorIs(t, err, ErrCaptchaRequired)
+	assert.Contains(t, err.Error(), ErrCaptchaRequired.Error())
+
+	var captcha *CaptchaError
+	assert.True(t, errors.As(err, &captcha))
+	assert.Equal(t, CaptchaSlider, captcha.Type)
+}
@@ -2,10 +2,13 @@ package xiaohongshu
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
 )
 
 // CommentFeedAction 表示 Feed 评论动作
@@ -18,33 +21,97 @@ func NewCommentFeedAction(page *rod.Page) *CommentFeedAction {
 	return &CommentFeedAction{page: page}
 }
 
-// PostComment 发表评论到 Feed
-func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken, content string) error {
+// PostedComment 发表成功的评论
+type PostedComment struct {
+	ID      string // 评论 ID，无法确定时为空
+	Content string // 实际发表的文本，优先取页面状态中的评论内容
+}
+
+// PostComment 发表评论到 Feed，sticker 为可选的平台表情代码（如 "[笑哭R]"），追加在 content 之后。
+// 输入框内容与预期不一致时不会提交，返回 ErrCommentTextMismatch。
+func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken, content, sticker string) (*PostedComment, error) {
 	page := f.page.Context(ctx).Timeout(60 * time.Second)
 
+	text := content + sticker
+
 	// 构建详情页 URL
 	url := makeFeedDetailURL(feedID, xsecToken)
 
 	logrus.Infof("Opening feed detail page: %s", url)
 
 	// 导航到详情页
-	page.MustNavigate(url)
+	if err := navigateAndWait(page, configs.WaitActionComment, url); err != nil {
+		return nil, err
+	}
 	page.MustWaitDOMStable()
 
 	time.Sleep(1 * time.Second)
 
-	elem := page.MustElement("div.input-box div.content-edit span")
+	elem := page.MustElement(selectors.Get(selectors.CommentInputTrigger))
+	if err := humanDelay(ctx); err != nil {
+		return nil, err
+	}
 	elem.MustClick()
 
-	elem2 := page.MustElement("div.input-box div.content-edit p.content-input")
-	elem2.MustInput(content)
+	elem2 := page.MustElement(selectors.Get(selectors.CommentInput))
+	typed, err := insertCommentText(elem2, text)
+	if err != nil {
+		return nil, err
+	}
 
 	time.Sleep(1 * time.Second)
 
-	submitButton := page.MustElement("div.bottom button.submit")
+	submitButton := page.MustElement(selectors.Get(selectors.CommentSubmit))
+	if err := humanDelay(ctx); err != nil {
+		return nil, err
+	}
+	postedAt := time.Now().Add(-time.Minute).UnixMilli()
 	submitButton.MustClick()
 
 	time.Sleep(1 * time.Second)
+	// 发送评论后可能弹出验证码，未完成验证时评论不会发出
+	if err := handleCaptcha(page); err != nil {
+		return nil, err
+	}
+
+	posted := &PostedComment{Content: strings.TrimSpace(typed)}
+	if c := waitForPostedComment(page, feedID, text, postedAt); c != nil {
+		posted.ID, posted.Content = c.ID, c.Content
+	}
+	return posted, nil
+}
+
+// waitForPostedComment 从页面状态中查找刚发表的评论，超时未找到返回 nil
+func waitForPostedComment(page *rod.Page, feedID, content string, since int64) *Comment {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if comments, err := evalComments(page, feedID); err == nil {
+			if id := findPostedComment(comments, content, since); id != "" {
+				return findCommentByID(comments, id)
+			}
+		}
+
+		if time.Now().After(deadline) || page.GetContext().Err() != nil {
+			logrus.Warnf("未能从页面状态中获取新评论的 ID: %s", feedID)
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
 
-	return nil
+// findPostedComment 在评论列表中查找内容匹配、且创建时间不早于 since 的最新评论
+func findPostedComment(comments []Comment, content string, since int64) string {
+	var (
+		id     string
+		latest int64
+	)
+	for _, c := range comments {
+		if !sameCommentText(c.Content, content) || c.CreateTime < since {
+			continue
+		}
+		if id == "" || c.CreateTime > latest {
+			id, latest = c.ID, c.CreateTime
+		}
+	}
+	return id
 }
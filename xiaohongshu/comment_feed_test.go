@@ -0,0 +1,52 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPostedComment(t *testing.T) {
+	comments := []Comment{
+		{ID: "old", Content: "好看", CreateTime: 1000},
+		{ID: "other", Content: "不错", CreateTime: 3000},
+		{ID: "new", Content: "好看 ", CreateTime: 2500},
+	}
+
+	assert.Equal(t, "new", findPostedComment(comments, "好看", 2000))
+	assert.Equal(t, "new", findPostedComment(comments, "好看", 0))
+	assert.Equal(t, "", findPostedComment(comments, "好看", 2600))
+	assert.Equal(t, "", findPostedComment(nil, "好看", 0))
+
+	// 编辑器可能补上 emoji 变体选择符
+	emoji := []Comment{{ID: "e1", Content: "好看❤️[笑哭R]", CreateTime: 3000}}
+	assert.Equal(t, "e1", findPostedComment(emoji, "好看❤[笑哭R]", 2000))
+}
+
+func TestFindCommentByID(t *testing.T) {
+	comments := []Comment{
+		{ID: "c1", SubComments: []Comment{{ID: "c1-1", UserInfo: User{Nickname: "小红"}}}},
+		{ID: "c2"},
+	}
+
+	assert.Equal(t, "c2", findCommentByID(comments, "c2").ID)
+	assert.Equal(t, "小红", findCommentByID(comments, "c1-1").UserInfo.Nickname)
+	assert.Nil(t, findCommentByID(comments, "missing"))
+}
+
+func TestFindPostedReply(t *testing.T) {
+	comments := []Comment{
+		{ID: "c1", SubComments: []Comment{
+			{ID: "old", Content: "谢谢", CreateTime: 1000},
+			{ID: "r1", Content: "@小红 谢谢", CreateTime: 2500},
+			{ID: "c1-2", Content: "不客气", CreateTime: 2600},
+		}},
+		{ID: "c2", SubComments: []Comment{{ID: "r2", Content: "谢谢", CreateTime: 3000}}},
+	}
+
+	assert.Equal(t, "r1", findPostedReply(comments, "c1", "谢谢", 2000))
+	// 回复楼中楼时在同一楼层下查找
+	assert.Equal(t, "r1", findPostedReply(comments, "c1-2", "谢谢", 2000))
+	assert.Equal(t, "r2", findPostedReply(comments, "c2", "谢谢", 0))
+	assert.Equal(t, "", findPostedReply(comments, "c1", "谢谢", 2700))
+}
@@ -0,0 +1,94 @@
+package xiaohongshu
+
+import "strings"
+
+// CommentFilter 评论筛选条件，字段为空表示不按该条件筛选，同时提供时需全部满足
+type CommentFilter struct {
+	Keyword string // 评论内容包含的关键词，不区分大小写
+	Author  string // 作者用户 ID（完全匹配）或昵称（包含，不区分大小写）
+}
+
+// IsEmpty 是否没有任何筛选条件
+func (f CommentFilter) IsEmpty() bool {
+	return strings.TrimSpace(f.Keyword) == "" && strings.TrimSpace(f.Author) == ""
+}
+
+// Match 判断单条评论是否满足筛选条件，不检查子评论
+func (f CommentFilter) Match(c Comment) bool {
+	if keyword := strings.ToLower(strings.TrimSpace(f.Keyword)); keyword != "" &&
+		!strings.Contains(strings.ToLower(c.Content), keyword) {
+		return false
+	}
+
+	author := strings.TrimSpace(f.Author)
+	if author == "" || c.UserInfo.UserID == author {
+		return true
+	}
+	author = strings.ToLower(author)
+	for _, name := range []string{c.UserInfo.Nickname, c.UserInfo.NickName} {
+		if name != "" && strings.Contains(strings.ToLower(name), author) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterComments 按条件筛选已加载的评论（含子评论），返回筛选后的一级评论、扫描的评论总数和匹配的评论数。
+// 一级评论本身匹配或有子评论匹配时保留，其 SubComments 只保留匹配的子评论。
+func FilterComments(comments []Comment, filter CommentFilter) (filtered []Comment, scanned, matched int) {
+	filtered = make([]Comment, 0)
+	for _, c := range comments {
+		scanned += 1 + len(c.SubComments)
+		if filter.IsEmpty() {
+			matched += 1 + len(c.SubComments)
+			filtered = append(filtered, c)
+			continue
+		}
+
+		subs := make([]Comment, 0)
+		for _, sub := range c.SubComments {
+			if filter.Match(sub) {
+				subs = append(subs, sub)
+			}
+		}
+		self := filter.Match(c)
+		if self {
+			matched++
+		}
+		matched += len(subs)
+
+		if self || len(subs) > 0 {
+			c.SubComments = subs
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, scanned, matched
+}
+
+// CommentsAwaitingReply 返回还需要 selfUserID 回复的一级评论：跳过 selfUserID 自己发表的评论，
+// 以及已加载的子评论中已有 selfUserID 回复的评论，避免重复执行时再次回复。
+// 未加载的子评论无法检查；selfUserID 为空时原样返回
+func CommentsAwaitingReply(comments []Comment, selfUserID string) []Comment {
+	if selfUserID == "" {
+		return comments
+	}
+
+	pending := make([]Comment, 0, len(comments))
+	for _, c := range comments {
+		if c.UserInfo.UserID == selfUserID || repliedBy(c, selfUserID) {
+			continue
+		}
+		pending = append(pending, c)
+	}
+	return pending
+}
+
+// repliedBy 判断评论已加载的子评论中是否有 userID 发表的回复
+func repliedBy(c Comment, userID string) bool {
+	for _, sub := range c.SubComments {
+		if sub.UserInfo.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterComments(t *testing.T) {
+	comments := []Comment{
+		{ID: "c1", Content: "请问这个在哪里买？", UserInfo: User{UserID: "u1", Nickname: "小明"}},
+		{ID: "c2", Content: "好看", UserInfo: User{UserID: "u2", Nickname: "Alice"}, SubComments: []Comment{
+			{ID: "s1", Content: "在哪里买的", UserInfo: User{UserID: "u3", Nickname: "Bob"}},
+			{ID: "s2", Content: "同问", UserInfo: User{UserID: "u1", Nickname: "小明"}},
+		}},
+		{ID: "c3", Content: "加V看更多", UserInfo: User{UserID: "u4", Nickname: "spam"}},
+	}
+
+	filtered, scanned, matched := FilterComments(comments, CommentFilter{Keyword: "哪里买"})
+	assert.Equal(t, 5, scanned)
+	assert.Equal(t, 2, matched)
+	if assert.Len(t, filtered, 2) {
+		assert.Equal(t, "c1", filtered[0].ID)
+		assert.Equal(t, "c2", filtered[1].ID)
+		assert.Len(t, filtered[1].SubComments, 1)
+		assert.Equal(t, "s1", filtered[1].SubComments[0].ID)
+	}
+	// 不修改原始评论
+	assert.Len(t, comments[1].SubComments, 2)
+
+	// 作者按用户 ID 完全匹配或昵称包含匹配
+	_, _, matched = FilterComments(comments, CommentFilter{Author: "u1"})
+	assert.Equal(t, 2, matched)
+	filtered, _, matched = FilterComments(comments, CommentFilter{Author: "ALI"})
+	assert.Equal(t, 1, matched)
+	assert.Equal(t, "c2", filtered[0].ID)
+	assert.Empty(t, filtered[0].SubComments)
+
+	// 同时提供时需全部满足
+	_, _, matched = FilterComments(comments, CommentFilter{Keyword: "同问", Author: "小明"})
+	assert.Equal(t, 1, matched)
+	_, _, matched = FilterComments(comments, CommentFilter{Keyword: "好看", Author: "spam"})
+	assert.Equal(t, 0, matched)
+
+	// 没有筛选条件时全部返回
+	filtered, scanned, matched = FilterComments(comments, CommentFilter{})
+	assert.Len(t, filtered, 3)
+	assert.Equal(t, scanned, matched)
+}
+
+func TestCommentsAwaitingReply(t *testing.T) {
+	comments := []Comment{
+		{ID: "c1", UserInfo: User{UserID: "u1"}},
+		{ID: "c2", UserInfo: User{UserID: "self"}},
+		{ID: "c3", UserInfo: User{UserID: "u2"}, SubComments: []Comment{
+			{ID: "s1", UserInfo: User{UserID: "u3"}},
+			{ID: "s2", UserInfo: User{UserID: "self"}},
+		}},
+		{ID: "c4", UserInfo: User{UserID: "u4"}, SubComments: []Comment{
+			{ID: "s3", UserInfo: User{UserID: "u1"}},
+		}},
+	}
+
+	pending := CommentsAwaitingReply(comments, "self")
+	if assert.Len(t, pending, 2) {
+		assert.Equal(t, "c1", pending[0].ID)
+		assert.Equal(t, "c4", pending[1].ID)
+	}
+
+	assert.Len(t, CommentsAwaitingReply(comments, ""), 4)
+}
@@ -0,0 +1,171 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// ErrCommentNotFound 滚动加载完评论列表后仍未找到指定评论
+var ErrCommentNotFound = errors.New("comment not found")
+
+const (
+	// maxCommentScrolls 查找评论时最多滚动加载的次数
+	maxCommentScrolls = 30
+	// maxStaleCommentScrolls 连续多少次滚动后评论数量不再增加即认为已加载完
+	maxStaleCommentScrolls = 3
+)
+
+// scrollCommentsExpr 将详情页的评论区滚动到底部以加载更多评论，返回是否已到达评论末尾
+const scrollCommentsExpr = `() => {
+	const scroller = document.querySelector('.note-scroller');
+	if (scroller) {
+		scroller.scrollTop = scroller.scrollHeight;
+	} else {
+		window.scrollTo(0, document.body.scrollHeight);
+	}
+	return !!document.querySelector('.comments-container .end-container');
+}`
+
+// LikeComment 点赞或取消点赞笔记下的指定评论。
+// 评论不在首屏时会滚动评论区加载更多，加载完仍未找到时返回 ErrCommentNotFound；
+// 评论已处于目标状态时直接返回，点击后会校验页面状态，两次点击仍未生效时返回错误。
+func (f *CommentFeedAction) LikeComment(ctx context.Context, feedID, xsecToken, commentID string, unlike bool) error {
+	page := f.page.Context(ctx).Timeout(60 * time.Second)
+
+	actionType := actionLike
+	if unlike {
+		actionType = actionUnlike
+	}
+
+	url := makeFeedDetailURL(feedID, xsecToken)
+
+	logrus.Infof("Opening feed detail page for comment %s: %s", actionType, url)
+
+	if err := navigateAndWait(page, configs.WaitActionComment, url); err != nil {
+		return err
+	}
+	if err := page.WaitDOMStable(time.Second, 0); err != nil {
+		return errors.Wrap(err, "wait feed detail page stable failed")
+	}
+	time.Sleep(1 * time.Second)
+
+	if isFeedMissing(page, feedID) {
+		return errors.Wrapf(ErrStaleXsecToken, "feed %s", feedID)
+	}
+
+	commentElem, err := scrollToComment(page, feedID, commentID)
+	if err != nil {
+		return err
+	}
+
+	target := !unlike
+	if liked, found := commentLiked(page, feedID, commentID); found && liked == target {
+		logrus.Infof("comment %s already in target state (%s), skip clicking", commentID, actionType)
+		return nil
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := clickCommentLike(ctx, commentElem); err != nil {
+			return err
+		}
+		time.Sleep(2 * time.Second)
+
+		liked, found := commentLiked(page, feedID, commentID)
+		if !found {
+			logrus.Warnf("验证评论%s状态失败: 页面状态中未找到评论 %s", actionType, commentID)
+			return nil
+		}
+		if liked == target {
+			logrus.Infof("comment %s %s成功", commentID, actionType)
+			return nil
+		}
+		logrus.Warnf("comment %s %s可能未成功，状态未变化（第 %d 次点击）", commentID, actionType, attempt)
+	}
+
+	return errors.Errorf("评论 %s %s未生效，请稍后重试", commentID, actionType)
+}
+
+// scrollToComment 查找评论元素，未加载时滚动评论区直到找到、到达评论末尾或评论数量不再增加
+func scrollToComment(page *rod.Page, feedID, commentID string) (*rod.Element, error) {
+	selector := fmt.Sprintf("#comment-%s", commentID)
+
+	loaded, stale := -1, 0
+	for i := 0; i <= maxCommentScrolls; i++ {
+		if has, elem, err := page.Has(selector); err == nil && has {
+			if err := elem.ScrollIntoView(); err != nil {
+				logrus.Warnf("scroll comment %s into view failed: %v", commentID, err)
+			}
+			return elem, nil
+		}
+		if err := page.GetContext().Err(); err != nil {
+			return nil, err
+		}
+
+		res, err := page.Evaluate(&rod.EvalOptions{JS: scrollCommentsExpr, ByValue: true})
+		if err != nil {
+			return nil, errors.Wrap(err, "scroll comments failed")
+		}
+		reachedEnd := res != nil && res.Value.Bool()
+
+		time.Sleep(1 * time.Second)
+
+		count := 0
+		if comments, err := evalComments(page, feedID); err == nil {
+			count = len(comments)
+		}
+		stale = nextStaleScrolls(loaded, count, stale)
+		loaded = count
+
+		if (reachedEnd || stale >= maxStaleCommentScrolls) && !pageHas(page, selector) {
+			break
+		}
+	}
+
+	return nil, errors.Wrapf(ErrCommentNotFound, "comment %s not found in feed %s", commentID, feedID)
+}
+
+// nextStaleScrolls 计算连续滚动后评论数量未增加的次数
+func nextStaleScrolls(prevCount, count, stale int) int {
+	if count > prevCount {
+		return 0
+	}
+	return stale + 1
+}
+
+func pageHas(page *rod.Page, selector string) bool {
+	has, _, err := page.Has(selector)
+	return err == nil && has
+}
+
+// commentLiked 从页面状态中读取评论的点赞状态
+func commentLiked(page *rod.Page, feedID, commentID string) (liked, found bool) {
+	comments, err := evalComments(page, feedID)
+	if err != nil {
+		return false, false
+	}
+	c := findCommentByID(comments, commentID)
+	if c == nil {
+		return false, false
+	}
+	return c.Liked, true
+}
+
+// clickCommentLike 点击评论的点赞按钮
+func clickCommentLike(ctx context.Context, commentElem *rod.Element) error {
+	likeButton, err := commentElem.Element(selectors.Get(selectors.CommentLikeButton))
+	if err != nil {
+		return errors.Wrap(err, "comment like button not found")
+	}
+	if err := humanDelay(ctx); err != nil {
+		return err
+	}
+	return likeButton.Click(proto.InputMouseButtonLeft, 1)
+}
@@ -0,0 +1,14 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextStaleScrolls(t *testing.T) {
+	assert.Equal(t, 0, nextStaleScrolls(-1, 10, 0), "first load")
+	assert.Equal(t, 0, nextStaleScrolls(10, 20, 2), "more comments loaded")
+	assert.Equal(t, 1, nextStaleScrolls(20, 20, 0))
+	assert.Equal(t, 3, nextStaleScrolls(20, 20, 2))
+}
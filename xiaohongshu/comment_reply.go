@@ -0,0 +1,246 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// ReplyToComment 回复 Feed 下的指定评论，返回新回复的 ID，无法确定时返回空字符串。
+// mentionAuthor 为 true 时在回复前自动 @ 被回复评论的作者，优先通过 @ 联想下拉框选择，
+// 以便生成可点击的提及；下拉框中没有匹配的用户时退化为纯文本。
+func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToken, commentID, content string, mentionAuthor bool) (string, error) {
+	page := f.page.Context(ctx).Timeout(60 * time.Second)
+
+	url := makeFeedDetailURL(feedID, xsecToken)
+
+	logrus.Infof("Opening feed detail page for reply: %s", url)
+
+	if err := navigateAndWait(page, configs.WaitActionComment, url); err != nil {
+		return "", err
+	}
+	if err := page.WaitDOMStable(time.Second, 0); err != nil {
+		return "", errors.Wrap(err, "wait dom stable failed")
+	}
+
+	time.Sleep(1 * time.Second)
+
+	target, err := loadComment(page, feedID, commentID)
+	if err != nil {
+		return "", err
+	}
+
+	commentElem, err := page.Element(fmt.Sprintf("#comment-%s", commentID))
+	if err != nil {
+		return "", errors.Wrapf(err, "comment %s not found on page", commentID)
+	}
+	replyButton, err := commentElem.Element(selectors.Get(selectors.CommentReplyButton))
+	if err != nil {
+		return "", errors.Wrap(err, "reply button not found")
+	}
+	if err := humanDelay(ctx); err != nil {
+		return "", err
+	}
+	if err := replyButton.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return "", errors.Wrap(err, "click reply button failed")
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	input, err := page.Element(selectors.Get(selectors.CommentInput))
+	if err != nil {
+		return "", errors.Wrap(err, "comment input not found")
+	}
+
+	if mentionAuthor {
+		if nickname := strings.TrimSpace(target.UserInfo.Nickname); nickname != "" {
+			if err := inputMention(input, nickname); err != nil {
+				return "", err
+			}
+		} else {
+			logrus.Warnf("评论 %s 缺少作者昵称，跳过 @ 提及", commentID)
+		}
+	}
+
+	if _, err := insertCommentText(input, content); err != nil {
+		return "", err
+	}
+
+	time.Sleep(1 * time.Second)
+
+	submitButton, err := page.Element(selectors.Get(selectors.CommentSubmit))
+	if err != nil {
+		return "", errors.Wrap(err, "submit button not found")
+	}
+	if err := humanDelay(ctx); err != nil {
+		return "", err
+	}
+	postedAt := time.Now().Add(-time.Minute).UnixMilli()
+	if err := submitButton.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return "", errors.Wrap(err, "click submit button failed")
+	}
+
+	time.Sleep(1 * time.Second)
+	// 发送回复后可能弹出验证码，未完成验证时回复不会发出
+	if err := handleCaptcha(page); err != nil {
+		return "", err
+	}
+
+	return waitForPostedReplyID(page, feedID, commentID, content, postedAt), nil
+}
+
+// inputMention 输入 @昵称，并从联想下拉框中选择昵称完全一致的用户；
+// 没有匹配项时补一个空格，保留纯文本的 @昵称
+func inputMention(input *rod.Element, nickname string) error {
+	if err := input.Input("@"); err != nil {
+		return errors.Wrap(err, "input mention failed")
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	for _, char := range nickname {
+		if err := input.Input(string(char)); err != nil {
+			return errors.Wrap(err, "input mention failed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	page := input.Page()
+	items, err := page.Elements(selectors.Get(selectors.MentionItem))
+	if err == nil {
+		for _, item := range items {
+			name, err := item.Text()
+			if err != nil || strings.TrimSpace(name) != nickname {
+				continue
+			}
+			if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+				logrus.Warnf("点击 @ 联想用户失败，使用纯文本提及: %v", err)
+				break
+			}
+			logrus.Infof("成功选择 @ 联想用户: %s", nickname)
+			time.Sleep(200 * time.Millisecond)
+			return nil
+		}
+	}
+
+	logrus.Warnf("未找到匹配的 @ 联想用户，使用纯文本提及: %s", nickname)
+	if err := input.Input(" "); err != nil {
+		return errors.Wrap(err, "input mention failed")
+	}
+	return nil
+}
+
+// loadComment 从页面状态中读取评论列表并查找指定评论
+func loadComment(page *rod.Page, feedID, commentID string) (*Comment, error) {
+	comments, err := evalComments(page, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := findCommentByID(comments, commentID)
+	if target == nil {
+		return nil, fmt.Errorf("comment %s not found in feed %s", commentID, feedID)
+	}
+	return target, nil
+}
+
+// evalComments 读取页面状态中已加载的评论列表
+func evalComments(page *rod.Page, feedID string) ([]Comment, error) {
+	comments, err := evalCommentList(page, feedID)
+	if err != nil {
+		return nil, err
+	}
+	return comments.List, nil
+}
+
+// evalCommentList 读取页面状态中的评论数据，包括是否还有更多评论
+func evalCommentList(page *rod.Page, feedID string) (CommentList, error) {
+	res, err := page.Evaluate(&rod.EvalOptions{JS: `(id) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		const detail = map && map[id];
+		const comments = detail && detail.comments;
+		return JSON.stringify(comments || {});
+	}`, JSArgs: []interface{}{feedID}, ByValue: true})
+	if err != nil {
+		return CommentList{}, errors.Wrap(err, "failed to evaluate comments")
+	}
+	if res == nil {
+		return CommentList{}, errors.New("failed to evaluate comments")
+	}
+
+	var comments CommentList
+	if err := json.Unmarshal([]byte(res.Value.Str()), &comments); err != nil {
+		return CommentList{}, errors.Wrap(err, "failed to unmarshal comments")
+	}
+	if comments.List == nil {
+		comments.List = []Comment{}
+	}
+	return comments, nil
+}
+
+// findCommentByID 在评论及其子评论中查找指定 ID 的评论
+func findCommentByID(comments []Comment, commentID string) *Comment {
+	for i := range comments {
+		if comments[i].ID == commentID {
+			return &comments[i]
+		}
+		if sub := findCommentByID(comments[i].SubComments, commentID); sub != nil {
+			return sub
+		}
+	}
+	return nil
+}
+
+// waitForPostedReplyID 从页面状态中查找刚发表的回复 ID，超时未找到返回空字符串
+func waitForPostedReplyID(page *rod.Page, feedID, commentID, content string, since int64) string {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if comments, err := evalComments(page, feedID); err == nil {
+			if id := findPostedReply(comments, commentID, content, since); id != "" {
+				return id
+			}
+		}
+
+		if time.Now().After(deadline) || page.GetContext().Err() != nil {
+			logrus.Warnf("未能从页面状态中获取新回复的 ID: %s", commentID)
+			return ""
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// findPostedReply 在评论所属楼层的子评论中查找以 content 结尾（允许前置 @ 提及）、
+// 且创建时间不早于 since 的最新回复
+func findPostedReply(comments []Comment, commentID, content string, since int64) string {
+	content = strings.TrimSpace(content)
+
+	var (
+		id     string
+		latest int64
+	)
+	for _, c := range comments {
+		if c.ID != commentID && findCommentByID(c.SubComments, commentID) == nil {
+			continue
+		}
+		for _, sub := range c.SubComments {
+			if !strings.HasSuffix(strings.TrimSpace(sub.Content), content) || sub.CreateTime < since {
+				continue
+			}
+			if id == "" || sub.CreateTime > latest {
+				id, latest = sub.ID, sub.CreateTime
+			}
+		}
+	}
+	return id
+}
@@ -0,0 +1,119 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCommentTextMismatch 输入框中的文本与要发表的内容不一致（如 emoji 被拆分或丢失）
+var ErrCommentTextMismatch = errors.New("comment text mismatch")
+
+// maxStickerCodeLen 表情代码（不含方括号）的最大长度
+const maxStickerCodeLen = 16
+
+// pasteTextExpr 以粘贴的方式向输入框写入文本：先派发 paste 事件交给编辑器处理，
+// 编辑器未处理时再用 insertText 命令插入，返回输入框最终的文本
+const pasteTextExpr = `function (text) {
+	this.focus();
+	const data = new DataTransfer();
+	data.setData('text/plain', text);
+	const event = new ClipboardEvent('paste', { clipboardData: data, bubbles: true, cancelable: true });
+	if (this.dispatchEvent(event)) {
+		document.execCommand('insertText', false, text);
+	}
+	return this.innerText;
+}`
+
+// restoreInputExpr 恢复输入框原有内容，并把光标移到末尾
+const restoreInputExpr = `function (html) {
+	this.innerHTML = html;
+	this.focus();
+	const range = document.createRange();
+	range.selectNodeContents(this);
+	range.collapse(false);
+	const selection = window.getSelection();
+	selection.removeAllRanges();
+	selection.addRange(range);
+}`
+
+// NormalizeStickerCode 规范化平台表情代码，如 "笑哭R" 或 "[笑哭R]" 均返回 "[笑哭R]"。
+// 小红书评论中的表情以 [名称] 形式的文本保存，发表后由站点渲染为表情图片。
+func NormalizeStickerCode(code string) (string, error) {
+	name := strings.TrimSpace(code)
+	name = strings.TrimPrefix(name, "[")
+	name = strings.TrimSuffix(name, "]")
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", errors.New("sticker code is empty")
+	}
+	if strings.ContainsAny(name, "[]") || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return "", errors.Errorf("invalid sticker code %q", code)
+	}
+	if len([]rune(name)) > maxStickerCodeLen {
+		return "", errors.Errorf("sticker code %q is too long", code)
+	}
+	return "[" + name + "]", nil
+}
+
+// insertCommentText 在评论输入框已有内容（如 @ 提及）之后写入完整文本，返回输入框最终的文本。
+// 整段通过 Input.insertText 一次写入而不是逐字符按键，避免多字节 emoji 被拆分；
+// 写入后校验输入框文本，不一致时恢复原有内容并改用粘贴的方式重新写入，仍不一致时返回 ErrCommentTextMismatch。
+func insertCommentText(input *rod.Element, text string) (string, error) {
+	before := elementText(input)
+	res, err := input.Eval(`function () { return this.innerHTML; }`)
+	if err != nil {
+		return "", errors.Wrap(err, "read comment input failed")
+	}
+	html := res.Value.Str()
+
+	if err := input.Input(text); err != nil {
+		return "", errors.Wrap(err, "input comment text failed")
+	}
+	want := before + text
+	got := elementText(input)
+	if sameCommentText(got, want) {
+		return got, nil
+	}
+
+	logrus.Warnf("评论输入框内容与预期不一致，改用粘贴方式写入: %q", got)
+	if _, err := input.Eval(restoreInputExpr, html); err != nil {
+		return "", errors.Wrap(err, "restore comment input failed")
+	}
+	res, err = input.Eval(pasteTextExpr, text)
+	if err != nil {
+		return "", errors.Wrap(err, "paste comment text failed")
+	}
+	got = res.Value.Str()
+	if !sameCommentText(got, want) {
+		return "", errors.Wrapf(ErrCommentTextMismatch, "want %q, got %q", want, got)
+	}
+	return got, nil
+}
+
+// elementText 返回元素的可见文本，读取失败时返回空字符串
+func elementText(el *rod.Element) string {
+	res, err := el.Eval(`function () { return this.innerText; }`)
+	if err != nil || res == nil {
+		return ""
+	}
+	return res.Value.Str()
+}
+
+// sameCommentText 比较两段评论文本是否一致，忽略空白和 emoji 变体选择符（编辑器可能补上或去掉 U+FE0F）
+func sameCommentText(a, b string) bool {
+	return normalizeCommentText(a) == normalizeCommentText(b)
+}
+
+func normalizeCommentText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '\uFE0F' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,29 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeStickerCode(t *testing.T) {
+	for _, code := range []string{"笑哭R", "[笑哭R]", " [笑哭R] ", "[ 笑哭R ]"} {
+		got, err := NormalizeStickerCode(code)
+		require.NoError(t, err, code)
+		assert.Equal(t, "[笑哭R]", got)
+	}
+
+	for _, code := range []string{"", "[]", "[笑[哭]R]", "笑 哭", "[这是一个非常非常非常非常长的表情名字R]"} {
+		_, err := NormalizeStickerCode(code)
+		assert.Error(t, err, code)
+	}
+}
+
+func TestSameCommentText(t *testing.T) {
+	assert.True(t, sameCommentText("好看😂👍🏻", "好看😂👍🏻"))
+	assert.True(t, sameCommentText("好看 ❤️\n", "好看❤"))
+	assert.True(t, sameCommentText("@小红 谢谢👨‍👩‍👧", "@小红 谢谢👨‍👩‍👧"))
+	assert.False(t, sameCommentText("好看😂", "好看��"))
+	assert.False(t, sameCommentText("好看[笑哭R]", "好看"))
+}
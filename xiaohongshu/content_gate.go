@@ -0,0 +1,114 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrContentGated 笔记详情被年龄/内容确认弹窗拦截且无法自动确认，笔记数据不会加载。
+// 可用 errors.Is 判断，errors.As 到 *ContentGatedError 获取弹窗提示
+var ErrContentGated = errors.New("note is behind a content confirmation gate that could not be dismissed")
+
+// ContentGatedError 内容确认弹窗的详细信息
+type ContentGatedError struct {
+	FeedID  string // 笔记 ID
+	Message string // 弹窗中的提示
+}
+
+func (e *ContentGatedError) Error() string {
+	return fmt.Sprintf("feed %s: %v: %s", e.FeedID, ErrContentGated, e.Message)
+}
+
+func (e *ContentGatedError) Is(target error) bool {
+	return target == ErrContentGated
+}
+
+// contentGateKeywords 弹窗文本包含这些关键词时认为是查看前需要确认的内容提示弹窗
+var contentGateKeywords = []string{
+	"年满18", "已满18", "满18周岁", "未成年", "年龄确认", "年龄验证",
+	"敏感内容", "引起不适", "令人不适", "谨慎观看", "确认是否继续查看",
+}
+
+// contentGateConfirmLabels 可用于确认继续查看的按钮文本
+var contentGateConfirmLabels = []string{
+	"继续查看", "仍要查看", "确认查看", "查看内容", "继续浏览", "我已年满18岁", "我已满18岁", "已满18岁", "确认", "确定", "继续",
+}
+
+// matchContentGateDialog 在弹窗中查找内容确认弹窗，返回弹窗下标和确认按钮下标。
+// 找不到弹窗时 dialogIdx 为 -1；找到弹窗但没有确认按钮时 buttonIdx 为 -1。
+// 按 contentGateConfirmLabels 的顺序选择按钮，避免误点“返回”等按钮。
+func matchContentGateDialog(dialogs []pageDialog) (dialogIdx, buttonIdx int) {
+	for i, d := range dialogs {
+		if !d.Visible || !containsAny(d.Text, contentGateKeywords) {
+			continue
+		}
+		for _, confirm := range contentGateConfirmLabels {
+			for j, label := range d.Buttons {
+				if strings.TrimSpace(label) == confirm {
+					return i, j
+				}
+			}
+		}
+		return i, -1
+	}
+	return -1, -1
+}
+
+// contentGateMessage 取弹窗文本中第一行包含关键词的提示
+func contentGateMessage(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); containsAny(line, contentGateKeywords) {
+			return line
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+// dismissContentGate 检测详情页的年龄/内容确认弹窗，能确认时自动点击继续查看，
+// 无法确认或点击后弹窗仍在时返回 *ContentGatedError。读取页面失败时不视为弹窗拦截。
+func dismissContentGate(page *rod.Page, feedID string) error {
+	var message string
+	// 最多点击两次，最后一轮只检查弹窗是否已关闭
+	for attempt := 0; attempt < 3; attempt++ {
+		dialogs, ok := evalDialogs(page)
+		if !ok {
+			return nil
+		}
+
+		dialogIdx, buttonIdx := matchContentGateDialog(dialogs)
+		if dialogIdx < 0 {
+			return nil
+		}
+		message = contentGateMessage(dialogs[dialogIdx].Text)
+		if buttonIdx < 0 || attempt == 2 {
+			break
+		}
+
+		logrus.Infof("笔记 %s 出现内容确认弹窗（%s），点击“%s”继续", feedID, message, dialogs[dialogIdx].Buttons[buttonIdx])
+		if err := clickDialogButton(page, dialogIdx, buttonIdx); err != nil {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	logrus.Warnf("笔记 %s 被内容确认弹窗拦截: %s", feedID, message)
+	return &ContentGatedError{FeedID: feedID, Message: message}
+}
+
+// checkContentGate 页面上仍有内容确认弹窗时返回 *ContentGatedError，不点击任何按钮
+func checkContentGate(page *rod.Page, feedID string) error {
+	dialogs, ok := evalDialogs(page)
+	if !ok {
+		return nil
+	}
+	dialogIdx, _ := matchContentGateDialog(dialogs)
+	if dialogIdx < 0 {
+		return nil
+	}
+	return &ContentGatedError{FeedID: feedID, Message: contentGateMessage(dialogs[dialogIdx].Text)}
+}
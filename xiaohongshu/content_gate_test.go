@@ -0,0 +1,70 @@
+package xiaohongshu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchContentGateDialog(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialogs    []pageDialog
+		wantDialog int
+		wantButton int
+	}{
+		{
+			name:       "no dialogs",
+			wantDialog: -1,
+			wantButton: -1,
+		},
+		{
+			name: "unrelated dialog",
+			dialogs: []pageDialog{
+				{Visible: true, Text: "确认删除这条笔记？", Buttons: []string{"取消", "确认"}},
+			},
+			wantDialog: -1,
+			wantButton: -1,
+		},
+		{
+			name: "age gate prefers explicit confirm",
+			dialogs: []pageDialog{
+				{Visible: true, Text: "该内容仅适合年满18周岁的用户查看\n请确认你的年龄", Buttons: []string{"返回", "确认", " 我已年满18岁 "}},
+			},
+			wantDialog: 0,
+			wantButton: 2,
+		},
+		{
+			name: "hidden gate",
+			dialogs: []pageDialog{
+				{Visible: false, Text: "内容可能引起不适", Buttons: []string{"继续查看"}},
+			},
+			wantDialog: -1,
+			wantButton: -1,
+		},
+		{
+			name: "no confirm button",
+			dialogs: []pageDialog{
+				{Visible: true, Text: "该内容可能引起不适，未成年人不可查看", Buttons: []string{"返回首页"}},
+			},
+			wantDialog: 0,
+			wantButton: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialogIdx, buttonIdx := matchContentGateDialog(tt.dialogs)
+			assert.Equal(t, tt.wantDialog, dialogIdx)
+			assert.Equal(t, tt.wantButton, buttonIdx)
+		})
+	}
+}
+
+func TestContentGatedError(t *testing.T) {
+	err := &ContentGatedError{FeedID: "abc", Message: contentGateMessage("提示\n该内容可能引起不适\n继续查看")}
+	assert.True(t, errors.Is(err, ErrContentGated))
+	assert.Equal(t, "该内容可能引起不适", err.Message)
+	assert.Contains(t, err.Error(), ErrContentGated.Error())
+}
@@ -0,0 +1,35 @@
+package xiaohongshu
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// humanDelayDuration 在 [min, max] 范围内随机取一个等待时长
+func humanDelayDuration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(rand.Int63n(int64(max-min)+1))
+}
+
+// humanDelay 在点击等交互前随机等待，模拟真人操作节奏，范围由 configs.GetHumanDelayRange 控制
+func humanDelay(ctx context.Context) error {
+	d := humanDelayDuration(configs.GetHumanDelayRange())
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+package xiaohongshu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanDelayDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), humanDelayDuration(0, 0))
+	assert.Equal(t, time.Second, humanDelayDuration(time.Second, 0))
+
+	for i := 0; i < 100; i++ {
+		d := humanDelayDuration(500*time.Millisecond, 1500*time.Millisecond)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, 1500*time.Millisecond)
+	}
+}
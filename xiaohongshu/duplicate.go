@@ -0,0 +1,39 @@
+package xiaohongshu
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// hashtagPattern 匹配正文中的话题，包括发布后的 #话题[话题]# 和发布前输入的 #话题
+var hashtagPattern = regexp.MustCompile(`#[^#\s]+\[话题\]#|#[^#\s]+`)
+
+// NoteFingerprint 返回标题和正文的指纹，用于判断两篇笔记内容是否相同。
+// 忽略话题标签和空白：发布时话题会以 #话题[话题]# 的形式追加到正文，与发布请求中的正文不完全一致。
+func NoteFingerprint(title, content string) string {
+	normalize := func(s string) string {
+		s = hashtagPattern.ReplaceAllString(s, "")
+		return strings.Join(strings.Fields(s), "")
+	}
+
+	sum := sha256.Sum256([]byte(normalize(title) + "\n" + normalize(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// RecentNotesWithTitle 在主页的前 limit 篇笔记（含置顶笔记）中查找标题为 title 的笔记
+func RecentNotesWithTitle(feeds []Feed, title string, limit int) []Feed {
+	title = strings.TrimSpace(title)
+	if len(feeds) > limit {
+		feeds = feeds[:limit]
+	}
+
+	var matched []Feed
+	for _, f := range feeds {
+		if title != "" && strings.TrimSpace(f.NoteCard.DisplayTitle) == title {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
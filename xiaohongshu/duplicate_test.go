@@ -0,0 +1,29 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteFingerprint(t *testing.T) {
+	published := NoteFingerprint("今日打卡", "早起跑步 5 公里\n#跑步[话题]# #晨跑[话题]#")
+	assert.Equal(t, NoteFingerprint(" 今日打卡", "早起跑步 5 公里 #跑步"), published)
+	assert.Equal(t, NoteFingerprint("今日打卡", "早起跑步5公里"), published)
+
+	assert.NotEqual(t, NoteFingerprint("今日打卡", "早起跑步 10 公里"), published)
+	assert.NotEqual(t, NoteFingerprint("明日打卡", "早起跑步 5 公里"), published)
+}
+
+func TestRecentNotesWithTitle(t *testing.T) {
+	note := func(id, title string) Feed {
+		f := Feed{ID: id}
+		f.NoteCard.DisplayTitle = title
+		return f
+	}
+	feeds := []Feed{note("a", "置顶"), note("b", "今日打卡 "), note("c", "其他"), note("d", "今日打卡")}
+
+	assert.Equal(t, []string{"b", "d"}, feedIDs(RecentNotesWithTitle(feeds, "今日打卡", 10)))
+	assert.Equal(t, []string{"b"}, feedIDs(RecentNotesWithTitle(feeds, "今日打卡", 3)))
+	assert.Empty(t, RecentNotesWithTitle(feeds, "", 10))
+}
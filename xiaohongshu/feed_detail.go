@@ -4,11 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
+const feedDetailReadyExpr = `() => {
+	const state = window.__INITIAL_STATE__;
+	return !!(state && state.note && state.note.noteDetailMap);
+}`
+
 // FeedDetailAction 表示 Feed 详情页动作
 type FeedDetailAction struct {
 	page *rod.Page
@@ -26,37 +36,20 @@ func (f *FeedDetailAction) GetFeedDetail(ctx context.Context, feedID, xsecToken
 	// 构建详情页 URL
 	url := makeFeedDetailURL(feedID, xsecToken)
 
-	// 导航到详情页
-	if err := page.Navigate(url); err != nil {
-		return nil, err
-	}
-
-	if err := waitForInitialState(page, `() => {
-		const state = window.__INITIAL_STATE__;
-		return !!(state && state.note && state.note.noteDetailMap);
-	}`, 30*time.Second); err != nil {
-		return nil, err
-	}
-
-	// 获取 window.__INITIAL_STATE__ 并转换为 JSON 字符串
-	result, err := page.Evaluate(&rod.EvalOptions{JS: `() => {
-		if (window.__INITIAL_STATE__) {
-			return JSON.stringify(window.__INITIAL_STATE__);
-		}
-		return "";
-	}`, ByValue: true})
+	jsonStr, err := navigateAndWaitState(page.GetContext(), page, configs.WaitActionFeedDetail, url, feedDetailReadyExpr, 30*time.Second,
+		// 部分笔记需要先在年龄/内容确认弹窗中确认才会加载内容
+		afterNavigate(func() error {
+			return dismissContentGate(page, feedID)
+		}),
+		onStateWaitFailed(func() error {
+			if gated := checkContentGate(page, feedID); gated != nil {
+				return gated
+			}
+			return checkRegionBlocked(page, RegionBlockedFeed, feedID)
+		}))
 	if err != nil {
 		return nil, err
 	}
-	if result == nil {
-		return nil, fmt.Errorf("failed to evaluate feed detail initial state")
-	}
-
-	jsonStr := result.Value.Str()
-
-	if jsonStr == "" {
-		return nil, fmt.Errorf("__INITIAL_STATE__ not found")
-	}
 
 	// 定义响应结构并直接反序列化
 	var initialState struct {
@@ -74,16 +67,107 @@ func (f *FeedDetailAction) GetFeedDetail(ctx context.Context, feedID, xsecToken
 
 	// 从 noteDetailMap 中获取对应 feedID 的数据
 	noteDetail, exists := initialState.Note.NoteDetailMap[feedID]
-	if !exists {
-		return nil, fmt.Errorf("feed %s not found in noteDetailMap", feedID)
+	if !exists || noteDetail.Note.NoteID == "" {
+		// 内容确认弹窗、地区限制与 token 过期都表现为没有笔记数据，前两者刷新 token 重试无效
+		if gated := checkContentGate(page, feedID); gated != nil {
+			return nil, gated
+		}
+		if blocked := checkRegionBlocked(page, RegionBlockedFeed, feedID); blocked != nil {
+			return nil, blocked
+		}
+		return nil, fmt.Errorf("feed %s not found in noteDetailMap: %w", feedID, ErrStaleXsecToken)
 	}
 
 	return &FeedDetailResponse{
 		Note:     noteDetail.Note,
 		Comments: noteDetail.Comments,
+		Tags:     extractTags(noteDetail.Note),
 	}, nil
 }
 
+// LoadComments 在 GetFeedDetail 打开的详情页中滚动评论区加载更多评论，
+// 直到加载了 limit 条一级评论、到达评论末尾或评论数量不再增加，返回最多 limit 条一级评论。
+func (f *FeedDetailAction) LoadComments(ctx context.Context, feedID string, limit int) (CommentList, error) {
+	page := f.page.Context(ctx).Timeout(60 * time.Second)
+
+	comments, err := evalCommentList(page, feedID)
+	if err != nil {
+		return CommentList{}, err
+	}
+
+	reachedEnd, stale := false, 0
+	for i := 0; i < maxCommentScrolls && len(comments.List) < limit && !reachedEnd; i++ {
+		if err := page.GetContext().Err(); err != nil {
+			return CommentList{}, err
+		}
+
+		res, err := page.Evaluate(&rod.EvalOptions{JS: scrollCommentsExpr, ByValue: true})
+		if err != nil {
+			return CommentList{}, errors.Wrap(err, "scroll comments failed")
+		}
+		reachedEnd = res != nil && res.Value.Bool()
+
+		time.Sleep(1 * time.Second)
+
+		loaded, err := evalCommentList(page, feedID)
+		if err != nil {
+			return CommentList{}, err
+		}
+		stale = nextStaleScrolls(len(comments.List), len(loaded.List), stale)
+		comments = loaded
+		if stale >= maxStaleCommentScrolls {
+			logrus.Infof("评论数量不再增加，共加载 %d 条: %s", len(comments.List), feedID)
+			break
+		}
+	}
+
+	if reachedEnd {
+		comments.HasMore = false
+	}
+	if len(comments.List) > limit {
+		comments.List = comments.List[:limit]
+		comments.HasMore = true
+	}
+	return comments, nil
+}
+
+// PageHTML 返回当前详情页的原始 HTML，用于排查解析失败的笔记
+func (f *FeedDetailAction) PageHTML() (string, error) {
+	return f.page.HTML()
+}
+
+// descTagPattern 匹配正文中的话题标记，如 #露营[话题]#
+var descTagPattern = regexp.MustCompile(`#([^#\[\]\s]+)\[话题\]#`)
+
+// extractTags 提取笔记的话题标签，优先使用 tagList，缺失时从正文的话题标记中解析，结果去重并保持顺序
+func extractTags(note FeedDetail) []string {
+	tags := make([]string, 0, len(note.TagList))
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+
+	for _, tag := range note.TagList {
+		add(tag.Name)
+	}
+	if len(tags) == 0 {
+		for _, m := range descTagPattern.FindAllStringSubmatch(note.Desc, -1) {
+			add(m[1])
+		}
+	}
+
+	return tags
+}
+
+// makeFeedDetailURL 生成详情页地址，xsecToken 为空时省略 token 参数
 func makeFeedDetailURL(feedID, xsecToken string) string {
+	if xsecToken == "" {
+		return fmt.Sprintf("https://www.xiaohongshu.com/explore/%s", feedID)
+	}
 	return fmt.Sprintf("https://www.xiaohongshu.com/explore/%s?xsec_token=%s&xsec_source=pc_feed", feedID, xsecToken)
 }
@@ -0,0 +1,22 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTags(t *testing.T) {
+	note := FeedDetail{
+		Desc:    "周末去露营 #露营[话题]# #户外[话题]#",
+		TagList: []NoteTag{{Name: "露营", Type: "topic"}, {Name: " 咖啡 "}, {Name: "露营"}},
+	}
+	assert.Equal(t, []string{"露营", "咖啡"}, extractTags(note))
+
+	note.TagList = nil
+	assert.Equal(t, []string{"露营", "户外"}, extractTags(note))
+
+	tags := extractTags(FeedDetail{Desc: "没有标签"})
+	assert.NotNil(t, tags)
+	assert.Empty(t, tags)
+}
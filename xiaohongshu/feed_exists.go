@@ -0,0 +1,108 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// 笔记可见性状态
+const (
+	FeedStatusFound   = "found"   // 笔记正常可见
+	FeedStatusDeleted = "deleted" // 笔记已删除或不存在
+	FeedStatusBlocked = "blocked" // 笔记被屏蔽、审核中或暂时无法浏览
+	FeedStatusPrivate = "private" // 笔记仅作者可见
+)
+
+var feedStatusKeywords = []struct {
+	status   string
+	keywords []string
+}{
+	{FeedStatusPrivate, []string{"仅作者可见", "私密笔记", "仅自己可见"}},
+	{FeedStatusDeleted, []string{"笔记不存在", "已被删除", "已删除", "内容不存在"}},
+	{FeedStatusBlocked, []string{"暂时无法浏览", "无法查看", "违规", "审核中", "内容已失效"}},
+}
+
+// classifyUnavailableFeed 根据页面地址和错误提示容器的文本判断笔记不可见的原因，无法判断时返回空字符串。
+// text 只应来自错误提示容器（选择器 feed_error），正文或评论中出现的“违规”“已删除”等字样不能作为依据。
+func classifyUnavailableFeed(pageURL, text string) string {
+	for _, item := range feedStatusKeywords {
+		for _, kw := range item.keywords {
+			if strings.Contains(text, kw) {
+				return item.status
+			}
+		}
+	}
+
+	if strings.Contains(pageURL, "/404") {
+		return FeedStatusDeleted
+	}
+
+	return ""
+}
+
+// CheckFeedExists 打开笔记详情页，判断笔记是否存在及不可见原因
+func (f *FeedDetailAction) CheckFeedExists(ctx context.Context, feedID, xsecToken string) (bool, string, error) {
+	page := f.page.Context(ctx).Timeout(60 * time.Second)
+
+	if err := navigateAndWait(page, configs.WaitActionFeedDetail, makeFeedDetailURL(feedID, xsecToken)); err != nil {
+		return false, "", err
+	}
+
+	var (
+		deadline = time.Now().Add(30 * time.Second)
+		loaded   bool
+	)
+	for time.Now().Before(deadline) {
+		res, err := page.Evaluate(&rod.EvalOptions{JS: `(id, selector) => {
+			const state = window.__INITIAL_STATE__;
+			const map = state && state.note && state.note.noteDetailMap;
+			const detail = map && map[id];
+			const found = !!(detail && detail.note && detail.note.noteId);
+			let text = "";
+			if (!found) {
+				let box = null;
+				try { box = document.querySelector(selector); } catch (e) {}
+				text = box ? (box.innerText || "").slice(0, 500) : "";
+			}
+			return JSON.stringify({found, loaded: !!map, url: location.href, text});
+		}`, JSArgs: []interface{}{feedID, selectors.Get(selectors.FeedError)}, ByValue: true})
+		if err == nil && res != nil {
+			var probe struct {
+				Found  bool   `json:"found"`
+				Loaded bool   `json:"loaded"`
+				URL    string `json:"url"`
+				Text   string `json:"text"`
+			}
+			if jsonErr := json.Unmarshal([]byte(res.Value.Str()), &probe); jsonErr == nil {
+				if probe.Found {
+					return true, FeedStatusFound, nil
+				}
+				// 页面数据中没有该笔记时，才根据地址和错误提示容器判断原因
+				if status := classifyUnavailableFeed(probe.URL, probe.Text); status != "" {
+					return false, status, nil
+				}
+				loaded = probe.Loaded
+			}
+		}
+
+		select {
+		case <-page.GetContext().Done():
+			return false, "", page.GetContext().Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	// 页面数据已加载但没有该笔记，视为不可见
+	if loaded {
+		return false, FeedStatusBlocked, nil
+	}
+
+	return false, "", errors.Errorf("无法判断笔记 %s 的状态", feedID)
+}
@@ -0,0 +1,26 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyUnavailableFeed(t *testing.T) {
+	tests := []struct {
+		url      string
+		text     string
+		expected string
+	}{
+		{"https://www.xiaohongshu.com/explore/abc", "当前笔记暂时无法浏览", FeedStatusBlocked},
+		{"https://www.xiaohongshu.com/explore/abc", "该笔记已被删除", FeedStatusDeleted},
+		{"https://www.xiaohongshu.com/explore/abc", "该笔记仅作者可见", FeedStatusPrivate},
+		{"https://www.xiaohongshu.com/404?source=note", "", FeedStatusDeleted},
+		{"https://www.xiaohongshu.com/explore/abc", "正常的笔记内容", ""},
+		{"https://www.xiaohongshu.com/explore/abc", "", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, classifyUnavailableFeed(tt.url, tt.text), tt.text)
+	}
+}
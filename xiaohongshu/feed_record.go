@@ -0,0 +1,97 @@
+package xiaohongshu
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FeedRecord 扁平化的 Feed 记录，计数已归一化为整数，便于导出为 CSV/JSON
+type FeedRecord struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	NoteType       string `json:"note_type"` // image、video 或 unknown
+	AuthorID       string `json:"author_id"`
+	AuthorName     string `json:"author_name"`
+	LikedCount     int64  `json:"liked_count"`
+	CommentCount   int64  `json:"comment_count"`
+	CollectedCount int64  `json:"collected_count"`
+	SharedCount    int64  `json:"shared_count"`
+	CoverURL       string `json:"cover_url"`
+	XsecToken      string `json:"xsec_token"`
+	URL            string `json:"url"`
+}
+
+// FeedRecordCSVHeader CSV 导出的表头，与 FeedRecord.CSVRow 顺序一致
+var FeedRecordCSVHeader = []string{
+	"id", "title", "note_type", "author_id", "author_name",
+	"liked_count", "comment_count", "collected_count", "shared_count",
+	"cover_url", "xsec_token", "url",
+}
+
+// CSVRow 返回 CSV 导出的一行
+func (r FeedRecord) CSVRow() []string {
+	return []string{
+		r.ID, r.Title, r.NoteType, r.AuthorID, r.AuthorName,
+		strconv.FormatInt(r.LikedCount, 10),
+		strconv.FormatInt(r.CommentCount, 10),
+		strconv.FormatInt(r.CollectedCount, 10),
+		strconv.FormatInt(r.SharedCount, 10),
+		r.CoverURL, r.XsecToken, r.URL,
+	}
+}
+
+// NewFeedRecords 将 Feed 列表转换为导出记录
+func NewFeedRecords(feeds []Feed) []FeedRecord {
+	feeds = normalizeFeeds(append([]Feed(nil), feeds...))
+
+	records := make([]FeedRecord, 0, len(feeds))
+	for _, f := range feeds {
+		info := f.NoteCard.InteractInfo
+		records = append(records, FeedRecord{
+			ID:             f.ID,
+			Title:          f.NoteCard.DisplayTitle,
+			NoteType:       string(f.Type),
+			AuthorID:       f.AuthorID,
+			AuthorName:     f.AuthorName,
+			LikedCount:     ParseCount(info.LikedCount),
+			CommentCount:   ParseCount(info.CommentCount),
+			CollectedCount: ParseCount(info.CollectedCount),
+			SharedCount:    ParseCount(info.SharedCount),
+			CoverURL:       f.CoverURL,
+			XsecToken:      f.XsecToken,
+			URL:            FeedURL(f.ID, f.XsecToken),
+		})
+	}
+	return records
+}
+
+// FeedURL 返回笔记详情页地址
+func FeedURL(feedID, xsecToken string) string {
+	return makeFeedDetailURL(feedID, xsecToken)
+}
+
+// ParseCount 将页面展示的计数（如 "1.2万"、"10w+"、"3k"）转换为整数，无法解析时返回 0
+func ParseCount(s string) int64 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "+"))
+	if s == "" {
+		return 0
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "万"):
+		multiplier, s = 10000, strings.TrimSuffix(s, "万")
+	case strings.HasSuffix(s, "w"), strings.HasSuffix(s, "W"):
+		multiplier, s = 10000, s[:len(s)-1]
+	case strings.HasSuffix(s, "千"):
+		multiplier, s = 1000, strings.TrimSuffix(s, "千")
+	case strings.HasSuffix(s, "k"), strings.HasSuffix(s, "K"):
+		multiplier, s = 1000, s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return int64(n*multiplier + 0.5)
+}
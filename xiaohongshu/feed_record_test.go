@@ -0,0 +1,26 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"", 0},
+		{"128", 128},
+		{"1.2万", 12000},
+		{"10万+", 100000},
+		{"3.5w", 35000},
+		{"2k", 2000},
+		{"赞", 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ParseCount(tt.input), tt.input)
+	}
+}
@@ -7,10 +7,23 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
+const feedsReadyExpr = `() => {
+	const state = window.__INITIAL_STATE__;
+	return !!(
+		state &&
+		state.feed &&
+		state.feed.feeds &&
+		state.feed.feeds._value &&
+		state.feed.feeds._value.length > 0
+	);
+}`
+
 type FeedsListAction struct {
-	page *rod.Page
+	page  *rod.Page
+	state string // 打开首页时读取的 __INITIAL_STATE__，首次 GetFeedsList 直接使用
 }
 
 // FeedsResult 定义页面初始状态结构
@@ -21,56 +34,38 @@ type FeedsResult struct {
 func NewFeedsListAction(page *rod.Page) (*FeedsListAction, error) {
 	pp := page.Timeout(60 * time.Second)
 
-	if err := pp.Navigate("https://www.xiaohongshu.com"); err != nil {
-		return nil, err
-	}
-
-	if err := waitForInitialState(pp, `() => {
-		const state = window.__INITIAL_STATE__;
-		return !!(
-			state &&
-			state.feed &&
-			state.feed.feeds &&
-			state.feed.feeds._value &&
-			state.feed.feeds._value.length > 0
-		);
-	}`, 30*time.Second); err != nil {
+	state, err := navigateAndWaitState(pp.GetContext(), pp, configs.WaitActionFeeds, "https://www.xiaohongshu.com", feedsReadyExpr, 30*time.Second)
+	if err != nil {
 		return nil, err
 	}
 
-	return &FeedsListAction{page: pp}, nil
+	return &FeedsListAction{page: pp, state: state}, nil
 }
 
 // GetFeedsList 获取页面的 Feed 列表数据
 func (f *FeedsListAction) GetFeedsList(ctx context.Context) ([]Feed, error) {
+	if state := f.state; state != "" {
+		f.state = ""
+		return parseFeeds(state)
+	}
+
 	page := f.page.Context(ctx)
 
 	// 获取 window.__INITIAL_STATE__ 并转换为 JSON 字符串
-	result, err := page.Evaluate(&rod.EvalOptions{JS: `() => {
-		if (window.__INITIAL_STATE__) {
-			return JSON.stringify(window.__INITIAL_STATE__);
-		}
-		return "";
-	}`, ByValue: true})
+	jsonStr, err := loadInitialState(page, feedsReadyExpr, 30*time.Second, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	if result == nil {
-		return nil, fmt.Errorf("failed to evaluate feeds initial state")
-	}
-
-	jsonStr := result.Value.Str()
 
-	if jsonStr == "" {
-		return nil, fmt.Errorf("__INITIAL_STATE__ not found")
-	}
+	return parseFeeds(jsonStr)
+}
 
-	// 解析完整的 InitialState
+// parseFeeds 从首页的 __INITIAL_STATE__ JSON 中解析 feed.feeds._value
+func parseFeeds(jsonStr string) ([]Feed, error) {
 	var state FeedsResult
 	if err := json.Unmarshal([]byte(jsonStr), &state); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal __INITIAL_STATE__: %w", err)
 	}
 
-	// 返回 feed.feeds._value
-	return state.Feed.Feeds.Value, nil
+	return normalizeFeeds(state.Feed.Feeds.Value), nil
 }
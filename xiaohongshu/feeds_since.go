@@ -0,0 +1,124 @@
+package xiaohongshu
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// maxSinceScrolls 增量获取时为找到 sinceFeedID 最多滚动加载的次数
+const maxSinceScrolls = 20
+
+// FeedsBefore 返回 feeds 中排在 sinceID 之前（即更新）的笔记，found 表示是否找到 sinceID。
+// 置顶笔记不代表新发布的内容，始终跳过；未找到时返回全部非置顶笔记。
+func FeedsBefore(feeds []Feed, sinceID string) (newer []Feed, found bool) {
+	newer = make([]Feed, 0, len(feeds))
+	for _, f := range feeds {
+		if f.NoteCard.InteractInfo.Sticky {
+			continue
+		}
+		if f.ID == sinceID {
+			return newer, true
+		}
+		newer = append(newer, f)
+	}
+	return newer, false
+}
+
+// GetFeedsSince 获取首页推荐中排在 sinceID 之前的笔记，当前列表中没有 sinceID 时滚动加载更多，
+// 直到出现 sinceID、连续多次滚动没有新内容或达到 maxSinceScrolls。found 表示是否找到 sinceID
+func (f *FeedsListAction) GetFeedsSince(ctx context.Context, sinceID string) (feeds []Feed, found bool, err error) {
+	page := f.page.Context(ctx)
+
+	all, err := collectUntilFeed(page, sinceID, func() ([]Feed, error) {
+		str, err := evalInitialState(page)
+		if err != nil {
+			return nil, err
+		}
+		return parseFeeds(str)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	feeds, found = FeedsBefore(all, sinceID)
+	return feeds, found, nil
+}
+
+// UserProfileSince 获取用户主页信息及排在 sinceID 之前（即更新）的笔记，
+// 已加载的笔记中没有 sinceID 时滚动加载更多。found 表示是否找到 sinceID
+func (u *UserProfileAction) UserProfileSince(ctx context.Context, userID, xsecToken, sinceID string) (profile *UserProfileResponse, found bool, err error) {
+	profile, err = u.UserProfile(ctx, userID, xsecToken)
+	if err != nil {
+		return nil, false, err
+	}
+
+	page := u.page.Context(ctx)
+	all, err := collectUntilFeed(page, sinceID, func() ([]Feed, error) {
+		str, err := evalInitialState(page)
+		if err != nil {
+			return nil, err
+		}
+		loaded, err := parseUserProfile(str)
+		if err != nil {
+			return nil, err
+		}
+		return loaded.Feeds, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	profile.Feeds, found = FeedsBefore(all, sinceID)
+	return profile, found, nil
+}
+
+// collectUntilFeed 反复读取页面上的笔记列表并滚动加载，直到列表中出现 sinceID，
+// 或连续多次滚动没有新内容、达到 maxSinceScrolls，返回最后一次读取的完整列表
+func collectUntilFeed(page *rod.Page, sinceID string, read func() ([]Feed, error)) ([]Feed, error) {
+	feeds, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	stale := 0
+	for i := 0; i < maxSinceScrolls && !containsFeed(feeds, sinceID); i++ {
+		if err := page.GetContext().Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := page.Evaluate(&rod.EvalOptions{JS: scrollSearchExpr}); err != nil {
+			return nil, errors.Wrap(err, "scroll feeds failed")
+		}
+		time.Sleep(1500 * time.Millisecond)
+
+		loaded, err := read()
+		if err != nil {
+			return nil, err
+		}
+
+		prev := len(feeds)
+		if len(loaded) >= prev {
+			feeds = loaded
+		}
+		stale = nextStaleScrolls(prev, len(feeds), stale)
+		if stale >= maxStaleSearchScrolls {
+			logrus.Infof("笔记列表不再增加，未找到笔记 %s，共 %d 条", sinceID, len(feeds))
+			break
+		}
+	}
+
+	return feeds, nil
+}
+
+func containsFeed(feeds []Feed, id string) bool {
+	for _, f := range feeds {
+		if f.ID == id {
+			return true
+		}
+	}
+	return false
+}
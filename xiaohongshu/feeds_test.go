@@ -84,3 +84,64 @@ func TestGetFeedsList(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeFeeds(t *testing.T) {
+	raw := `[{
+		"xsecToken": "token-1",
+		"id": "feed-1",
+		"noteCard": {
+			"type": "video",
+			"user": {"userId": "user-1", "nickName": "作者"},
+			"cover": {"width": 720, "height": 960, "urlPre": "https://example.com/pre.jpg", "urlDefault": "https://example.com/default.jpg"},
+			"video": {
+				"capa": {"duration": 42},
+				"media": {"stream": {"h264": [{"width": 720, "height": 1280}], "h265": [{"width": 1080, "height": 1920}]}}
+			}
+		}
+	}, {
+		"id": "feed-2",
+		"noteCard": {"xsecToken": "token-2", "type": "normal"}
+	}]`
+
+	var feeds []Feed
+	require.NoError(t, json.Unmarshal([]byte(raw), &feeds))
+
+	feeds = normalizeFeeds(feeds)
+	require.Len(t, feeds, 2)
+	require.Equal(t, "token-1", feeds[0].XsecToken)
+	require.Equal(t, "user-1", feeds[0].AuthorID)
+	require.Equal(t, "作者", feeds[0].AuthorName)
+	require.Equal(t, "https://example.com/default.jpg", feeds[0].CoverURL)
+	require.Equal(t, NoteTypeVideo, feeds[0].Type)
+	require.Equal(t, 42, feeds[0].VideoDuration)
+	require.Equal(t, 1080, feeds[0].VideoWidth)
+	require.Equal(t, 1920, feeds[0].VideoHeight)
+	require.Zero(t, feeds[1].VideoDuration)
+
+	// 用户主页等场景下令牌位于 noteCard 内
+	require.Equal(t, "token-2", feeds[1].XsecToken)
+}
+
+func TestFeedsBefore(t *testing.T) {
+	pinned := Feed{ID: "pinned", NoteCard: NoteCard{InteractInfo: InteractInfo{Sticky: true}}}
+	feeds := []Feed{pinned, {ID: "c"}, {ID: "b"}, {ID: "a"}}
+
+	newer, found := FeedsBefore(feeds, "b")
+	require.True(t, found)
+	require.Len(t, newer, 1)
+	require.Equal(t, "c", newer[0].ID)
+
+	newer, found = FeedsBefore(feeds, "c")
+	require.True(t, found)
+	require.Empty(t, newer)
+
+	// 没有找到时返回全部非置顶笔记
+	newer, found = FeedsBefore(feeds, "missing")
+	require.False(t, found)
+	require.Len(t, newer, 3)
+
+	// 置顶笔记不作为截止位置
+	newer, found = FeedsBefore(feeds, "pinned")
+	require.False(t, found)
+	require.Len(t, newer, 3)
+}
@@ -0,0 +1,161 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// HotSearchItem 热搜词
+type HotSearchItem struct {
+	Rank      int    `json:"rank"`
+	Keyword   string `json:"keyword"`
+	Heat      string `json:"heat,omitempty"`       // 页面展示的热度
+	HeatValue int64  `json:"heat_value,omitempty"` // 归一化后的热度数值
+}
+
+// hotSearchExpr 读取热搜列表：优先取 __INITIAL_STATE__ 中的热搜数据，否则读取搜索框下拉的热搜面板
+const hotSearchExpr = `() => {
+	const unwrap = (v) => (v && v._value !== undefined ? v._value : v);
+	const state = window.__INITIAL_STATE__;
+	const search = state && unwrap(state.search);
+	if (search) {
+		for (const key of ["hotList", "hotSearch", "hotSearchList", "trending"]) {
+			let list = unwrap(search[key]);
+			if (list && !Array.isArray(list)) list = unwrap(list.items || list.list || list.queries);
+			if (Array.isArray(list) && list.length > 0) {
+				return JSON.stringify(list.map((item) => (typeof item === "string" ? { title: item } : item)));
+			}
+		}
+	}
+
+	const items = [];
+	document.querySelectorAll(".hot-list-item, .hot-search-item, [class*='hot'] .sug-item").forEach((el) => {
+		const title = el.querySelector(".title, .text, .query");
+		const heat = el.querySelector(".score, .hot-num, .heat, .num");
+		items.push({
+			title: (title ? title.innerText : el.innerText).trim().split("\n")[0],
+			score: heat ? heat.innerText.trim() : "",
+		});
+	});
+	return JSON.stringify(items);
+}`
+
+// rawHotSearchItem 兼容热搜数据中不同的字段命名
+type rawHotSearchItem struct {
+	Title    string          `json:"title"`
+	Word     string          `json:"word"`
+	Query    string          `json:"query"`
+	Name     string          `json:"name"`
+	Score    json.RawMessage `json:"score"`
+	HotValue json.RawMessage `json:"hot_value"`
+	Heat     json.RawMessage `json:"heat"`
+}
+
+// HotSearchAction 热搜动作
+type HotSearchAction struct {
+	page *rod.Page
+}
+
+// NewHotSearchAction 创建热搜动作
+func NewHotSearchAction(page *rod.Page) *HotSearchAction {
+	pp := page.Timeout(60 * time.Second)
+	return &HotSearchAction{page: pp}
+}
+
+// GetHotSearches 获取当前热搜词，页面上没有热搜面板时返回空列表
+func (a *HotSearchAction) GetHotSearches(ctx context.Context) ([]HotSearchItem, error) {
+	page := a.page.Context(ctx)
+
+	if err := navigateAndWait(page, configs.WaitActionSearch, "https://www.xiaohongshu.com/explore"); err != nil {
+		return nil, err
+	}
+	if err := page.WaitLoad(); err != nil {
+		return nil, errors.Wrap(err, "wait explore page load failed")
+	}
+
+	// 聚焦搜索框以展开热搜面板
+	if input, err := page.Timeout(10 * time.Second).Element(selectors.Get(selectors.SearchInput)); err == nil {
+		if err := input.Focus(); err != nil {
+			logrus.Debugf("focus search input failed: %v", err)
+		}
+	} else {
+		logrus.Warnf("未找到搜索框，热搜面板可能无法展开: %v", err)
+	}
+
+	var raw []rawHotSearchItem
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		res, err := page.Evaluate(&rod.EvalOptions{JS: hotSearchExpr, ByValue: true})
+		if err == nil && res != nil {
+			if jsonErr := json.Unmarshal([]byte(res.Value.Str()), &raw); jsonErr == nil && len(raw) > 0 {
+				break
+			}
+		}
+
+		if time.Now().After(deadline) {
+			logrus.Warn("未找到热搜面板，返回空列表")
+			return []HotSearchItem{}, nil
+		}
+
+		select {
+		case <-page.GetContext().Done():
+			return nil, page.GetContext().Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	return parseHotSearchItems(raw), nil
+}
+
+// parseHotSearchItems 归一化热搜数据，跳过没有关键词的条目，按出现顺序编号
+func parseHotSearchItems(raw []rawHotSearchItem) []HotSearchItem {
+	items := make([]HotSearchItem, 0, len(raw))
+	for _, r := range raw {
+		keyword := firstNonEmpty(r.Title, r.Word, r.Query, r.Name)
+		if keyword == "" {
+			continue
+		}
+
+		heat := firstNonEmpty(rawScalar(r.Score), rawScalar(r.HotValue), rawScalar(r.Heat))
+		items = append(items, HotSearchItem{
+			Rank:      len(items) + 1,
+			Keyword:   keyword,
+			Heat:      heat,
+			HeatValue: ParseCount(heat),
+		})
+	}
+	return items
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// rawScalar 将 JSON 中的字符串或数字转换为字符串
+func rawScalar(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHotSearchItems(t *testing.T) {
+	var raw []rawHotSearchItem
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"title": "秋冬穿搭", "score": "128.5万"},
+		{"word": "露营", "hot_value": 35000},
+		{"title": "  "},
+		{"query": "咖啡"}
+	]`), &raw))
+
+	items := parseHotSearchItems(raw)
+	assert.Equal(t, []HotSearchItem{
+		{Rank: 1, Keyword: "秋冬穿搭", Heat: "128.5万", HeatValue: 1285000},
+		{Rank: 2, Keyword: "露营", Heat: "35000", HeatValue: 35000},
+		{Rank: 3, Keyword: "咖啡"},
+	}, items)
+}
@@ -0,0 +1,123 @@
+package xiaohongshu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadFixture 读取 testdata 下录制的 __INITIAL_STATE__ JSON
+func loadFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestParseFeeds(t *testing.T) {
+	feeds, err := parseFeeds(loadFixture(t, "feeds_state.json"))
+	require.NoError(t, err)
+	require.Len(t, feeds, 3)
+
+	image := feeds[0]
+	assert.Equal(t, "65f1a2b3000000001203c4d5", image.ID)
+	assert.Equal(t, "ABtoken1=", image.XsecToken)
+	assert.Equal(t, "春天的第一杯咖啡", image.NoteCard.DisplayTitle)
+	assert.Equal(t, "5a1b2c3d0000000001001111", image.AuthorID)
+	assert.Equal(t, "咖啡日记", image.AuthorName)
+	assert.Equal(t, "https://sns-webpic.example/cover1_dft.jpg", image.CoverURL)
+	assert.Equal(t, NoteTypeImage, image.Type)
+	assert.Equal(t, "1.2万", image.NoteCard.InteractInfo.LikedCount)
+	assert.Zero(t, image.VideoDuration)
+	assert.Zero(t, image.VideoWidth)
+	assert.Zero(t, image.VideoHeight)
+
+	video := feeds[1]
+	assert.Equal(t, NoteTypeVideo, video.Type)
+	assert.True(t, video.NoteCard.InteractInfo.Liked)
+	require.NotNil(t, video.NoteCard.Video)
+	assert.Equal(t, 185, video.NoteCard.Video.Capa.Duration)
+	assert.Equal(t, 185, video.VideoDuration)
+	assert.Equal(t, 1080, video.VideoWidth)
+	assert.Equal(t, 1920, video.VideoHeight)
+
+	assert.Equal(t, "ads", feeds[2].ModelType)
+	assert.Equal(t, NoteTypeUnknown, feeds[2].Type)
+}
+
+func TestParseSearchFeeds(t *testing.T) {
+	feeds, err := parseSearchFeeds(loadFixture(t, "search_state.json"))
+	require.NoError(t, err)
+	require.Len(t, feeds, 3)
+
+	assert.Equal(t, "城市漫游", feeds[0].AuthorName)
+	assert.Equal(t, "1024", feeds[0].NoteCard.InteractInfo.CollectedCount)
+	assert.Equal(t, "3天前", feeds[0].NoteCard.publishTimeText())
+	assert.False(t, feeds[0].PublishedAt.IsZero())
+
+	assert.Equal(t, "https://sns-webpic.example/search2_dft.jpg", feeds[1].CoverURL)
+	assert.True(t, time.Date(2023, 11, 5, 0, 0, 0, 0, siteLocation).Equal(feeds[1].PublishedAt))
+
+	assert.Len(t, FilterFeedsByNoteType(feeds, NoteTypeVideo), 1)
+}
+
+func TestParseSearchFeedsEmpty(t *testing.T) {
+	for _, state := range []string{
+		`{"search":{"feeds":{"_value":[]}}}`,
+		`{"search":{"feeds":{}}}`,
+	} {
+		feeds, err := parseSearchFeeds(state)
+		require.NoError(t, err)
+		assert.NotNil(t, feeds)
+		assert.Empty(t, feeds)
+	}
+}
+
+func TestParseUserProfile(t *testing.T) {
+	profile, err := parseUserProfile(loadFixture(t, "user_profile_state.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "咖啡日记", profile.UserBasicInfo.Nickname)
+	assert.Equal(t, "95012345", profile.UserBasicInfo.RedId)
+	assert.Equal(t, "上海", profile.UserBasicInfo.IpLocation)
+	require.Len(t, profile.Interactions, 3)
+	assert.Equal(t, "fans", profile.Interactions[1].Type)
+	assert.Equal(t, "3.4万", profile.Interactions[1].Count)
+
+	require.Len(t, profile.Feeds, 2)
+	assert.True(t, profile.Feeds[0].NoteCard.InteractInfo.Sticky)
+	assert.Equal(t, NoteTypeVideo, profile.Feeds[1].Type)
+	assert.Equal(t, "https://sns-webpic.example/cover3_prv.jpg", profile.Feeds[1].CoverURL)
+}
+
+func TestParseInteractState(t *testing.T) {
+	state := loadFixture(t, "feed_detail_state.json")
+
+	liked, collected, err := parseInteractState(state, "65f1a2b3000000001203c4d5")
+	require.NoError(t, err)
+	assert.True(t, liked)
+	assert.False(t, collected)
+
+	liked, collected, err = parseInteractState(state, "65f1a2b3000000001203c4d6")
+	require.NoError(t, err)
+	assert.False(t, liked)
+	assert.True(t, collected)
+
+	_, _, err = parseInteractState(state, "missing")
+	assert.ErrorContains(t, err, "not found in note detail map")
+}
+
+func TestParseInitialStateInvalidJSON(t *testing.T) {
+	_, err := parseFeeds("{")
+	assert.Error(t, err)
+	_, err = parseSearchFeeds("")
+	assert.Error(t, err)
+	_, err = parseUserProfile("not json")
+	assert.Error(t, err)
+	_, _, err = parseInteractState("[]", "id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package xiaohongshu
+
+// LatestNoteCandidates 返回可能是最新笔记的候选项。
+// 主页笔记按发布时间倒序排列，但置顶笔记固定在最前面，因此最新笔记是
+// 第一篇非置顶笔记或排在它前面的某篇置顶笔记，需要调用方按发布时间比较。
+func LatestNoteCandidates(feeds []Feed) []Feed {
+	for i, f := range feeds {
+		if !f.NoteCard.InteractInfo.Sticky {
+			return feeds[:i+1]
+		}
+	}
+	return feeds
+}
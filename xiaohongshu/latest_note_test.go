@@ -0,0 +1,30 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestNoteCandidates(t *testing.T) {
+	sticky := func(id string) Feed {
+		return Feed{ID: id, NoteCard: NoteCard{InteractInfo: InteractInfo{Sticky: true}}}
+	}
+
+	assert.Empty(t, LatestNoteCandidates(nil))
+	assert.Equal(t, []Feed{{ID: "a"}}, LatestNoteCandidates([]Feed{{ID: "a"}, {ID: "b"}}))
+
+	got := LatestNoteCandidates([]Feed{sticky("p1"), sticky("p2"), {ID: "a"}, {ID: "b"}})
+	assert.Equal(t, []string{"p1", "p2", "a"}, feedIDs(got))
+
+	got = LatestNoteCandidates([]Feed{sticky("p1"), sticky("p2")})
+	assert.Equal(t, []string{"p1", "p2"}, feedIDs(got))
+}
+
+func feedIDs(feeds []Feed) []string {
+	ids := make([]string, 0, len(feeds))
+	for _, f := range feeds {
+		ids = append(ids, f.ID)
+	}
+	return ids
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
 )
 
 // ActionResult 通用动作响应（点赞/收藏等）
@@ -19,11 +23,6 @@ type ActionResult struct {
 	Message string `json:"message"`
 }
 
-const (
-	selectorLikeButton    = ".interact-container .left .like-lottie"
-	selectorCollectButton = ".interact-container .left .reds-icon.collect-icon"
-)
-
 type interactActionType string
 
 const (
@@ -44,17 +43,92 @@ func newInteractAction(page *rod.Page) *interactAction {
 func (a *interactAction) preparePage(ctx context.Context, actionType interactActionType, feedID, xsecToken string) (*rod.Page, error) {
 	page := a.page.Context(ctx).Timeout(60 * time.Second)
 	url := makeFeedDetailURL(feedID, xsecToken)
+
+	// 批量操作复用同一页面时，页面已经是详情页，无需再等待整页稳定
+	if current := currentPageURL(page); feedIDFromURL(current) != "" {
+		return a.switchFeed(page, actionType, current, feedID, xsecToken)
+	}
+
 	logrus.Infof("Opening feed detail page for %s: %s", actionType, url)
 
-	if err := page.Navigate(url); err != nil {
+	if err := navigateAndWait(page, configs.WaitActionInteract, url); err != nil {
 		return nil, err
 	}
-	page.MustWaitDOMStable()
+	if err := page.WaitDOMStable(time.Second, 0); err != nil {
+		return nil, errors.Wrap(err, "wait feed detail page stable failed")
+	}
 	time.Sleep(1 * time.Second)
 
+	if isFeedMissing(page, feedID) {
+		return nil, errors.Wrapf(ErrStaleXsecToken, "feed %s", feedID)
+	}
+
+	return page, nil
+}
+
+// switchFeed 在已打开的详情页上切换到目标笔记：笔记 ID 和 xsec_token 都相同时直接复用，
+// 否则导航后只等待目标笔记的状态就绪（token 过期重试时 token 不同，会重新打开详情页）
+func (a *interactAction) switchFeed(page *rod.Page, actionType interactActionType, currentURL, feedID, xsecToken string) (*rod.Page, error) {
+	if isSameFeedTarget(currentURL, feedID, xsecToken) {
+		logrus.Infof("Already on feed detail page for %s: %s", actionType, feedID)
+		return page, nil
+	}
+
+	url := makeFeedDetailURL(feedID, xsecToken)
+	if feedIDFromURL(currentURL) == feedID {
+		// withTokenRefresh 换用新 token 重试时，页面仍停留在用旧 token 打开的“笔记不存在”页面
+		logrus.Infof("xsec_token changed, reopening feed detail page for %s: %s", actionType, feedID)
+	}
+	logrus.Infof("Switching feed detail page for %s: %s", actionType, url)
+
+	if _, err := navigateAndWaitState(page.GetContext(), page, configs.WaitActionInteract, url, feedDetailReadyExpr, 30*time.Second); err != nil {
+		return nil, errors.Wrap(err, "wait feed detail state failed")
+	}
+	if isFeedMissing(page, feedID) {
+		return nil, errors.Wrapf(ErrStaleXsecToken, "feed %s", feedID)
+	}
+
 	return page, nil
 }
 
+// currentPageURL 返回页面当前地址，获取失败时返回空字符串
+func currentPageURL(page *rod.Page) string {
+	info, err := page.Info()
+	if err != nil || info == nil {
+		return ""
+	}
+	return info.URL
+}
+
+// isSameFeedTarget 判断当前地址是否就是用 xsecToken 打开的目标笔记详情页
+func isSameFeedTarget(currentURL, feedID, xsecToken string) bool {
+	if feedIDFromURL(currentURL) != feedID {
+		return false
+	}
+	u, err := url.Parse(currentURL)
+	if err != nil {
+		return false
+	}
+	return u.Query().Get("xsec_token") == xsecToken
+}
+
+// feedIDFromURL 从 https://www.xiaohongshu.com/explore/<id> 形式的地址中提取笔记 ID
+func feedIDFromURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if !isXiaohongshuHost(u.Hostname()) {
+		return ""
+	}
+
+	id, ok := strings.CutPrefix(u.Path, "/explore/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return ""
+	}
+	return id
+}
+
 func (a *interactAction) performClick(page *rod.Page, selector string) error {
 	element, err := page.Element(selector)
 	if err != nil {
@@ -63,6 +137,9 @@ func (a *interactAction) performClick(page *rod.Page, selector string) error {
 	if element == nil {
 		return errors.Errorf("未找到操作按钮: %s", selector)
 	}
+	if err := humanDelay(page.GetContext()); err != nil {
+		return err
+	}
 	return element.Click(proto.InputMouseButtonLeft, 1)
 }
 
@@ -112,10 +189,13 @@ func (a *LikeAction) perform(ctx context.Context, feedID, xsecToken string, targ
 }
 
 func (a *LikeAction) toggleLike(page *rod.Page, feedID string, targetLiked bool, actionType interactActionType) error {
-	if err := a.performClick(page, selectorLikeButton); err != nil {
+	if err := a.performClick(page, selectors.Get(selectors.LikeButton)); err != nil {
 		return err
 	}
 	time.Sleep(3 * time.Second)
+	if err := handleCaptcha(page); err != nil {
+		return err
+	}
 
 	liked, _, err := a.getInteractState(page, feedID)
 	if err != nil {
@@ -128,10 +208,13 @@ func (a *LikeAction) toggleLike(page *rod.Page, feedID string, targetLiked bool,
 	}
 
 	logrus.Warnf("feed %s %s可能未成功，状态未变化，尝试再次点击", feedID, actionType)
-	if err := a.performClick(page, selectorLikeButton); err != nil {
+	if err := a.performClick(page, selectors.Get(selectors.LikeButton)); err != nil {
 		return err
 	}
 	time.Sleep(2 * time.Second)
+	if err := handleCaptcha(page); err != nil {
+		return err
+	}
 
 	liked, _, err = a.getInteractState(page, feedID)
 	if err != nil {
@@ -192,10 +275,13 @@ func (a *FavoriteAction) perform(ctx context.Context, feedID, xsecToken string,
 }
 
 func (a *FavoriteAction) toggleFavorite(page *rod.Page, feedID string, targetCollected bool, actionType interactActionType) error {
-	if err := a.performClick(page, selectorCollectButton); err != nil {
+	if err := a.performClick(page, selectors.Get(selectors.CollectButton)); err != nil {
 		return err
 	}
 	time.Sleep(3 * time.Second)
+	if err := handleCaptcha(page); err != nil {
+		return err
+	}
 
 	_, collected, err := a.getInteractState(page, feedID)
 	if err != nil {
@@ -208,10 +294,13 @@ func (a *FavoriteAction) toggleFavorite(page *rod.Page, feedID string, targetCol
 	}
 
 	logrus.Warnf("feed %s %s可能未成功，状态未变化，尝试再次点击", feedID, actionType)
-	if err := a.performClick(page, selectorCollectButton); err != nil {
+	if err := a.performClick(page, selectors.Get(selectors.CollectButton)); err != nil {
 		return err
 	}
 	time.Sleep(2 * time.Second)
+	if err := handleCaptcha(page); err != nil {
+		return err
+	}
 
 	_, collected, err = a.getInteractState(page, feedID)
 	if err != nil {
@@ -227,31 +316,33 @@ func (a *FavoriteAction) toggleFavorite(page *rod.Page, feedID string, targetCol
 }
 
 func (a *interactAction) getInteractState(page *rod.Page, feedID string) (liked bool, collected bool, err error) {
-	result := page.MustEval(`() => {
-        if (window.__INITIAL_STATE__ && window.__INITIAL_STATE__.note && window.__INITIAL_STATE__.note.noteDetailMap) {
-            return JSON.stringify(window.__INITIAL_STATE__.note.noteDetailMap);
-        }
-        return "";
-    }`).Str()
-
-	if result == "" {
-		return false, false, errors.New("__INITIAL_STATE__ not found")
+	jsonStr, err := evalInitialState(page)
+	if err != nil {
+		return false, false, errors.Wrap(err, "evaluate __INITIAL_STATE__ failed")
 	}
+	return parseInteractState(jsonStr, feedID)
+}
 
-	var noteDetailMap map[string]struct {
+// parseInteractState 从详情页的 __INITIAL_STATE__ JSON 中读取笔记的点赞、收藏状态
+func parseInteractState(jsonStr, feedID string) (liked bool, collected bool, err error) {
+	var state struct {
 		Note struct {
-			InteractInfo struct {
-				Liked     bool `json:"liked"`
-				Collected bool `json:"collected"`
-			} `json:"interactInfo"`
+			NoteDetailMap map[string]struct {
+				Note struct {
+					InteractInfo struct {
+						Liked     bool `json:"liked"`
+						Collected bool `json:"collected"`
+					} `json:"interactInfo"`
+				} `json:"note"`
+			} `json:"noteDetailMap"`
 		} `json:"note"`
 	}
 
-	if err := json.Unmarshal([]byte(result), &noteDetailMap); err != nil {
+	if err := json.Unmarshal([]byte(jsonStr), &state); err != nil {
 		return false, false, errors.Wrap(err, "unmarshal note detail map failed")
 	}
 
-	noteDetail, ok := noteDetailMap[feedID]
+	noteDetail, ok := state.Note.NoteDetailMap[feedID]
 	if !ok {
 		return false, false, fmt.Errorf("feed %s not found in note detail map", feedID)
 	}
@@ -0,0 +1,36 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedIDFromURL(t *testing.T) {
+	assert.Equal(t, "abc123", feedIDFromURL(makeFeedDetailURL("abc123", "token")))
+	assert.Equal(t, "abc123", feedIDFromURL("https://www.xiaohongshu.com/explore/abc123"))
+	assert.Equal(t, "", feedIDFromURL("https://www.xiaohongshu.com/explore"))
+	assert.Equal(t, "", feedIDFromURL("https://www.xiaohongshu.com/user/profile/abc123"))
+	assert.Equal(t, "", feedIDFromURL("https://example.com/explore/abc123"))
+	assert.Equal(t, "", feedIDFromURL("https://evilxiaohongshu.com/explore/abc123"))
+	assert.Equal(t, "abc123", feedIDFromURL("https://xiaohongshu.com/explore/abc123"))
+	assert.Equal(t, "", feedIDFromURL("about:blank"))
+}
+
+func TestIsSameFeedTarget(t *testing.T) {
+	current := makeFeedDetailURL("abc123", "token")
+	assert.True(t, isSameFeedTarget(current, "abc123", "token"))
+	assert.False(t, isSameFeedTarget(current, "abc123", "other"))
+	assert.False(t, isSameFeedTarget(current, "def456", "token"))
+	assert.True(t, isSameFeedTarget(makeFeedDetailURL("abc123", ""), "abc123", ""))
+	assert.False(t, isSameFeedTarget("about:blank", "abc123", "token"))
+}
+
+// 首次操作因 token 过期失败后页面停留在旧 token 的详情页，换用新 token 重试时必须重新打开
+func TestStaleTokenRetryReopensFeed(t *testing.T) {
+	afterFirstAttempt := makeFeedDetailURL("abc123", "stale")
+	assert.False(t, isSameFeedTarget(afterFirstAttempt, "abc123", "fresh"))
+
+	afterRetry := makeFeedDetailURL("abc123", "fresh")
+	assert.True(t, isSameFeedTarget(afterRetry, "abc123", "fresh"))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-rod/rod"
 	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
 )
 
 type LoginAction struct {
@@ -22,7 +23,7 @@ func (a *LoginAction) CheckLoginStatus(ctx context.Context) (bool, error) {
 
 	time.Sleep(1 * time.Second)
 
-	exists, _, err := pp.Has(`.main-container .user .link-wrapper .channel`)
+	exists, _, err := pp.Has(selectors.Get(selectors.LoginStatus))
 	if err != nil {
 		return false, errors.Wrap(err, "check login status failed")
 	}
@@ -44,14 +45,14 @@ func (a *LoginAction) Login(ctx context.Context) error {
 	time.Sleep(2 * time.Second)
 
 	// 检查是否已经登录
-	if exists, _, _ := pp.Has(".main-container .user .link-wrapper .channel"); exists {
+	if exists, _, _ := pp.Has(selectors.Get(selectors.LoginStatus)); exists {
 		// 已经登录，直接返回
 		return nil
 	}
 
 	// 等待扫码成功提示或者登录完成
 	// 这里我们等待登录成功的元素出现，这样更简单可靠
-	pp.MustElement(".main-container .user .link-wrapper .channel")
+	pp.MustElement(selectors.Get(selectors.LoginStatus))
 
 	return nil
 }
@@ -66,12 +67,12 @@ func (a *LoginAction) FetchQrcodeImage(ctx context.Context) (string, bool, error
 	time.Sleep(2 * time.Second)
 
 	// 检查是否已经登录
-	if exists, _, _ := pp.Has(".main-container .user .link-wrapper .channel"); exists {
+	if exists, _, _ := pp.Has(selectors.Get(selectors.LoginStatus)); exists {
 		return "", true, nil
 	}
 
 	// 获取二维码图片
-	src, err := pp.MustElement(".login-container .qrcode-img").Attribute("src")
+	src, err := pp.MustElement(selectors.Get(selectors.LoginQrcode)).Attribute("src")
 	if err != nil {
 		return "", false, errors.Wrap(err, "get qrcode src failed")
 	}
@@ -92,7 +93,7 @@ func (a *LoginAction) WaitForLogin(ctx context.Context) bool {
 		case <-ctx.Done():
 			return false
 		case <-ticker.C:
-			el, err := pp.Element(".main-container .user .link-wrapper .channel")
+			el, err := pp.Element(selectors.Get(selectors.LoginStatus))
 			if err == nil && el != nil {
 				return true
 			}
@@ -0,0 +1,39 @@
+package xiaohongshu
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+)
+
+// ErrNotLoggedIn 页面被重定向到登录页，账号的登录状态已失效
+var ErrNotLoggedIn = errors.New("not logged in: page redirected to the login page")
+
+// isLoginRedirect 判断页面地址是否为小红书（含创作者中心）的登录页
+func isLoginRedirect(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || !strings.HasSuffix(strings.ToLower(u.Hostname()), "xiaohongshu.com") {
+		return false
+	}
+
+	path := strings.ToLower(u.Path)
+	// 验证码页也在 /website-login 下，但不代表登录状态失效
+	if strings.Contains(path, "captcha") {
+		return false
+	}
+	return strings.HasPrefix(path, "/login") || strings.HasPrefix(path, "/website-login")
+}
+
+// checkLoginRedirect 页面已被重定向到登录页时返回 ErrNotLoggedIn
+func checkLoginRedirect(page *rod.Page) error {
+	info, err := page.Info()
+	if err != nil || info == nil {
+		return nil
+	}
+	if isLoginRedirect(info.URL) {
+		return errors.Wrapf(ErrNotLoggedIn, "redirected to %s", info.URL)
+	}
+	return nil
+}
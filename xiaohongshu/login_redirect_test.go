@@ -0,0 +1,19 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLoginRedirect(t *testing.T) {
+	assert.True(t, isLoginRedirect("https://www.xiaohongshu.com/login?redirectPath=%2Fexplore%2Fabc"))
+	assert.True(t, isLoginRedirect("https://www.xiaohongshu.com/website-login/error"))
+	assert.True(t, isLoginRedirect("https://creator.xiaohongshu.com/login?source=official"))
+
+	assert.False(t, isLoginRedirect("https://www.xiaohongshu.com/explore/abc"))
+	assert.False(t, isLoginRedirect("https://creator.xiaohongshu.com/publish/publish"))
+	assert.False(t, isLoginRedirect("https://www.xiaohongshu.com/website-login/captcha?redirectPath=%2Fexplore"))
+	assert.False(t, isLoginRedirect("https://example.com/login"))
+	assert.False(t, isLoginRedirect("::"))
+}
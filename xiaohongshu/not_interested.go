@@ -0,0 +1,121 @@
+package xiaohongshu
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+var (
+	// ErrFeedNotOnHome 首页推荐列表中没有该笔记，只能对当前推荐列表中的笔记标记不感兴趣
+	ErrFeedNotOnHome = errors.New("feed not found on home page")
+	// ErrNotInterestedUnavailable 笔记卡片上没有“不感兴趣”菜单（页面改版或该笔记不支持）
+	ErrNotInterestedUnavailable = errors.New("not interested option not available")
+)
+
+// notInterestedLabel 卡片菜单中“不感兴趣”选项的文本
+const notInterestedLabel = `^\s*不感兴趣\s*$`
+
+// MarkNotInterested 在首页推荐列表中找到笔记卡片，打开卡片菜单并点击“不感兴趣”。
+// 笔记不在当前推荐列表中时用 xsecToken 打开详情页确认笔记仍可访问：详情页提示笔记不存在时返回
+// ErrStaleXsecToken（可刷新 token 后重试），否则返回 ErrFeedNotOnHome；卡片没有该菜单时返回 ErrNotInterestedUnavailable。
+func (f *FeedsListAction) MarkNotInterested(ctx context.Context, feedID, xsecToken string) error {
+	page := f.page.Context(ctx)
+
+	card, err := findFeedCard(page, feedID)
+	if errors.Is(err, ErrFeedNotOnHome) {
+		if err := checkFeedReachable(page, feedID, xsecToken); err != nil {
+			return err
+		}
+		return errors.Wrapf(ErrFeedNotOnHome, "feed %s", feedID)
+	}
+	if err != nil {
+		return err
+	}
+	if err := card.ScrollIntoView(); err != nil {
+		logrus.Debugf("scroll feed card into view failed: %v", err)
+	}
+	if err := card.Hover(); err != nil {
+		logrus.Debugf("hover feed card failed: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	if err := openFeedCardMenu(card); err != nil {
+		return err
+	}
+
+	option, err := page.Timeout(3*time.Second).ElementR(selectors.Get(selectors.FeedCardMenuItem), notInterestedLabel)
+	if err != nil {
+		return errors.Wrapf(ErrNotInterestedUnavailable, "feed %s", feedID)
+	}
+	if err := humanDelay(ctx); err != nil {
+		return err
+	}
+	if err := option.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击不感兴趣失败")
+	}
+
+	time.Sleep(1 * time.Second)
+	logrus.Infof("已将笔记 %s 标记为不感兴趣", feedID)
+	return nil
+}
+
+// checkFeedReachable 用 xsecToken 打开笔记详情页，页面提示笔记不存在时返回 ErrStaleXsecToken
+func checkFeedReachable(page *rod.Page, feedID, xsecToken string) error {
+	if err := navigateAndWait(page, configs.WaitActionInteract, makeFeedDetailURL(feedID, xsecToken)); err != nil {
+		return err
+	}
+	if err := page.WaitDOMStable(time.Second, 0); err != nil {
+		return errors.Wrap(err, "wait feed detail page stable failed")
+	}
+	if isFeedMissing(page, feedID) {
+		return errors.Wrapf(ErrStaleXsecToken, "feed %s", feedID)
+	}
+	return nil
+}
+
+// openFeedCardMenu 打开笔记卡片菜单：优先点击卡片上的更多按钮，没有时右键卡片
+func openFeedCardMenu(card *rod.Element) error {
+	if has, button, _ := card.Has(selectors.Get(selectors.FeedCardMenu)); has {
+		if err := button.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "打开笔记卡片菜单失败")
+		}
+	} else if err := card.Click(proto.InputMouseButtonRight, 1); err != nil {
+		return errors.Wrap(err, "右键打开笔记卡片菜单失败")
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// findFeedCard 在首页推荐列表中查找指定笔记的卡片
+func findFeedCard(page *rod.Page, feedID string) (*rod.Element, error) {
+	selector := selectors.Get(selectors.FeedCard)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		res, err := page.Evaluate(&rod.EvalOptions{
+			JS:      findNoteCardExpr,
+			JSArgs:  []interface{}{selector, feedID},
+			ByValue: true,
+		})
+		if err == nil && res != nil {
+			if idx := res.Value.Int(); idx >= 0 {
+				cards, err := page.Elements(selector)
+				if err == nil && idx < len(cards) {
+					return cards[idx], nil
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.Wrapf(ErrFeedNotOnHome, "feed %s", feedID)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
@@ -0,0 +1,75 @@
+package xiaohongshu
+
+// NoteType 笔记类型，Feed.Type 由原始的 noteCard.type 解析而来，同时用作列表接口的类型筛选项
+type NoteType string
+
+const (
+	NoteTypeAll     NoteType = "all" // 仅用于筛选，表示不限类型
+	NoteTypeVideo   NoteType = "video"
+	NoteTypeImage   NoteType = "image"
+	NoteTypeUnknown NoteType = "unknown" // 无法识别的类型，只在不限类型时返回
+)
+
+// 页面数据中 noteCard.type 的原始取值
+const (
+	rawNoteTypeNormal = "normal" // 图文
+	rawNoteTypeVideo  = "video"
+)
+
+// ParseNoteType 将页面数据中的原始类型转换为 NoteType，无法识别时返回 NoteTypeUnknown
+func ParseNoteType(raw string) NoteType {
+	switch raw {
+	case rawNoteTypeNormal:
+		return NoteTypeImage
+	case rawNoteTypeVideo:
+		return NoteTypeVideo
+	default:
+		return NoteTypeUnknown
+	}
+}
+
+// ParseNoteTypeFilter 解析列表接口的 note_type 筛选项（all/video/image），空值视为 all，
+// 不合法时返回 *FilterError
+func ParseNoteTypeFilter(raw string) (NoteType, error) {
+	if raw == "" {
+		return NoteTypeAll, nil
+	}
+
+	filterErr := &FilterError{Fields: map[string]string{}}
+	filterErr.check("note_type", raw, noteTypeLabels)
+	if len(filterErr.Fields) > 0 {
+		return "", filterErr
+	}
+	return NoteType(raw), nil
+}
+
+// FilterFeedsByNoteType 按笔记类型过滤 Feed，noteType 为空或 all 时原样返回
+func FilterFeedsByNoteType(feeds []Feed, noteType NoteType) []Feed {
+	if noteType == "" || noteType == NoteTypeAll {
+		return feeds
+	}
+
+	filtered := make([]Feed, 0, len(feeds))
+	for _, f := range feeds {
+		if f.Type == noteType {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// DedupFeedsByAuthor 每个作者只保留第一条笔记，保持原有顺序；没有作者 ID 的笔记全部保留
+func DedupFeedsByAuthor(feeds []Feed) []Feed {
+	seen := make(map[string]bool, len(feeds))
+	deduped := make([]Feed, 0, len(feeds))
+	for _, f := range feeds {
+		if f.AuthorID != "" {
+			if seen[f.AuthorID] {
+				continue
+			}
+			seen[f.AuthorID] = true
+		}
+		deduped = append(deduped, f)
+	}
+	return deduped
+}
@@ -0,0 +1,56 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNoteType(t *testing.T) {
+	assert.Equal(t, NoteTypeImage, ParseNoteType("normal"))
+	assert.Equal(t, NoteTypeVideo, ParseNoteType("video"))
+	assert.Equal(t, NoteTypeUnknown, ParseNoteType("live"))
+	assert.Equal(t, NoteTypeUnknown, ParseNoteType(""))
+}
+
+func TestFilterFeedsByNoteType(t *testing.T) {
+	feeds := []Feed{
+		{ID: "a", NoteCard: NoteCard{Type: "normal"}},
+		{ID: "b", NoteCard: NoteCard{Type: "video"}},
+		{ID: "c", NoteCard: NoteCard{Type: "live"}},
+	}
+	feeds = normalizeFeeds(feeds)
+
+	assert.Len(t, FilterFeedsByNoteType(feeds, ""), 3)
+	assert.Len(t, FilterFeedsByNoteType(feeds, NoteTypeAll), 3)
+	assert.Equal(t, []Feed{feeds[1]}, FilterFeedsByNoteType(feeds, NoteTypeVideo))
+	assert.Equal(t, []Feed{feeds[0]}, FilterFeedsByNoteType(feeds, NoteTypeImage))
+}
+
+func TestParseNoteTypeFilter(t *testing.T) {
+	nt, err := ParseNoteTypeFilter("")
+	require.NoError(t, err)
+	assert.Equal(t, NoteTypeAll, nt)
+
+	nt, err = ParseNoteTypeFilter("video")
+	require.NoError(t, err)
+	assert.Equal(t, NoteTypeVideo, nt)
+
+	var filterErr *FilterError
+	_, err = ParseNoteTypeFilter("unknown")
+	assert.ErrorAs(t, err, &filterErr)
+}
+
+func TestDedupFeedsByAuthor(t *testing.T) {
+	feeds := []Feed{
+		{ID: "a", AuthorID: "u1"},
+		{ID: "b", AuthorID: "u2"},
+		{ID: "c", AuthorID: "u1"},
+		{ID: "d"},
+		{ID: "e"},
+	}
+
+	assert.Equal(t, []string{"a", "b", "d", "e"}, feedIDs(DedupFeedsByAuthor(feeds)))
+	assert.Empty(t, DedupFeedsByAuthor(nil))
+}
@@ -0,0 +1,476 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// 未读通知数量的来源
+const (
+	NotificationSourceState = "state" // 页面状态中的分类未读数
+	NotificationSourceBadge = "badge" // 侧边栏“通知”入口的角标，只有总数
+	NotificationSourceNone  = "none"  // 页面上没有未读数据，视为没有新通知
+)
+
+// NotificationCounts 未读通知数量。站点的通知页分为“评论和@”、“赞和收藏”、“新增关注”三类，
+// 评论与 @ 提及合并计数。
+type NotificationCounts struct {
+	CommentsAndMentions int64  `json:"comments_and_mentions"` // 评论和@
+	LikesAndCollects    int64  `json:"likes_and_collects"`    // 赞和收藏
+	NewFollowers        int64  `json:"new_followers"`         // 新增关注
+	Total               int64  `json:"total"`                 // 未读总数
+	Source              string `json:"source"`                // 数据来源：state、badge 或 none
+}
+
+// notificationsExpr 读取未读通知数量：优先取页面状态中的分类未读数，否则读取侧边栏“通知”入口的角标
+const notificationsExpr = `(badgeSelector) => {
+	const unwrap = (v) => (v && v._value !== undefined ? v._value : v);
+	const state = window.__INITIAL_STATE__;
+	const notification = state && unwrap(state.notification);
+	const unread = notification && unwrap(notification.unreadCount);
+	if (unread && typeof unread === "object") {
+		return JSON.stringify({ source: "state", unread });
+	}
+
+	const badge = document.querySelector(badgeSelector);
+	const text = badge ? badge.innerText.trim() : "";
+	return JSON.stringify({ source: text ? "badge" : "", badge: text });
+}`
+
+// rawNotifications notificationsExpr 的返回值，计数兼容数字和字符串
+type rawNotifications struct {
+	Source string `json:"source"`
+	Unread struct {
+		Mentions    json.RawMessage `json:"mentions"`
+		Likes       json.RawMessage `json:"likes"`
+		Connections json.RawMessage `json:"connections"`
+		UnreadCount json.RawMessage `json:"unreadCount"`
+		Unread      json.RawMessage `json:"unread_count"`
+	} `json:"unread"`
+	Badge string `json:"badge"`
+}
+
+// MarkReadResult 标记通知已读的结果
+type MarkReadResult struct {
+	Marked    int64 `json:"marked"`    // 标记为已读的通知数量
+	Remaining int64 `json:"remaining"` // 标记后仍未读的数量
+	Known     bool  `json:"known"`     // 标记前能否读取到未读数；为 false 时 marked 无法确定，固定为 0
+}
+
+// notificationTabs 通知页的分类标签，及其对应的未读数
+var notificationTabs = []struct {
+	label  string
+	unread func(NotificationCounts) int64
+}{
+	{"评论和@", func(c NotificationCounts) int64 { return c.CommentsAndMentions }},
+	{"赞和收藏", func(c NotificationCounts) int64 { return c.LikesAndCollects }},
+	{"新增关注", func(c NotificationCounts) int64 { return c.NewFollowers }},
+}
+
+const (
+	// maxNotificationScrolls 获取通知列表时最多滚动加载的次数
+	maxNotificationScrolls = 20
+	// maxStaleNotificationScrolls 连续多少次滚动后通知数量不再增加即认为已加载完
+	maxStaleNotificationScrolls = 3
+)
+
+// Notification 通知页中的一条消息
+type Notification struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`               // 消息类型：comment、mention、like、collect、follow 等
+	RawType   string            `json:"raw_type,omitempty"` // 站点原始类型，如 comment/item、comment/comment
+	Title     string            `json:"title,omitempty"`    // 页面展示的动作描述，如“评论了你的笔记”
+	User      NotificationUser  `json:"user"`               // 发起消息的用户
+	Note      *NotificationNote `json:"note,omitempty"`     // 相关笔记，新增关注等消息没有
+	CommentID string            `json:"comment_id,omitempty"`
+	Snippet   string            `json:"snippet,omitempty"` // 评论或回复的内容
+	Time      time.Time         `json:"time,omitzero"`
+}
+
+// NotificationUser 发起消息的用户
+type NotificationUser struct {
+	UserID    string `json:"user_id"`
+	Nickname  string `json:"nickname"`
+	Avatar    string `json:"avatar,omitempty"`
+	XsecToken string `json:"xsec_token,omitempty"`
+}
+
+// NotificationNote 消息相关的笔记，ID 与 XsecToken 可直接用于获取详情和回复评论
+type NotificationNote struct {
+	ID        string `json:"id"`
+	XsecToken string `json:"xsec_token,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Cover     string `json:"cover,omitempty"`
+}
+
+// notificationListExpr 读取通知页当前已加载的消息：合并页面状态中各分类的消息列表
+const notificationListExpr = `() => {
+	const unwrap = (v) => (v && v._value !== undefined ? v._value : v);
+	const state = window.__INITIAL_STATE__;
+	const notification = state && unwrap(state.notification);
+	const map = notification && unwrap(notification.notificationMap);
+	if (!map || typeof map !== "object") {
+		return "";
+	}
+
+	const messages = [];
+	for (const key of Object.keys(map)) {
+		const category = unwrap(map[key]);
+		const list = category && unwrap(category.messageList);
+		if (Array.isArray(list)) {
+			messages.push(...list);
+		}
+	}
+	return JSON.stringify(messages);
+}`
+
+// scrollNotificationsExpr 将通知页的消息列表滚动到底部以加载更多消息
+const scrollNotificationsExpr = `(scrollerSelector) => {
+	document.querySelectorAll(scrollerSelector).forEach((el) => {
+		el.scrollTop = el.scrollHeight;
+	});
+	window.scrollTo(0, document.body.scrollHeight);
+}`
+
+// rawNotification 页面状态中的消息，字段与站点接口一致
+type rawNotification struct {
+	ID       json.RawMessage `json:"id"`
+	Type     string          `json:"type"`
+	Title    string          `json:"title"`
+	Time     json.RawMessage `json:"time"`
+	UserInfo struct {
+		UserID    string `json:"userid"`
+		Nickname  string `json:"nickname"`
+		Image     string `json:"image"`
+		XsecToken string `json:"xsec_token"`
+	} `json:"user_info"`
+	ItemInfo struct {
+		ID        string `json:"id"`
+		Content   string `json:"content"`
+		Image     string `json:"image"`
+		XsecToken string `json:"xsec_token"`
+	} `json:"item_info"`
+	CommentInfo struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	} `json:"comment_info"`
+}
+
+// NotificationsAction 通知动作
+type NotificationsAction struct {
+	page *rod.Page
+}
+
+// NewNotificationsAction 创建通知动作
+func NewNotificationsAction(page *rod.Page) *NotificationsAction {
+	pp := page.Timeout(60 * time.Second)
+	return &NotificationsAction{page: pp}
+}
+
+// GetNotificationCounts 获取当前账号的未读通知数量。
+// 打开首页而不是通知页，避免查看时把通知标记为已读；页面上没有未读数据时返回全 0。
+func (a *NotificationsAction) GetNotificationCounts(ctx context.Context) (NotificationCounts, error) {
+	page := a.page.Context(ctx)
+
+	if err := navigateAndWait(page, configs.WaitActionFeeds, "https://www.xiaohongshu.com/explore"); err != nil {
+		return NotificationCounts{}, err
+	}
+	if err := page.WaitLoad(); err != nil {
+		return NotificationCounts{}, errors.Wrap(err, "wait explore page load failed")
+	}
+
+	return readNotificationCounts(page)
+}
+
+// readNotificationCounts 读取当前页面上的未读通知数量。
+// 未读数由页面加载后异步请求，等待一段时间直到页面状态中出现分类未读数，否则退回角标。
+func readNotificationCounts(page *rod.Page) (NotificationCounts, error) {
+	var raw string
+	deadline := time.Now().Add(8 * time.Second)
+	for {
+		res, err := page.Evaluate(&rod.EvalOptions{
+			JS:      notificationsExpr,
+			JSArgs:  []interface{}{selectors.Get(selectors.NotificationBadge)},
+			ByValue: true,
+		})
+		if err == nil && res != nil {
+			raw = res.Value.Str()
+			if counts, err := parseNotificationCounts(raw); err == nil && counts.Source == NotificationSourceState {
+				return counts, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			break
+		}
+
+		select {
+		case <-page.GetContext().Done():
+			return NotificationCounts{}, page.GetContext().Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	counts, err := parseNotificationCounts(raw)
+	if err != nil {
+		logrus.Warnf("解析未读通知失败，按没有新通知处理: %v", err)
+		return NotificationCounts{Source: NotificationSourceNone}, nil
+	}
+	return counts, nil
+}
+
+// MarkAllRead 将所有未读通知标记为已读，返回标记的数量。
+// 站点在打开通知页的分类标签时清除该分类的未读数，因此依次打开有未读的分类，再回到首页确认剩余未读数；
+// 没有未读通知时直接返回，不打开通知页。
+func (a *NotificationsAction) MarkAllRead(ctx context.Context) (MarkReadResult, error) {
+	before, err := a.GetNotificationCounts(ctx)
+	if err != nil {
+		return MarkReadResult{}, err
+	}
+	known := before.Source != NotificationSourceNone
+	if known && before.Total == 0 {
+		logrus.Info("没有未读通知，无需标记")
+		return MarkReadResult{Known: true}, nil
+	}
+
+	page := a.page.Context(ctx)
+	if err := navigateAndWait(page, configs.WaitActionFeeds, "https://www.xiaohongshu.com/notification"); err != nil {
+		return MarkReadResult{}, err
+	}
+	if err := page.WaitLoad(); err != nil {
+		return MarkReadResult{}, errors.Wrap(err, "wait notification page load failed")
+	}
+	time.Sleep(1 * time.Second)
+
+	for _, tab := range notificationTabs {
+		// 只有分类未读数时才跳过没有未读的分类，角标只有总数时全部打开
+		if before.Source == NotificationSourceState && tab.unread(before) == 0 {
+			continue
+		}
+		if err := humanDelay(ctx); err != nil {
+			return MarkReadResult{}, err
+		}
+		if err := clickNotificationTab(page, tab.label); err != nil {
+			logrus.Warnf("打开通知分类 %s 失败: %v", tab.label, err)
+			continue
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	after, err := a.GetNotificationCounts(ctx)
+	if err != nil {
+		return MarkReadResult{}, err
+	}
+	if after.Total > 0 {
+		logrus.Warnf("标记已读后仍有 %d 条未读通知", after.Total)
+	}
+
+	result := MarkReadResult{Remaining: after.Total, Known: known}
+	if known && before.Total > after.Total {
+		result.Marked = before.Total - after.Total
+	}
+	return result, nil
+}
+
+// clickNotificationTab 点击通知页中文本以 label 开头的分类标签，标签文本中可能带有未读数
+func clickNotificationTab(page *rod.Page, label string) error {
+	tabs, err := page.Elements(selectors.Get(selectors.NotificationTab))
+	if err != nil {
+		return err
+	}
+
+	for _, tab := range tabs {
+		text, err := tab.Text()
+		if err != nil || !strings.HasPrefix(strings.TrimSpace(text), label) {
+			continue
+		}
+		return tab.Click(proto.InputMouseButtonLeft, 1)
+	}
+	return errors.Errorf("未找到通知分类: %s", label)
+}
+
+// GetNotifications 打开通知页获取最近的 limit 条消息，不足时滚动加载更多，
+// 消息数量不再增加或达到最大滚动次数时停止。默认打开“评论和@”分类，可用于找出有新评论的笔记。
+func (a *NotificationsAction) GetNotifications(ctx context.Context, limit int) ([]Notification, error) {
+	page := a.page.Context(ctx)
+
+	if err := navigateAndWait(page, configs.WaitActionFeeds, "https://www.xiaohongshu.com/notification"); err != nil {
+		return nil, err
+	}
+	if err := page.WaitLoad(); err != nil {
+		return nil, errors.Wrap(err, "wait notification page load failed")
+	}
+
+	notifications, err := waitNotifications(page)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := 0
+	for i := 0; i < maxNotificationScrolls && len(notifications) < limit; i++ {
+		if err := page.GetContext().Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := page.Evaluate(&rod.EvalOptions{
+			JS:     scrollNotificationsExpr,
+			JSArgs: []interface{}{selectors.Get(selectors.NotificationScroller)},
+		}); err != nil {
+			return nil, errors.Wrap(err, "scroll notifications failed")
+		}
+		time.Sleep(1 * time.Second)
+
+		loaded, err := evalNotifications(page)
+		if err != nil {
+			return nil, err
+		}
+		stale = nextStaleScrolls(len(notifications), len(loaded), stale)
+		notifications = loaded
+		if stale >= maxStaleNotificationScrolls {
+			logrus.Infof("通知列表不再增加，共 %d 条", len(notifications))
+			break
+		}
+	}
+
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+// waitNotifications 等待页面状态中出现消息列表，列表为空表示没有消息
+func waitNotifications(page *rod.Page) ([]Notification, error) {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		notifications, err := evalNotifications(page)
+		if err == nil {
+			return notifications, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+
+		select {
+		case <-page.GetContext().Done():
+			return nil, page.GetContext().Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// evalNotifications 读取并解析页面当前已加载的消息
+func evalNotifications(page *rod.Page) ([]Notification, error) {
+	res, err := page.Evaluate(&rod.EvalOptions{JS: notificationListExpr, ByValue: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "read notifications failed")
+	}
+	raw := res.Value.Str()
+	if raw == "" {
+		return nil, errors.New("notification state not found")
+	}
+	return parseNotifications(raw)
+}
+
+// parseNotifications 解析 notificationListExpr 的返回值，按 ID 去重并保持页面顺序
+func parseNotifications(raw string) ([]Notification, error) {
+	var list []rawNotification
+	if err := json.Unmarshal([]byte(raw), &list); err != nil {
+		return nil, errors.Wrap(err, "unmarshal notifications failed")
+	}
+
+	notifications := make([]Notification, 0, len(list))
+	seen := make(map[string]bool, len(list))
+	for _, r := range list {
+		n := r.normalize()
+		key := n.ID
+		if key == "" {
+			key = strings.Join([]string{n.RawType, n.User.UserID, n.CommentID, strconv.FormatInt(n.Time.Unix(), 10)}, "|")
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+// normalize 转换为对外的消息结构，类型取原始类型中 / 之前的部分
+func (r rawNotification) normalize() Notification {
+	msgType, _, _ := strings.Cut(r.Type, "/")
+	n := Notification{
+		ID:      rawScalar(r.ID),
+		Type:    msgType,
+		RawType: r.Type,
+		Title:   strings.TrimSpace(r.Title),
+		User: NotificationUser{
+			UserID:    r.UserInfo.UserID,
+			Nickname:  r.UserInfo.Nickname,
+			Avatar:    r.UserInfo.Image,
+			XsecToken: r.UserInfo.XsecToken,
+		},
+		CommentID: r.CommentInfo.ID,
+		Snippet:   strings.TrimSpace(r.CommentInfo.Content),
+	}
+	if r.ItemInfo.ID != "" {
+		n.Note = &NotificationNote{
+			ID:        r.ItemInfo.ID,
+			XsecToken: r.ItemInfo.XsecToken,
+			Content:   strings.TrimSpace(r.ItemInfo.Content),
+			Cover:     r.ItemInfo.Image,
+		}
+	}
+
+	// 接口中的时间为秒级时间戳，兼容毫秒
+	if ts, err := strconv.ParseInt(rawScalar(r.Time), 10, 64); err == nil && ts > 0 {
+		if ts > 1e12 {
+			n.Time = time.UnixMilli(ts).In(siteLocation)
+		} else {
+			n.Time = time.Unix(ts, 0).In(siteLocation)
+		}
+	}
+	return n
+}
+
+// parseNotificationCounts 解析 notificationsExpr 的返回值。
+// 角标只有总数；没有任何未读数据时返回全 0，来源为 none。
+func parseNotificationCounts(raw string) (NotificationCounts, error) {
+	if raw == "" {
+		return NotificationCounts{Source: NotificationSourceNone}, nil
+	}
+
+	var r rawNotifications
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		return NotificationCounts{}, errors.Wrap(err, "unmarshal notifications failed")
+	}
+
+	switch r.Source {
+	case NotificationSourceState:
+		counts := NotificationCounts{
+			CommentsAndMentions: ParseCount(rawScalar(r.Unread.Mentions)),
+			LikesAndCollects:    ParseCount(rawScalar(r.Unread.Likes)),
+			NewFollowers:        ParseCount(rawScalar(r.Unread.Connections)),
+			Source:              NotificationSourceState,
+		}
+		counts.Total = counts.CommentsAndMentions + counts.LikesAndCollects + counts.NewFollowers
+		if total := ParseCount(firstNonEmpty(rawScalar(r.Unread.UnreadCount), rawScalar(r.Unread.Unread))); total > counts.Total {
+			counts.Total = total
+		}
+		return counts, nil
+	case NotificationSourceBadge:
+		return NotificationCounts{Total: ParseCount(r.Badge), Source: NotificationSourceBadge}, nil
+	default:
+		return NotificationCounts{Source: NotificationSourceNone}, nil
+	}
+}
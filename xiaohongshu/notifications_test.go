@@ -0,0 +1,94 @@
+package xiaohongshu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotificationCounts(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want NotificationCounts
+	}{
+		{
+			name: "state",
+			raw:  `{"source":"state","unread":{"mentions":3,"likes":"12","connections":1,"unreadCount":16}}`,
+			want: NotificationCounts{CommentsAndMentions: 3, LikesAndCollects: 12, NewFollowers: 1, Total: 16, Source: NotificationSourceState},
+		},
+		{
+			name: "state without total",
+			raw:  `{"source":"state","unread":{"mentions":2,"likes":0,"connections":5}}`,
+			want: NotificationCounts{CommentsAndMentions: 2, NewFollowers: 5, Total: 7, Source: NotificationSourceState},
+		},
+		{
+			name: "state all zero",
+			raw:  `{"source":"state","unread":{"mentions":0,"likes":0,"connections":0,"unread_count":0}}`,
+			want: NotificationCounts{Source: NotificationSourceState},
+		},
+		{
+			name: "badge",
+			raw:  `{"source":"badge","badge":"99+"}`,
+			want: NotificationCounts{Total: 99, Source: NotificationSourceBadge},
+		},
+		{
+			name: "no badge",
+			raw:  `{"source":"","badge":""}`,
+			want: NotificationCounts{Source: NotificationSourceNone},
+		},
+		{
+			name: "empty",
+			raw:  "",
+			want: NotificationCounts{Source: NotificationSourceNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNotificationCounts(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := parseNotificationCounts("{")
+	assert.Error(t, err)
+}
+
+func TestParseNotifications(t *testing.T) {
+	notifications, err := parseNotifications(loadFixture(t, "notifications_state.json"))
+	require.NoError(t, err)
+	require.Len(t, notifications, 3)
+
+	comment := notifications[0]
+	assert.Equal(t, "6710a1b2000000001d02aa01", comment.ID)
+	assert.Equal(t, "comment", comment.Type)
+	assert.Equal(t, "comment/item", comment.RawType)
+	assert.Equal(t, "城市漫游", comment.User.Nickname)
+	assert.Equal(t, "ABuser1=", comment.User.XsecToken)
+	require.NotNil(t, comment.Note)
+	assert.Equal(t, "65f1a2b3000000001203c4d5", comment.Note.ID)
+	assert.Equal(t, "ABtoken1=", comment.Note.XsecToken)
+	assert.Equal(t, "6710a1b2000000001d02bb01", comment.CommentID)
+	assert.Equal(t, "请问这家店在哪里？[害羞R]", comment.Snippet)
+	assert.True(t, time.Unix(1728979200, 0).Equal(comment.Time))
+
+	mention := notifications[1]
+	assert.Equal(t, "mention", mention.Type)
+	assert.True(t, time.UnixMilli(1728892800000).Equal(mention.Time))
+
+	follow := notifications[2]
+	assert.Equal(t, "follow", follow.Type)
+	assert.Nil(t, follow.Note)
+	assert.Empty(t, follow.Snippet)
+
+	empty, err := parseNotifications("[]")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = parseNotifications("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// ErrOwnNoteNotFound 自己的主页中暂未出现指定笔记，刚发布的笔记可能仍在处理或审核中
+var ErrOwnNoteNotFound = errors.New("note not found on own profile")
+
+const selfUserReadyExpr = `() => {
+	const state = window.__INITIAL_STATE__;
+	return !!(state && state.user && state.user.userInfo);
+}`
+
+// SelfUserID 打开首页，从页面状态中读取当前登录账号的用户 ID
+func (u *UserProfileAction) SelfUserID(ctx context.Context) (string, error) {
+	page := u.page.Context(ctx)
+
+	if err := navigateAndWait(page, configs.WaitActionFeeds, "https://www.xiaohongshu.com/explore"); err != nil {
+		return "", err
+	}
+	if err := waitForInitialState(page, selfUserReadyExpr, 15*time.Second); err != nil {
+		return "", errors.Wrap(err, "wait user info failed")
+	}
+
+	jsonStr, err := evalInitialState(page)
+	if err != nil {
+		return "", err
+	}
+	userID := parseSelfUserID(jsonStr)
+	if userID == "" {
+		return "", errors.New("未能获取当前账号的用户 ID")
+	}
+	return userID, nil
+}
+
+// FindOwnNote 在当前登录账号的主页中查找 since 之后发布、标题为 title 的笔记，
+// 返回的 Feed 带有 xsecToken，可直接用于评论等操作；找不到时返回 ErrOwnNoteNotFound
+func (u *UserProfileAction) FindOwnNote(ctx context.Context, title string, since time.Time) (*Feed, error) {
+	notes, err := u.OwnNotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed := findNoteByTitle(notes, title, since); feed != nil {
+		return feed, nil
+	}
+	return nil, ErrOwnNoteNotFound
+}
+
+// OwnNotes 返回当前登录账号主页中的笔记（置顶笔记在前，其余按发布时间倒序）
+func (u *UserProfileAction) OwnNotes(ctx context.Context) ([]Feed, error) {
+	userID, err := u.SelfUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := u.UserProfile(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	return profile.Feeds, nil
+}
+
+// parseSelfUserID 从 __INITIAL_STATE__ JSON 的 user.userInfo 中解析当前账号的用户 ID
+func parseSelfUserID(jsonStr string) string {
+	var state struct {
+		User struct {
+			UserInfo json.RawMessage `json:"userInfo"`
+		} `json:"user"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &state); err != nil {
+		return ""
+	}
+
+	// userInfo 可能是响应式包装（_value / _rawValue）或普通对象
+	var info struct {
+		UserID   string `json:"userId"`
+		Value    *User  `json:"_value"`
+		RawValue *User  `json:"_rawValue"`
+	}
+	if err := json.Unmarshal(state.User.UserInfo, &info); err != nil {
+		return ""
+	}
+	switch {
+	case info.Value != nil && info.Value.UserID != "":
+		return info.Value.UserID
+	case info.RawValue != nil && info.RawValue.UserID != "":
+		return info.RawValue.UserID
+	}
+	return info.UserID
+}
+
+// findNoteByTitle 取主页中第一篇非置顶笔记（即最新发布的笔记），标题匹配时返回。
+// 刚发布的笔记不会是置顶笔记；卡片带有发布时间且早于 since 时视为同名的旧笔记，返回 nil
+func findNoteByTitle(feeds []Feed, title string, since time.Time) *Feed {
+	title = strings.TrimSpace(title)
+	for _, f := range feeds {
+		if f.NoteCard.InteractInfo.Sticky {
+			continue
+		}
+		if f.XsecToken == "" || strings.TrimSpace(f.NoteCard.DisplayTitle) != title {
+			return nil
+		}
+		if !f.PublishedAt.IsZero() && f.PublishedAt.Before(since) {
+			return nil
+		}
+		return &f
+	}
+	return nil
+}
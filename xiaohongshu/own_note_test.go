@@ -0,0 +1,47 @@
+package xiaohongshu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelfUserID(t *testing.T) {
+	assert.Equal(t, "5f0c1e2d000000000101abcd", parseSelfUserID(loadFixture(t, "feeds_state.json")))
+	assert.Equal(t, "u1", parseSelfUserID(`{"user":{"userInfo":{"_rawValue":{"userId":"u1"}}}}`))
+	assert.Equal(t, "u2", parseSelfUserID(`{"user":{"userInfo":{"userId":"u2"}}}`))
+	assert.Equal(t, "", parseSelfUserID(`{"user":{}}`))
+	assert.Equal(t, "", parseSelfUserID(`not json`))
+}
+
+func TestFindNoteByTitle(t *testing.T) {
+	since := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	note := func(id, title string, publishedAt time.Time, sticky bool) Feed {
+		return Feed{
+			ID:          id,
+			XsecToken:   "token-" + id,
+			PublishedAt: publishedAt,
+			NoteCard: NoteCard{
+				DisplayTitle: title,
+				InteractInfo: InteractInfo{Sticky: sticky},
+			},
+		}
+	}
+
+	feeds := []Feed{
+		note("pinned", "春日咖啡", time.Time{}, true),
+		note("new", " 春日咖啡 ", since.Add(time.Minute), false),
+		note("old", "春日咖啡", since.Add(-24*time.Hour), false),
+	}
+	// 跳过置顶笔记，取第一篇非置顶笔记
+	got := findNoteByTitle(feeds, "春日咖啡", since)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "new", got.ID)
+		assert.Equal(t, "token-new", got.XsecToken)
+	}
+
+	// 新笔记尚未出现时，不把同名的旧笔记当作新笔记
+	assert.Nil(t, findNoteByTitle(feeds[2:], "春日咖啡", since))
+	assert.Nil(t, findNoteByTitle(feeds[1:], "其他标题", since))
+}
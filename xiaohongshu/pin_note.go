@@ -0,0 +1,175 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+const urlOfNoteManager = "https://creator.xiaohongshu.com/new/note-manager"
+
+// ErrPinLimitReached 置顶笔记数量已达上限，需要先取消其他笔记的置顶
+var ErrPinLimitReached = errors.New("pinned note limit reached, unpin another note first")
+
+// findNoteCardExpr 返回包含指定笔记 ID 的卡片下标，找不到时返回 -1
+const findNoteCardExpr = `(selector, noteID) => {
+	const cards = Array.from(document.querySelectorAll(selector));
+	return cards.findIndex((el) => el.outerHTML.includes(noteID));
+}`
+
+// PinAction 表示笔记置顶动作
+type PinAction struct {
+	page *rod.Page
+}
+
+// NewPinAction 创建笔记置顶动作
+func NewPinAction(page *rod.Page) *PinAction {
+	return &PinAction{page: page}
+}
+
+// SetPinned 在创作者中心笔记管理页置顶或取消置顶自己的笔记。
+// 笔记已处于目标状态时直接返回；置顶数量已达上限时返回 ErrPinLimitReached。
+func (a *PinAction) SetPinned(ctx context.Context, noteID string, pin bool) error {
+	page := a.page.Context(ctx).Timeout(60 * time.Second)
+
+	if err := page.Navigate(urlOfNoteManager); err != nil {
+		return errors.Wrap(err, "打开笔记管理页失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		return errors.Wrap(err, "笔记管理页加载失败")
+	}
+	if err := checkLoginRedirect(page); err != nil {
+		return err
+	}
+	if err := dismissProfileSetup(page); err != nil {
+		return err
+	}
+
+	card, err := findNoteCard(page, noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := card.Hover(); err != nil {
+		logrus.Debugf("hover note card failed: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	text, err := card.Text()
+	if err != nil {
+		return errors.Wrap(err, "读取笔记卡片失败")
+	}
+	pinned := strings.Contains(text, "取消置顶")
+	if pinned == pin {
+		logrus.Infof("笔记 %s 已处于目标置顶状态: %v", noteID, pin)
+		return nil
+	}
+
+	label := "置顶"
+	if !pin {
+		label = "取消置顶"
+	}
+	button, err := card.ElementR("span, div, button", fmt.Sprintf(`^\s*%s\s*$`, label))
+	if err != nil {
+		return errors.Errorf("笔记 %s 不支持%s", noteID, label)
+	}
+	if err := humanDelay(ctx); err != nil {
+		return err
+	}
+	if err := button.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrapf(err, "点击%s失败", label)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	// 部分操作会弹出二次确认
+	if dialog, err := findVisibleDialog(page.Timeout(2 * time.Second)); err == nil {
+		if confirm, err := dialog.ElementR(selectors.Get(selectors.DialogButton), `^\s*(确定|确认|置顶)\s*$`); err == nil {
+			if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+				return errors.Wrap(err, "确认置顶操作失败")
+			}
+		}
+	}
+
+	return waitForPinResult(page, noteID, pin)
+}
+
+// findNoteCard 在笔记管理页中查找指定笔记的卡片
+func findNoteCard(page *rod.Page, noteID string) (*rod.Element, error) {
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		res, err := page.Evaluate(&rod.EvalOptions{
+			JS:      findNoteCardExpr,
+			JSArgs:  []interface{}{selectors.Get(selectors.NoteCard), noteID},
+			ByValue: true,
+		})
+		if err == nil && res != nil {
+			if idx := res.Value.Int(); idx >= 0 {
+				cards, err := page.Elements(selectors.Get(selectors.NoteCard))
+				if err == nil && idx < len(cards) {
+					return cards[idx], nil
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("笔记管理页中未找到笔记 %s，请确认是当前账号发布的笔记", noteID)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// waitForPinResult 等待置顶操作的提示，提示上限时返回 ErrPinLimitReached
+func waitForPinResult(page *rod.Page, noteID string, pin bool) error {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		res, err := page.Evaluate(&rod.EvalOptions{JS: `() => Array.from(document.querySelectorAll('.d-toast, .el-message, [class*="toast"]'))
+			.map(e => (e.innerText || "").trim())
+			.filter(Boolean)
+			.join(" ")`, ByValue: true})
+		if err == nil && res != nil {
+			if err := classifyPinToast(res.Value.Str()); err != nil {
+				return err
+			}
+			if res.Value.Str() != "" {
+				logrus.Infof("笔记 %s 置顶操作提示: %s", noteID, res.Value.Str())
+				return nil
+			}
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	// 没有提示时以卡片状态为准
+	card, err := findNoteCard(page, noteID)
+	if err != nil {
+		return err
+	}
+	text, err := card.Text()
+	if err != nil {
+		return errors.Wrap(err, "读取笔记卡片失败")
+	}
+	if strings.Contains(text, "取消置顶") != pin {
+		return errors.Errorf("笔记 %s 置顶状态未改变", noteID)
+	}
+	return nil
+}
+
+// classifyPinToast 根据操作提示判断置顶是否失败
+func classifyPinToast(toast string) error {
+	switch {
+	case toast == "":
+		return nil
+	case strings.Contains(toast, "上限") || strings.Contains(toast, "最多"):
+		return errors.Wrap(ErrPinLimitReached, toast)
+	case strings.Contains(toast, "失败") || strings.Contains(toast, "不支持") || strings.Contains(toast, "无法"):
+		return errors.Errorf("置顶操作失败: %s", toast)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package xiaohongshu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPinToast(t *testing.T) {
+	assert.NoError(t, classifyPinToast(""))
+	assert.NoError(t, classifyPinToast("置顶成功"))
+	assert.True(t, errors.Is(classifyPinToast("最多置顶3篇笔记"), ErrPinLimitReached))
+	assert.True(t, errors.Is(classifyPinToast("置顶数量已达上限"), ErrPinLimitReached))
+
+	err := classifyPinToast("该笔记暂不支持置顶")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrPinLimitReached))
+}
@@ -0,0 +1,135 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// ErrProfileSetupRequired 新账号被“完善资料”弹窗拦截且无法自动跳过，需要用户手动完成资料设置。
+var ErrProfileSetupRequired = errors.New("profile setup required: complete the account profile in the browser manually")
+
+// profileSetupKeywords 弹窗文本包含这些关键词时认为是完善资料弹窗
+var profileSetupKeywords = []string{"完善资料", "完善个人资料", "完善个人信息", "完善信息", "设置头像", "设置昵称", "填写昵称", "选择你感兴趣"}
+
+// profileSetupSkipLabels 可用于跳过弹窗的按钮文本
+var profileSetupSkipLabels = []string{"跳过", "稍后", "稍后再说", "以后再说", "下次再说", "暂不", "暂不完善", "我知道了"}
+
+// collectDialogsExpr 收集当前可见弹窗的文本和按钮文本
+const collectDialogsExpr = `(dialogSel, buttonSel) => {
+	const visible = (el) => {
+		const rect = el.getBoundingClientRect();
+		const style = window.getComputedStyle(el);
+		return rect.width > 0 && rect.height > 0 && style.display !== 'none' && style.visibility !== 'hidden';
+	};
+	const dialogs = Array.from(document.querySelectorAll(dialogSel)).map((el) => ({
+		visible: visible(el),
+		text: (el.innerText || '').slice(0, 500),
+		buttons: Array.from(el.querySelectorAll(buttonSel)).map((b) => (b.innerText || '').trim()),
+	}));
+	return JSON.stringify(dialogs);
+}`
+
+// clickDialogButtonExpr 点击第 dialogIdx 个弹窗中的第 buttonIdx 个按钮
+const clickDialogButtonExpr = `(dialogSel, buttonSel, dialogIdx, buttonIdx) => {
+	const dialog = document.querySelectorAll(dialogSel)[dialogIdx];
+	const button = dialog && dialog.querySelectorAll(buttonSel)[buttonIdx];
+	if (!button) {
+		return false;
+	}
+	button.click();
+	return true;
+}`
+
+// pageDialog 页面上的弹窗快照
+type pageDialog struct {
+	Visible bool     `json:"visible"`
+	Text    string   `json:"text"`
+	Buttons []string `json:"buttons"`
+}
+
+// matchProfileSetupDialog 在弹窗中查找完善资料弹窗，返回弹窗下标和跳过按钮下标。
+// 找不到弹窗时 dialogIdx 为 -1；找到弹窗但没有跳过按钮时 buttonIdx 为 -1。
+func matchProfileSetupDialog(dialogs []pageDialog) (dialogIdx, buttonIdx int) {
+	for i, d := range dialogs {
+		if !d.Visible || !containsAny(d.Text, profileSetupKeywords) {
+			continue
+		}
+		for j, label := range d.Buttons {
+			for _, skip := range profileSetupSkipLabels {
+				if strings.TrimSpace(label) == skip {
+					return i, j
+				}
+			}
+		}
+		return i, -1
+	}
+	return -1, -1
+}
+
+func containsAny(s string, keywords []string) bool {
+	for _, k := range keywords {
+		if strings.Contains(s, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// dismissProfileSetup 检测页面上的完善资料弹窗，能跳过时自动点击跳过，
+// 无法跳过时返回 ErrProfileSetupRequired。读取页面失败时不视为弹窗拦截。
+func dismissProfileSetup(page *rod.Page) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		dialogs, ok := evalDialogs(page)
+		if !ok {
+			return nil
+		}
+
+		dialogIdx, buttonIdx := matchProfileSetupDialog(dialogs)
+		if dialogIdx < 0 {
+			return nil
+		}
+		if buttonIdx < 0 {
+			return ErrProfileSetupRequired
+		}
+
+		logrus.Infof("检测到完善资料弹窗，点击“%s”跳过", dialogs[dialogIdx].Buttons[buttonIdx])
+		if err := clickDialogButton(page, dialogIdx, buttonIdx); err != nil {
+			return ErrProfileSetupRequired
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	// 点击跳过后弹窗仍在
+	return ErrProfileSetupRequired
+}
+
+// evalDialogs 读取页面上的弹窗快照，读取失败时 ok 为 false
+func evalDialogs(page *rod.Page) (dialogs []pageDialog, ok bool) {
+	res, err := page.Evaluate(&rod.EvalOptions{
+		JS:      collectDialogsExpr,
+		JSArgs:  []interface{}{selectors.Get(selectors.Dialog), selectors.Get(selectors.DialogButton)},
+		ByValue: true,
+	})
+	if err != nil || res == nil {
+		return nil, false
+	}
+	if err := json.Unmarshal([]byte(res.Value.Str()), &dialogs); err != nil {
+		return nil, false
+	}
+	return dialogs, true
+}
+
+// clickDialogButton 点击第 dialogIdx 个弹窗中的第 buttonIdx 个按钮
+func clickDialogButton(page *rod.Page, dialogIdx, buttonIdx int) error {
+	_, err := page.Evaluate(&rod.EvalOptions{
+		JS:     clickDialogButtonExpr,
+		JSArgs: []interface{}{selectors.Get(selectors.Dialog), selectors.Get(selectors.DialogButton), dialogIdx, buttonIdx},
+	})
+	return err
+}
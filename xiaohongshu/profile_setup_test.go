@@ -0,0 +1,63 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchProfileSetupDialog(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialogs    []pageDialog
+		wantDialog int
+		wantButton int
+	}{
+		{
+			name:       "no dialogs",
+			wantDialog: -1,
+			wantButton: -1,
+		},
+		{
+			name: "unrelated dialog",
+			dialogs: []pageDialog{
+				{Visible: true, Text: "确认删除这条笔记？", Buttons: []string{"取消", "确认"}},
+			},
+			wantDialog: -1,
+			wantButton: -1,
+		},
+		{
+			name: "hidden profile dialog",
+			dialogs: []pageDialog{
+				{Visible: false, Text: "完善资料，让更多人认识你", Buttons: []string{"跳过"}},
+			},
+			wantDialog: -1,
+			wantButton: -1,
+		},
+		{
+			name: "skippable",
+			dialogs: []pageDialog{
+				{Visible: true, Text: "登录成功"},
+				{Visible: true, Text: "完善资料，让更多人认识你", Buttons: []string{"去完善", " 跳过 "}},
+			},
+			wantDialog: 1,
+			wantButton: 1,
+		},
+		{
+			name: "not skippable",
+			dialogs: []pageDialog{
+				{Visible: true, Text: "请设置头像和昵称", Buttons: []string{"保存"}},
+			},
+			wantDialog: 0,
+			wantButton: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialogIdx, buttonIdx := matchProfileSetupDialog(tt.dialogs)
+			assert.Equal(t, tt.wantDialog, dialogIdx)
+			assert.Equal(t, tt.wantButton, buttonIdx)
+		})
+	}
+}
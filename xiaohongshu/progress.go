@@ -0,0 +1,89 @@
+package xiaohongshu
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// ProgressFunc 上传进度回调，percent 取值 0-100
+type ProgressFunc func(percent int)
+
+type progressKey struct{}
+
+// WithProgress 在 context 中附加上传进度回调，视频上传过程中进度变化时调用
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func progressFromContext(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
+
+// uploadProgressExpr 从上传界面读取进度百分比，读取不到时返回 -1
+const uploadProgressExpr = `() => {
+	for (const el of document.querySelectorAll('[class*="progress"]')) {
+		const m = (el.innerText || '').match(/(\d{1,3})\s*%/);
+		if (m) return Number(m[1]);
+		const width = el.style && el.style.width;
+		if (width && width.endsWith('%')) return Math.round(parseFloat(width));
+	}
+	const m = document.body && document.body.innerText.match(/上传中[^\d]{0,10}(\d{1,3})\s*%/);
+	return m ? Number(m[1]) : -1;
+}`
+
+// progressLogInterval 进度未变化时也定期打印日志，避免看起来像卡住
+const progressLogInterval = 30 * time.Second
+
+// uploadProgressTracker 记录上传进度，进度变化时回调，并按 10% 或固定间隔打印日志
+type uploadProgressTracker struct {
+	fn       ProgressFunc
+	percent  int
+	logged   int
+	loggedAt time.Time
+}
+
+func newUploadProgressTracker(fn ProgressFunc) *uploadProgressTracker {
+	return &uploadProgressTracker{fn: fn, percent: -1, logged: -1, loggedAt: time.Now()}
+}
+
+// poll 读取页面上的上传进度
+func (t *uploadProgressTracker) poll(page *rod.Page) {
+	res, err := page.Evaluate(&rod.EvalOptions{JS: uploadProgressExpr, ByValue: true})
+	if err != nil || res == nil {
+		return
+	}
+	if percent := res.Value.Int(); percent >= 0 {
+		t.report(percent)
+	} else if time.Since(t.loggedAt) >= progressLogInterval {
+		slog.Info("视频上传中，暂未读取到进度")
+		t.loggedAt = time.Now()
+	}
+}
+
+func (t *uploadProgressTracker) report(percent int) {
+	if percent > 100 {
+		percent = 100
+	}
+	// 进度只增不减，避免读到其他进度条时回退
+	if percent <= t.percent {
+		if time.Since(t.loggedAt) >= progressLogInterval {
+			slog.Info("视频上传中", "progress", t.percent)
+			t.loggedAt = time.Now()
+		}
+		return
+	}
+	t.percent = percent
+
+	if t.fn != nil {
+		t.fn(percent)
+	}
+	if percent/10 > t.logged/10 || percent == 100 || time.Since(t.loggedAt) >= progressLogInterval {
+		slog.Info("视频上传中", "progress", percent)
+		t.logged = percent
+		t.loggedAt = time.Now()
+	}
+}
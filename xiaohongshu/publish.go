@@ -2,6 +2,8 @@ package xiaohongshu
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
@@ -11,6 +13,8 @@ import (
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
 )
 
 // PublishImageContent 发布图文内容
@@ -18,22 +22,19 @@ type PublishImageContent struct {
 	Title      string
 	Content    string
 	Tags       []string
-	ImagePaths []string
+	ImagePaths []string // 按顺序上传，第一张作为封面
+	ProductIDs []string // 需要挂载的商品 ID，需账号具备带货权限
 }
 
 type PublishAction struct {
 	page *rod.Page
 }
 
-const (
-	urlOfPublic = `https://creator.xiaohongshu.com/publish/publish?source=official`
-)
-
 func NewPublishImageAction(page *rod.Page) (*PublishAction, error) {
 
 	pp := page.Timeout(90 * time.Second)
 
-	pp.MustNavigate(urlOfPublic)
+	pp.MustNavigate(configs.GetPublishURL())
 
 	if err := waitPublishEditorReady(pp); err != nil {
 		return nil, err
@@ -44,12 +45,10 @@ func NewPublishImageAction(page *rod.Page) (*PublishAction, error) {
 	// 等待一段时间确保页面完全加载
 	time.Sleep(1 * time.Second)
 
-	if err := clickPublishTab(pp, "上传图文"); err != nil {
+	if err := clickPublishTab(pp, selectors.Get(selectors.ImageTabLabel)); err != nil {
 		return nil, err
 	}
 
-	time.Sleep(1 * time.Second)
-
 	return &PublishAction{
 		page: pp,
 	}, nil
@@ -66,15 +65,16 @@ func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent
 		return errors.Wrap(err, "小红书上传图片失败")
 	}
 
-	if err := submitPublish(page, content.Title, content.Content, content.Tags); err != nil {
+	if err := submitPublish(page, content.Title, content.Content, content.Tags, content.ProductIDs); err != nil {
 		return errors.Wrap(err, "小红书发布失败")
 	}
 
 	return nil
 }
 
+// clickPublishTab 点击指定的发布 TAB，并等待其切换完成、上传面板渲染后返回
 func clickPublishTab(page *rod.Page, label string) error {
-	createElems, err := page.Elements("div.creator-tab")
+	createElems, err := page.Elements(selectors.Get(selectors.CreatorTab))
 	if err != nil {
 		return err
 	}
@@ -97,21 +97,118 @@ func clickPublishTab(page *rod.Page, label string) error {
 			continue
 		}
 
-		if text == label {
+		if matchTabLabel(text, label) {
 			if err := elem.Click(proto.InputMouseButtonLeft, 1); err != nil {
 				slog.Error("点击发布TAB失败", "label", label, "error", err)
 				continue
 			}
-			return nil
+			return waitPublishTabActive(page, label)
 		}
 	}
 
 	return errors.Errorf("未找到发布TAB: %s", label)
 }
 
-func uploadImages(page *rod.Page, imagesPaths []string) error {
-	pp := page.Timeout(30 * time.Second)
+// waitPublishTabActive 等待指定发布 TAB 处于选中状态、且其上传面板可见，避免在面板切换完成前查找上传输入框。
+// 最长等待 configs.GetPublishTabTimeout()。
+func waitPublishTabActive(page *rod.Page, label string) error {
+	timeout := configs.GetPublishTabTimeout()
+	deadline := time.Now().Add(timeout)
+	for {
+		if publishTabActive(page, label) && uploadPanelVisible(page) {
+			return nil
+		}
+		if err := page.GetContext().Err(); err != nil {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("发布TAB未切换到: %s（已等待 %s）", label, timeout)
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+}
 
+// matchTabLabel 判断 TAB 文本是否包含 label，忽略空白，避免站点在文本前后增加图标或空格后匹配失败
+func matchTabLabel(text, label string) bool {
+	label = strings.Join(strings.Fields(label), "")
+	return label != "" && strings.Contains(strings.Join(strings.Fields(text), ""), label)
+}
+
+// publishTabActive 判断文本包含 label 的可见发布 TAB 是否带有 active 类名
+func publishTabActive(page *rod.Page, label string) bool {
+	elems, err := page.Elements(selectors.Get(selectors.CreatorTab))
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		if !isElementVisible(elem) {
+			continue
+		}
+		text, err := elem.Text()
+		if err != nil || !matchTabLabel(text, label) {
+			continue
+		}
+		class, err := elem.Attribute("class")
+		if err == nil && class != nil && hasClass(*class, "active") {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadPanelVisible 判断是否有可见的上传面板，且面板内已渲染上传输入框
+func uploadPanelVisible(page *rod.Page) bool {
+	panels, err := page.Elements(selectors.Get(selectors.UploadContent))
+	if err != nil {
+		return false
+	}
+	for _, panel := range panels {
+		if !isElementVisible(panel) {
+			continue
+		}
+		if has, _, err := panel.Has("input[type='file']"); err == nil && has {
+			return true
+		}
+	}
+	return false
+}
+
+// hasClass 判断 class 属性中是否包含指定类名
+func hasClass(classAttr, name string) bool {
+	for _, c := range strings.Fields(classAttr) {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// errUploadStalled 预览数量低于预期且在 image_upload_stall 内没有变化，通常是该图片上传静默失败
+var errUploadStalled = errors.New("图片上传停滞")
+
+// FailedImage 重试后仍未上传成功的图片
+type FailedImage struct {
+	Index int    `json:"index"` // 在图片列表中的位置，从 0 开始
+	Path  string `json:"path"`
+}
+
+// ImageUploadError 部分图片重试后仍未上传成功，Failed 列出这些图片
+type ImageUploadError struct {
+	Failed []FailedImage
+}
+
+func (e *ImageUploadError) Error() string {
+	parts := make([]string, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		parts = append(parts, fmt.Sprintf("第 %d 张 %s", f.Index+1, f.Path))
+	}
+	return fmt.Sprintf("%d 张图片上传失败: %s", len(e.Failed), strings.Join(parts, ", "))
+}
+
+// uploadImages 逐张上传图片，保证笔记中的图片顺序与 imagesPaths 一致（第一张为封面）。
+// 一次性 SetFiles 多个文件时站点不保证顺序，因此每张上传后等待预览数量恰好加一再上传下一张。
+// 某张图片重试后仍停滞时跳过它继续上传其余图片，最后通过 *ImageUploadError 返回所有失败的图片。
+func uploadImages(page *rod.Page, imagesPaths []string) error {
 	// 验证文件路径有效性
 	for _, path := range imagesPaths {
 		if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -119,45 +216,122 @@ func uploadImages(page *rod.Page, imagesPaths []string) error {
 		}
 	}
 
-	// 等待上传输入框出现
-	uploadInput, err := pp.Element(".upload-input")
-	if err != nil {
-		return err
+	retries, _ := configs.GetImageUploadRetries()
+
+	var failed []FailedImage
+	uploaded := 0
+	for i, path := range imagesPaths {
+		err := uploadImage(page, path, uploaded, retries)
+		if errors.Is(err, errUploadStalled) {
+			slog.Warn("图片重试后仍未上传成功，继续上传其余图片", "index", i+1, "path", path, "error", err)
+			failed = append(failed, FailedImage{Index: i, Path: path})
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "第 %d 张图片上传失败: %s", i+1, path)
+		}
+		uploaded++
 	}
-	if uploadInput == nil {
-		return errors.New("未找到图片上传输入框")
+
+	if len(failed) > 0 {
+		return &ImageUploadError{Failed: failed}
 	}
+	return nil
+}
 
-	// 上传多个文件
-	if err := uploadInput.SetFiles(imagesPaths); err != nil {
-		return errors.Wrap(err, "设置上传文件失败")
+// uploadImage 上传单张图片并等待其预览出现，uploaded 为此前已上传成功的图片数量。
+// 预览数量停滞时重新设置该文件，最多重试 retries 次。
+func uploadImage(page *rod.Page, path string, uploaded, retries int) error {
+	for attempt := 0; ; attempt++ {
+		uploadInput, err := findImageUploadInput(page, uploaded)
+		if err != nil {
+			return err
+		}
+
+		if err := uploadInput.SetFiles([]string{path}); err != nil {
+			return errors.Wrapf(err, "设置上传文件失败: %s", path)
+		}
+
+		// 等待并验证本张上传完成
+		err = waitForUploadComplete(page, uploaded+1)
+		if !errors.Is(err, errUploadStalled) || attempt >= retries {
+			return err
+		}
+		slog.Warn("图片上传停滞，重新设置文件", "path", path, "attempt", attempt+1, "max_attempts", retries+1)
+	}
+}
+
+// findImageUploadInput 查找图片上传输入框：首张图片使用初始上传区域，
+// 后续图片只在图文编辑器的追加图片区域中查找，避免误用同一页面上视频或封面的上传框
+func findImageUploadInput(page *rod.Page, uploaded int) (*rod.Element, error) {
+	if uploaded == 0 {
+		input, err := page.Timeout(30 * time.Second).Element(selectors.Get(selectors.UploadInput))
+		if err != nil {
+			return nil, errors.Wrap(err, "未找到图片上传输入框")
+		}
+		return input, nil
 	}
 
-	// 等待并验证上传完成
-	return waitForUploadComplete(pp, len(imagesPaths))
+	selector := selectors.Get(selectors.UploadInputMore)
+	if _, err := page.Timeout(30 * time.Second).Element(selector); err != nil {
+		return nil, errors.Wrap(err, "未找到追加图片的上传输入框")
+	}
+	inputs, err := page.Elements(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "未找到追加图片的上传输入框")
+	}
+	for _, input := range inputs {
+		accept, _ := input.Attribute("accept")
+		if accept != nil && acceptsVideo(*accept) {
+			continue
+		}
+		return input, nil
+	}
+	return nil, errors.New("追加图片区域中没有接受图片的上传输入框")
 }
 
-// waitForUploadComplete 等待并验证上传完成
+// waitForUploadComplete 等待并验证上传完成。
+// 预览数量超过预期时说明上传顺序已无法保证，直接返回错误。
+// 配置了 upload_preview_tolerance 时，预览数量在 upload_preview_grace 内不再变化且缺少的数量不超过容忍值，
+// 视为上传完成（预览渲染偶尔滞后于实际上传）；缺少的数量超过容忍值且在 image_upload_stall 内没有变化时返回 errUploadStalled。
 func waitForUploadComplete(page *rod.Page, expectedCount int) error {
 	maxWaitTime := 90 * time.Second
 	checkInterval := 500 * time.Millisecond
 	start := time.Now()
+	tolerance, grace := configs.GetUploadPreviewTolerance()
+	_, stall := configs.GetImageUploadRetries()
+
+	lastCount := -1
+	lastChange := start
 
 	slog.Info("开始等待图片上传完成", "expected_count", expectedCount)
 
 	for time.Since(start) < maxWaitTime {
 		// 使用具体的pr类名检查已上传的图片
-		uploadedImages, err := page.Elements(".img-preview-area .pr")
-
-		slog.Info("uploadedImages", "uploadedImages", uploadedImages)
+		uploadedImages, err := page.Elements(selectors.Get(selectors.ImagePreview))
 
 		if err == nil {
 			currentCount := len(uploadedImages)
 			slog.Info("检测到已上传图片", "current_count", currentCount, "expected_count", expectedCount)
-			if currentCount >= expectedCount {
-				slog.Info("所有图片上传完成", "count", currentCount)
+			if currentCount > expectedCount {
+				return errors.Errorf("图片预览数量(%d)超过预期(%d)，无法保证图片顺序", currentCount, expectedCount)
+			}
+			if currentCount == expectedCount {
+				slog.Info("图片上传完成", "count", currentCount)
+				return nil
+			}
+
+			if currentCount != lastCount {
+				lastCount, lastChange = currentCount, time.Now()
+			}
+			if uploadPreviewSettled(currentCount, expectedCount, tolerance, time.Since(lastChange), grace) {
+				slog.Warn("图片预览数量未达到预期，已在容忍范围内视为上传完成",
+					"current_count", currentCount, "expected_count", expectedCount, "tolerance", tolerance)
 				return nil
 			}
+			if uploadStalled(currentCount, expectedCount, tolerance, time.Since(lastChange), stall) {
+				return errors.Wrapf(errUploadStalled, "预览数量(%d)在 %s 内没有变化，预期 %d", currentCount, stall, expectedCount)
+			}
 		} else {
 			slog.Debug("未找到已上传图片元素")
 		}
@@ -168,24 +342,48 @@ func waitForUploadComplete(page *rod.Page, expectedCount int) error {
 	return errors.New("上传超时，请检查网络连接和图片大小")
 }
 
+// uploadPreviewSettled 判断预览数量未达到预期时能否按容忍值视为上传完成：
+// 至少有一张预览、缺少的数量不超过 tolerance，且预览数量已稳定 grace 以上。
+func uploadPreviewSettled(currentCount, expectedCount, tolerance int, stableFor, grace time.Duration) bool {
+	if tolerance <= 0 || currentCount <= 0 || currentCount >= expectedCount {
+		return false
+	}
+	return expectedCount-currentCount <= tolerance && stableFor >= grace
+}
+
+// uploadStalled 判断上传是否停滞：缺少的预览数量超过 tolerance，且预览数量已有 stall 以上没有变化。
+// stall 为 0 时不检测停滞。
+func uploadStalled(currentCount, expectedCount, tolerance int, stableFor, stall time.Duration) bool {
+	if stall <= 0 || currentCount >= expectedCount {
+		return false
+	}
+	return expectedCount-currentCount > tolerance && stableFor >= stall
+}
+
 func waitPublishEditorReady(page *rod.Page) error {
 	deadline := time.Now().Add(60 * time.Second)
 	for time.Now().Before(deadline) {
-		el, err := page.Element("div.upload-content")
+		el, err := page.Element(selectors.Get(selectors.UploadContent))
 		if err == nil && el != nil {
 			visible, visErr := el.Visible()
 			if visErr == nil && visible {
 				return nil
 			}
 		}
+		if err := checkLoginRedirect(page); err != nil {
+			return err
+		}
+		if err := dismissProfileSetup(page); err != nil {
+			return err
+		}
 		time.Sleep(500 * time.Millisecond)
 	}
 	return errors.New("发布编辑器未在预期时间内准备就绪")
 }
 
-func submitPublish(page *rod.Page, title, content string, tags []string) error {
+func submitPublish(page *rod.Page, title, content string, tags, productIDs []string) error {
 
-	titleElem, err := page.Element("div.d-input input")
+	titleElem, err := page.Element(selectors.Get(selectors.TitleInput))
 	if err != nil {
 		return errors.Wrap(err, "未找到标题输入框")
 	}
@@ -211,7 +409,11 @@ func submitPublish(page *rod.Page, title, content string, tags []string) error {
 
 	time.Sleep(1 * time.Second)
 
-	submitButton, err := page.Element("div.submit div.d-button-content")
+	if err := attachProducts(page, productIDs); err != nil {
+		return err
+	}
+
+	submitButton, err := page.Element(selectors.Get(selectors.SubmitButton))
 	if err != nil {
 		return errors.Wrap(err, "未找到提交按钮")
 	}
@@ -222,9 +424,79 @@ func submitPublish(page *rod.Page, title, content string, tags []string) error {
 		return errors.Wrap(err, "点击提交按钮失败")
 	}
 
-	time.Sleep(3 * time.Second)
+	return waitForPublishResult(page)
+}
 
-	return nil
+// ErrPublishUnconfirmed 已点击发布，但在等待时间内既没有成功提示也没有失败提示。
+// 笔记可能已经发布，调用方不应直接重试，以免重复发布
+var ErrPublishUnconfirmed = errors.New("publish submitted but the result could not be confirmed")
+
+// publishFailureKeywords 提示中包含这些文本时认为发布失败，其他提示（如“保存中”“上传中”）继续等待
+var publishFailureKeywords = []string{
+	"发布失败", "发布异常", "提交失败", "上传失败", "网络错误", "网络异常",
+	"操作频繁", "操作太频繁", "请稍后再试", "请稍后重试", "违规", "违反", "不能为空", "超出字数", "超过字数",
+}
+
+// isPublishFailureToast 提示是否表示发布失败
+func isPublishFailureToast(toast string) bool {
+	return containsAny(toast, publishFailureKeywords)
+}
+
+// waitForPublishResult 点击发布后轮询发布结果，成功提示或失败提示出现即返回，
+// 最长等待 configs.GetPublishConfirmTimeout()，超时返回 ErrPublishUnconfirmed。
+func waitForPublishResult(page *rod.Page) error {
+	timeout := configs.GetPublishConfirmTimeout()
+	deadline := time.Now().Add(timeout)
+
+	var lastToast string
+	for time.Now().Before(deadline) {
+		if err := page.GetContext().Err(); err != nil {
+			return err
+		}
+
+		// 点击发布后弹出验证码时等待完成验证，完成后重新计算确认发布结果的等待时间
+		if detectCaptcha(page) != nil {
+			if err := handleCaptcha(page); err != nil {
+				return err
+			}
+			deadline = time.Now().Add(timeout)
+		}
+
+		res, err := page.Evaluate(&rod.EvalOptions{JS: `() => {
+			const body = document.body ? document.body.innerText : "";
+			const toast = Array.from(document.querySelectorAll('.d-toast, .el-message, [class*="toast"]'))
+				.map(e => (e.innerText || "").trim())
+				.filter(Boolean)
+				.join(" ");
+			return JSON.stringify({
+				success: location.href.includes("published=true") || body.includes("发布成功"),
+				toast: toast,
+			});
+		}`, ByValue: true})
+		if err == nil && res != nil {
+			var outcome struct {
+				Success bool   `json:"success"`
+				Toast   string `json:"toast"`
+			}
+			if jsonErr := json.Unmarshal([]byte(res.Value.Str()), &outcome); jsonErr == nil {
+				if outcome.Success {
+					slog.Info("发布成功")
+					return nil
+				}
+				if isPublishFailureToast(outcome.Toast) {
+					return errors.Errorf("发布失败: %s", outcome.Toast)
+				}
+				if outcome.Toast != "" && outcome.Toast != lastToast {
+					slog.Info("发布中出现提示", "toast", outcome.Toast)
+					lastToast = outcome.Toast
+				}
+			}
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return errors.Wrapf(ErrPublishUnconfirmed, "未能在 %s 内确认发布结果", timeout)
 }
 
 // 查找内容输入框 - 使用Race方法处理两种样式
@@ -233,7 +505,7 @@ func getContentElement(page *rod.Page) (*rod.Element, bool) {
 	var found bool
 
 	page.Race().
-		Element("div.ql-editor").MustHandle(func(e *rod.Element) {
+		Element(selectors.Get(selectors.ContentEditor)).MustHandle(func(e *rod.Element) {
 		foundElement = e
 		found = true
 	}).
@@ -292,7 +564,7 @@ func inputTag(contentElem *rod.Element, tag string) {
 	time.Sleep(1 * time.Second)
 
 	page := contentElem.Page()
-	topicContainer, err := page.Element("#creator-editor-topic-container")
+	topicContainer, err := page.Element(selectors.Get(selectors.TopicContainer))
 	if err == nil && topicContainer != nil {
 		firstItem, err := topicContainer.Element(".item")
 		if err == nil && firstItem != nil {
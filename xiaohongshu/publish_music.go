@@ -0,0 +1,138 @@
+package xiaohongshu
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+var (
+	// ErrMusicUnavailable 发布页没有配乐入口（页面改版或账号不支持）
+	ErrMusicUnavailable = errors.New("music selection not available")
+	// ErrMusicNotFound 配乐搜索结果中没有匹配的曲目
+	ErrMusicNotFound = errors.New("no matching music track")
+)
+
+// musicEntryLabel 视频发布页配乐入口的文本
+const musicEntryLabel = `^\s*(添加音乐|选择音乐|添加配乐|配乐|背景音乐)\s*$`
+
+// selectMusic 打开配乐面板，按 query 搜索并选用第一首名称（含歌手）包含 query 的曲目。
+// 没有配乐入口时返回 ErrMusicUnavailable，没有匹配的曲目时关闭面板并返回 ErrMusicNotFound。
+func selectMusic(page *rod.Page, query string) error {
+	entry, err := page.Timeout(5*time.Second).ElementR("div, span, button", musicEntryLabel)
+	if err != nil || entry == nil || !isElementVisible(entry) {
+		return ErrMusicUnavailable
+	}
+	if err := entry.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击配乐入口失败")
+	}
+
+	time.Sleep(1 * time.Second)
+
+	dialog, err := findVisibleDialog(page)
+	if err != nil {
+		return errors.Wrap(ErrMusicUnavailable, "配乐面板未出现")
+	}
+
+	if err := searchMusic(dialog, query); err != nil {
+		closeMusicPanel(page)
+		return err
+	}
+
+	items, err := dialog.Timeout(5 * time.Second).Elements(selectors.Get(selectors.MusicItem))
+	if err != nil {
+		closeMusicPanel(page)
+		return errors.Wrapf(ErrMusicNotFound, "query %q", query)
+	}
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i], _ = item.Text()
+	}
+
+	idx := pickMusicResult(titles, query)
+	if idx < 0 {
+		closeMusicPanel(page)
+		return errors.Wrapf(ErrMusicNotFound, "query %q", query)
+	}
+
+	item := items[idx]
+	if err := item.Hover(); err != nil {
+		slog.Debug("悬停配乐曲目失败", "error", err)
+	}
+	if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择配乐失败")
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	// 曲目上有“使用”按钮时点击，面板有确认按钮时再确认
+	if use, err := item.ElementR(selectors.Get(selectors.DialogButton), `^\s*(使用|选用|添加)\s*$`); err == nil {
+		if err := use.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "使用配乐失败")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	if confirm, err := dialog.ElementR(selectors.Get(selectors.DialogButton), `^\s*(确定|确认|完成)\s*$`); err == nil {
+		if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "确认配乐失败")
+		}
+	}
+
+	slog.Info("已添加背景音乐", "query", query, "track", strings.TrimSpace(titles[idx]))
+	time.Sleep(1 * time.Second)
+	return nil
+}
+
+// searchMusic 在配乐面板的搜索框中输入 query 并搜索
+func searchMusic(dialog *rod.Element, query string) error {
+	searchInput, err := dialog.Element("input")
+	if err != nil {
+		return errors.Wrap(ErrMusicUnavailable, "未找到配乐搜索输入框")
+	}
+	if err := searchInput.Input(query); err != nil {
+		return errors.Wrap(err, "输入配乐关键词失败")
+	}
+	if err := searchInput.Type(input.Enter); err != nil {
+		return errors.Wrap(err, "搜索配乐失败")
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	return nil
+}
+
+// closeMusicPanel 按 Esc 关闭配乐面板，避免遮挡后续的发布操作
+func closeMusicPanel(page *rod.Page) {
+	if err := page.Keyboard.Type(input.Escape); err != nil {
+		slog.Debug("关闭配乐面板失败", "error", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+}
+
+// pickMusicResult 返回第一个文本包含 query 的曲目下标（忽略大小写和空白），没有时返回 -1
+func pickMusicResult(titles []string, query string) int {
+	query = normalizeMusicText(query)
+	if query == "" {
+		return -1
+	}
+	for i, title := range titles {
+		if strings.Contains(normalizeMusicText(title), query) {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeMusicText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
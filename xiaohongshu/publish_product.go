@@ -0,0 +1,127 @@
+package xiaohongshu
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// ErrProductPermission 账号没有商品（带货）权限，发布页不提供添加商品入口
+var ErrProductPermission = errors.New("account has no product tagging permission: a store or 带货 permission is required to attach products")
+
+// productNoPermissionKeywords 商品选择弹窗中提示没有权限的文本
+var productNoPermissionKeywords = []string{"暂无权限", "开通店铺", "开通带货", "申请带货", "没有可选商品权限"}
+
+// NormalizeProductIDs 去除商品 ID 的空白、空值和重复项，保持原有顺序
+func NormalizeProductIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// attachProducts 打开商品选择弹窗，按商品 ID 搜索并勾选后确认。
+// 页面没有添加商品入口或弹窗提示无权限时返回 ErrProductPermission，不会忽略商品继续发布。
+func attachProducts(page *rod.Page, productIDs []string) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	entry, err := page.Timeout(5*time.Second).ElementR("div, span, button", `^\s*添加商品\s*$`)
+	if err != nil || entry == nil || !isElementVisible(entry) {
+		return ErrProductPermission
+	}
+	if err := entry.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击添加商品失败")
+	}
+
+	time.Sleep(1 * time.Second)
+
+	dialog, err := findVisibleDialog(page)
+	if err != nil {
+		return errors.Wrap(err, "未找到商品选择弹窗")
+	}
+	if text, err := dialog.Text(); err == nil && containsAny(text, productNoPermissionKeywords) {
+		return ErrProductPermission
+	}
+
+	searchInput, err := dialog.Element("input")
+	if err != nil {
+		return errors.Wrap(err, "未找到商品搜索输入框")
+	}
+
+	for _, id := range productIDs {
+		if err := selectProduct(dialog, searchInput, id); err != nil {
+			return err
+		}
+	}
+
+	confirm, err := dialog.ElementR(selectors.Get(selectors.DialogButton), `^\s*(确定|确认|保存|完成)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "未找到商品选择确认按钮")
+	}
+	if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "确认商品选择失败")
+	}
+
+	slog.Info("已添加商品", "count", len(productIDs))
+	time.Sleep(1 * time.Second)
+	return nil
+}
+
+// selectProduct 在商品选择弹窗中搜索商品 ID 并勾选第一个结果
+func selectProduct(dialog, searchInput *rod.Element, productID string) error {
+	if err := searchInput.SelectAllText(); err != nil {
+		return errors.Wrap(err, "清空商品搜索框失败")
+	}
+	if err := searchInput.Input(productID); err != nil {
+		return errors.Wrapf(err, "输入商品 ID 失败: %s", productID)
+	}
+	if err := searchInput.Type(input.Enter); err != nil {
+		return errors.Wrapf(err, "搜索商品失败: %s", productID)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	checkbox, err := dialog.Timeout(5 * time.Second).Element(`[class*="goods-item"] input[type="checkbox"], [class*="goods-item"] [class*="checkbox"], [class*="product-item"] [class*="checkbox"]`)
+	if err != nil || checkbox == nil {
+		return errors.Errorf("未找到商品: %s，请确认商品 ID 正确且在账号的可选商品中", productID)
+	}
+	if err := checkbox.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrapf(err, "勾选商品失败: %s", productID)
+	}
+
+	slog.Info("已勾选商品", "product_id", productID)
+	time.Sleep(300 * time.Millisecond)
+	return nil
+}
+
+// findVisibleDialog 返回页面上第一个可见的弹窗
+func findVisibleDialog(page *rod.Page) (*rod.Element, error) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		dialogs, err := page.Elements(selectors.Get(selectors.Dialog))
+		if err == nil {
+			for _, d := range dialogs {
+				if visible, err := d.Visible(); err == nil && visible {
+					return d, nil
+				}
+			}
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return nil, errors.New("弹窗未出现")
+}
@@ -2,7 +2,10 @@ package xiaohongshu
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 
@@ -30,3 +33,126 @@ func TestPublish(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestAcceptsVideo(t *testing.T) {
+	assert.True(t, acceptsVideo("video/*"))
+	assert.True(t, acceptsVideo(".mp4, .mov"))
+	assert.True(t, acceptsVideo("image/png,video/mp4"))
+	assert.False(t, acceptsVideo(".jpg,.jpeg,.png,.webp"))
+	assert.False(t, acceptsVideo(""))
+}
+
+func TestAcceptsImageOnly(t *testing.T) {
+	assert.True(t, acceptsImageOnly(".jpg,.jpeg,.png,.webp"))
+	assert.True(t, acceptsImageOnly("image/*"))
+	assert.False(t, acceptsImageOnly("image/png,video/mp4"))
+	assert.False(t, acceptsImageOnly(""))
+}
+
+func TestHasClass(t *testing.T) {
+	assert.True(t, hasClass("creator-tab active", "active"))
+	assert.False(t, hasClass("creator-tab inactive", "active"))
+	assert.False(t, hasClass("", "active"))
+}
+
+func TestMatchTabLabel(t *testing.T) {
+	assert.True(t, matchTabLabel("上传图文", "上传图文"))
+	assert.True(t, matchTabLabel(" 上传 图文\n", "上传图文"))
+	assert.True(t, matchTabLabel("上传图文NEW", "上传图文"))
+	assert.False(t, matchTabLabel("上传视频", "上传图文"))
+	assert.False(t, matchTabLabel("上传图文", ""))
+}
+
+func TestNormalizeProductIDs(t *testing.T) {
+	assert.Equal(t, []string{"a1", "b2"}, NormalizeProductIDs([]string{" a1 ", "", "b2", "a1"}))
+	assert.Empty(t, NormalizeProductIDs(nil))
+}
+
+func TestUploadPreviewSettled(t *testing.T) {
+	grace := 10 * time.Second
+
+	assert.False(t, uploadPreviewSettled(2, 3, 0, time.Minute, grace), "strict by default")
+	assert.False(t, uploadPreviewSettled(2, 3, 1, 5*time.Second, grace), "waits for grace period")
+	assert.True(t, uploadPreviewSettled(2, 3, 1, grace, grace))
+	assert.False(t, uploadPreviewSettled(1, 3, 1, time.Minute, grace), "missing more than tolerance")
+	assert.False(t, uploadPreviewSettled(0, 1, 1, time.Minute, grace), "requires at least one preview")
+	assert.False(t, uploadPreviewSettled(3, 3, 1, time.Minute, grace))
+}
+
+func TestUploadStalled(t *testing.T) {
+	stall := 15 * time.Second
+
+	assert.False(t, uploadStalled(2, 3, 0, 10*time.Second, stall), "waits for stall window")
+	assert.True(t, uploadStalled(2, 3, 0, stall, stall))
+	assert.True(t, uploadStalled(0, 1, 0, time.Minute, stall), "first image never appeared")
+	assert.False(t, uploadStalled(2, 3, 1, time.Minute, stall), "missing preview within tolerance")
+	assert.False(t, uploadStalled(3, 3, 0, time.Minute, stall))
+	assert.False(t, uploadStalled(2, 3, 0, time.Minute, 0), "disabled")
+}
+
+func TestImageUploadError(t *testing.T) {
+	err := &ImageUploadError{Failed: []FailedImage{{Index: 1, Path: "/tmp/b.jpg"}, {Index: 3, Path: "/tmp/d.jpg"}}}
+	assert.Equal(t, "2 张图片上传失败: 第 2 张 /tmp/b.jpg, 第 4 张 /tmp/d.jpg", err.Error())
+}
+
+func TestMatchVideoUploadFailure(t *testing.T) {
+	keyword, ok := matchVideoUploadFailure("视频上传失败，请重试")
+	assert.True(t, ok)
+	assert.Equal(t, "上传失败", keyword)
+
+	_, ok = matchVideoUploadFailure("上传中 45%")
+	assert.False(t, ok)
+}
+
+func TestPickMusicResult(t *testing.T) {
+	titles := []string{"晴天\n周杰伦", "Summer Vibes\nDJ Mix", "夏日 Summer\n纯音乐"}
+
+	assert.Equal(t, 1, pickMusicResult(titles, "summer vibes"))
+	assert.Equal(t, 0, pickMusicResult(titles, "周杰伦"))
+	assert.Equal(t, 2, pickMusicResult(titles, "夏日summer"))
+	assert.Equal(t, -1, pickMusicResult(titles, "稻香"))
+	assert.Equal(t, -1, pickMusicResult(titles, "  "))
+	assert.Equal(t, -1, pickMusicResult(nil, "晴天"))
+}
+
+func TestPathWithinRoot(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	outside := t.TempDir()
+
+	inside := filepath.Join(root, "sub", "a.jpg")
+	require.NoError(t, os.MkdirAll(filepath.Dir(inside), 0o755))
+	require.NoError(t, os.WriteFile(inside, nil, 0o644))
+	secret := filepath.Join(outside, "secret.txt")
+	require.NoError(t, os.WriteFile(secret, nil, 0o644))
+
+	ok, err := pathWithinRoot(inside, root)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = pathWithinRoot(secret, root)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 通过 .. 或符号链接跳出根目录
+	ok, err = pathWithinRoot(filepath.Join(root, "sub", "..", "..", filepath.Base(outside), "secret.txt"), root)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	link := filepath.Join(root, "link.jpg")
+	require.NoError(t, os.Symlink(secret, link))
+	ok, err = pathWithinRoot(link, root)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = pathWithinRoot(filepath.Join(root, "missing.jpg"), root)
+	assert.Error(t, err)
+}
+
+func TestIsPublishFailureToast(t *testing.T) {
+	assert.True(t, isPublishFailureToast("发布失败，请重试"))
+	assert.True(t, isPublishFailureToast("操作太频繁，请稍后再试"))
+	assert.True(t, isPublishFailureToast("标题不能为空"))
+	assert.False(t, isPublishFailureToast(""))
+	assert.False(t, isPublishFailureToast("保存中"))
+	assert.False(t, isPublishFailureToast("图片上传中，请稍候"))
+}
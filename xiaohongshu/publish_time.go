@@ -0,0 +1,102 @@
+package xiaohongshu
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cornerTagPublishTime 笔记卡片角标中表示发布时间的类型
+const cornerTagPublishTime = "publish_time"
+
+// siteLocation 站点展示时间使用的时区（北京时间）
+var siteLocation = time.FixedZone("CST", 8*60*60)
+
+// CornerTag 笔记卡片上的角标信息，搜索结果中发布时间以 "3天前" 等文本形式放在角标中
+type CornerTag struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+var (
+	relativeAgoPattern = regexp.MustCompile(`^(\d+)\s*(秒|分钟|小时|天|周)前$`)
+	dayTimePattern     = regexp.MustCompile(`^(今天|昨天|前天)\s*(\d{1,2}):(\d{2})$`)
+	monthDayPattern    = regexp.MustCompile(`^(\d{1,2})-(\d{1,2})$`)
+	fullDatePattern    = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+)
+
+// publishedAt 返回卡片的发布时间：优先使用毫秒时间戳，其次解析角标中的发布时间文本，都没有时返回零值
+func (c NoteCard) publishedAt(now time.Time) time.Time {
+	if c.Time > 0 {
+		return time.UnixMilli(c.Time)
+	}
+	if t, ok := ParsePublishTime(c.publishTimeText(), now); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+// publishTimeText 返回角标中的发布时间文本
+func (c NoteCard) publishTimeText() string {
+	for _, tag := range c.CornerTagInfo {
+		if tag.Type == cornerTagPublishTime {
+			return strings.TrimSpace(tag.Text)
+		}
+	}
+	return ""
+}
+
+// ParsePublishTime 将站点展示的发布时间转换为绝对时间，支持
+// "刚刚"、"N秒/分钟/小时/天/周前"、"今天/昨天/前天 HH:MM"、"MM-DD"（今年，晚于当前时间时为去年）和 "YYYY-MM-DD"。
+// 相对时间按 now 计算，精度与站点展示一致；无法识别时返回 false。
+func ParsePublishTime(text string, now time.Time) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}, false
+	}
+	now = now.In(siteLocation)
+
+	if text == "刚刚" {
+		return now, true
+	}
+
+	if m := relativeAgoPattern.FindStringSubmatch(text); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := map[string]time.Duration{
+			"秒":  time.Second,
+			"分钟": time.Minute,
+			"小时": time.Hour,
+			"天":  24 * time.Hour,
+			"周":  7 * 24 * time.Hour,
+		}[m[2]]
+		return now.Add(-time.Duration(n) * unit), true
+	}
+
+	if m := dayTimePattern.FindStringSubmatch(text); m != nil {
+		days := map[string]int{"今天": 0, "昨天": 1, "前天": 2}[m[1]]
+		hour, _ := strconv.Atoi(m[2])
+		minute, _ := strconv.Atoi(m[3])
+		day := now.AddDate(0, 0, -days)
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, siteLocation), true
+	}
+
+	if m := fullDatePattern.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, siteLocation), true
+	}
+
+	if m := monthDayPattern.FindStringSubmatch(text); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		t := time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, siteLocation)
+		if t.After(now) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,68 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublishTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, siteLocation)
+
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"刚刚", now},
+		{"5分钟前", now.Add(-5 * time.Minute)},
+		{"2小时前", now.Add(-2 * time.Hour)},
+		{"3天前", now.Add(-3 * 24 * time.Hour)},
+		{"1周前", now.Add(-7 * 24 * time.Hour)},
+		{"今天 09:05", time.Date(2024, 3, 10, 9, 5, 0, 0, siteLocation)},
+		{"昨天 23:10", time.Date(2024, 3, 9, 23, 10, 0, 0, siteLocation)},
+		{"前天 08:00", time.Date(2024, 3, 8, 8, 0, 0, 0, siteLocation)},
+		{"03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, siteLocation)},
+		{"12-25", time.Date(2023, 12, 25, 0, 0, 0, 0, siteLocation)},
+		{"2022-06-18", time.Date(2022, 6, 18, 0, 0, 0, 0, siteLocation)},
+	}
+	for _, tt := range tests {
+		got, ok := ParsePublishTime(tt.text, now)
+		require.True(t, ok, tt.text)
+		assert.True(t, tt.want.Equal(got), "%s: want %v, got %v", tt.text, tt.want, got)
+	}
+
+	for _, text := range []string{"", "很久以前", "编辑于 3天前"} {
+		_, ok := ParsePublishTime(text, now)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestNoteCardPublishedAt(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, siteLocation)
+
+	card := NoteCard{Time: 1700000000000, CornerTagInfo: []CornerTag{{Type: cornerTagPublishTime, Text: "3天前"}}}
+	assert.True(t, time.UnixMilli(1700000000000).Equal(card.publishedAt(now)))
+
+	card = NoteCard{CornerTagInfo: []CornerTag{{Type: "ad", Text: "广告"}, {Type: cornerTagPublishTime, Text: "3天前"}}}
+	assert.True(t, now.Add(-3*24*time.Hour).Equal(card.publishedAt(now)))
+
+	assert.True(t, NoteCard{}.publishedAt(now).IsZero())
+}
+
+func TestFeedPublishedAtJSON(t *testing.T) {
+	feeds := normalizeFeeds([]Feed{
+		{ID: "a", NoteCard: NoteCard{Time: 1700000000000}},
+		{ID: "b"},
+	})
+
+	data, err := json.Marshal(feeds[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"publishedAt"`)
+
+	data, err = json.Marshal(feeds[1])
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"publishedAt"`)
+}
@@ -3,13 +3,14 @@ package xiaohongshu
 import (
 	"context"
 	"log/slog"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
 )
 
 // PublishVideoContent 发布视频内容
@@ -18,24 +19,26 @@ type PublishVideoContent struct {
 	Content   string
 	Tags      []string
 	VideoPath string
+
+	// MusicQuery 可选，配乐搜索关键词，选用第一首名称或歌手包含该关键词的曲目；
+	// 发布页没有配乐入口或没有匹配的曲目时不添加配乐，继续发布
+	MusicQuery string
 }
 
 // NewPublishVideoAction 进入发布页并切换到“上传视频”
 func NewPublishVideoAction(page *rod.Page) (*PublishAction, error) {
 	pp := page.Timeout(90 * time.Second)
 
-	pp.MustNavigate(urlOfPublic)
+	pp.MustNavigate(configs.GetPublishURL())
 
 	if err := waitPublishEditorReady(pp); err != nil {
 		return nil, err
 	}
 
-	if err := clickPublishTab(pp, "上传视频"); err != nil {
+	if err := clickPublishTab(pp, selectors.Get(selectors.VideoTabLabel)); err != nil {
 		return nil, err
 	}
 
-	time.Sleep(1 * time.Second)
-
 	return &PublishAction{page: pp}, nil
 }
 
@@ -51,50 +54,224 @@ func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoCo
 		return errors.Wrap(err, "小红书上传视频失败")
 	}
 
+	if query := strings.TrimSpace(content.MusicQuery); query != "" {
+		if err := selectMusic(page, query); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			slog.Warn("未能添加背景音乐，不带配乐继续发布", "query", query, "error", err)
+		}
+	}
+
 	if err := submitPublishVideo(page, content.Title, content.Content, content.Tags); err != nil {
 		return errors.Wrap(err, "小红书发布失败")
 	}
 	return nil
 }
 
-// uploadVideo 上传单个本地视频
-func uploadVideo(page *rod.Page, videoPath string) error {
-	pp := page.Timeout(5 * time.Minute)
+// ErrVideoUploadFailed 上传界面提示视频上传失败（如网络中断）
+var ErrVideoUploadFailed = errors.New("video upload failed")
+
+// videoUploadFailureKeywords 上传界面中表示上传失败的提示
+var videoUploadFailureKeywords = []string{"上传失败", "网络异常", "网络错误", "上传出错", "上传中断"}
 
-	if _, err := os.Stat(videoPath); os.IsNotExist(err) {
-		return errors.Wrapf(err, "视频文件不存在: %s", videoPath)
+// videoUploadAttemptTimeout 单次上传视频（选择文件到发布按钮可点击）的超时时间，每次重试重新计时
+const videoUploadAttemptTimeout = 5 * time.Minute
+
+// videoUploadRetryDelay 上传失败后重新选择文件前的等待时间
+const videoUploadRetryDelay = 2 * time.Second
+
+// uploadPanelTextExpr 读取当前可见的上传面板的文本，用于判断是否出现上传失败提示；
+// 只读可见面板，避免把隐藏的图文面板或页面其他位置的提示误判为视频上传失败
+const uploadPanelTextExpr = `(panelSelector) => {
+	const texts = [];
+	for (const el of document.querySelectorAll(panelSelector)) {
+		if (el.offsetParent === null) continue;
+		texts.push(el.innerText || '');
+	}
+	return texts.join('\n');
+}`
+
+// uploadVideo 上传单个本地视频。
+// 上传界面提示失败时重新选择文件上传，最多重试 configs.GetVideoUploadRetries() 次，
+// 避免在已经失败的上传上等满整个超时时间。每次尝试单独计时，且不超过 page 的 context。
+func uploadVideo(page *rod.Page, videoPath string) error {
+	if err := CheckUploadPath(videoPath); err != nil {
+		return err
+	}
+	if err := ValidateVideoFile(videoPath); err != nil {
+		return err
 	}
 
-	fileInput, err := pp.Element(".upload-input")
-	if err != nil || fileInput == nil {
-		fileInput, err = pp.Element("input[type='file']")
-		if err != nil || fileInput == nil {
-			return errors.New("未找到视频上传输入框")
+	ctx := page.GetContext()
+	tracker := newUploadProgressTracker(progressFromContext(ctx))
+	retries := configs.GetVideoUploadRetries()
+
+	for attempt := 0; ; attempt++ {
+		slog.Info("开始上传视频", "attempt", attempt+1, "max_attempts", retries+1)
+
+		err := uploadVideoAttempt(page, videoPath, tracker)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrVideoUploadFailed) || attempt >= retries {
+			return err
+		}
+
+		slog.Warn("视频上传失败，重新上传", "attempt", attempt+1, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(videoUploadRetryDelay):
 		}
 	}
+}
+
+// uploadVideoAttempt 选择视频文件并等待上传完成，最多等待 videoUploadAttemptTimeout
+func uploadVideoAttempt(page *rod.Page, videoPath string, tracker *uploadProgressTracker) error {
+	ctx, cancel := context.WithTimeout(page.GetContext(), videoUploadAttemptTimeout)
+	defer cancel()
+	pp := page.Context(ctx)
 
+	fileInput, err := findVideoUploadInput(pp)
+	if err != nil {
+		return err
+	}
 	if err := fileInput.SetFiles([]string{videoPath}); err != nil {
 		return errors.Wrap(err, "视频文件选择失败")
 	}
 
-	btn, err := waitForPublishButtonClickable(pp)
+	btn, err := waitForPublishButtonClickable(pp, func(page *rod.Page) error {
+		tracker.poll(page)
+		return checkVideoUploadFailed(page)
+	})
 	if err != nil {
 		return err
 	}
+	tracker.report(100)
 	slog.Info("视频上传/处理完成，发布按钮可点击", "button", btn)
 	return nil
 }
 
-// waitForPublishButtonClickable 等待发布按钮可点击
-func waitForPublishButtonClickable(page *rod.Page) (*rod.Element, error) {
+// checkVideoUploadFailed 可见的上传面板中出现失败提示时返回 ErrVideoUploadFailed
+func checkVideoUploadFailed(page *rod.Page) error {
+	res, err := page.Evaluate(&rod.EvalOptions{
+		JS:      uploadPanelTextExpr,
+		JSArgs:  []interface{}{selectors.Get(selectors.UploadContent)},
+		ByValue: true,
+	})
+	if err != nil || res == nil {
+		return nil
+	}
+	if keyword, ok := matchVideoUploadFailure(res.Value.Str()); ok {
+		return errors.Wrapf(ErrVideoUploadFailed, "上传界面提示: %s", keyword)
+	}
+	return nil
+}
+
+// matchVideoUploadFailure 判断上传区域文本是否包含上传失败提示，返回命中的提示
+func matchVideoUploadFailure(text string) (string, bool) {
+	for _, keyword := range videoUploadFailureKeywords {
+		if strings.Contains(text, keyword) {
+			return keyword, true
+		}
+	}
+	return "", false
+}
+
+// findVideoUploadInput 在当前可见的上传面板内查找接受视频文件的上传输入框。
+// 切换 TAB 后页面可能同时残留图文和视频两个输入框，不能直接取页面上第一个 input[type='file']。
+func findVideoUploadInput(page *rod.Page) (*rod.Element, error) {
+	panels, err := page.Elements(selectors.Get(selectors.UploadContent))
+	if err != nil {
+		return nil, errors.Wrap(err, "未找到上传面板")
+	}
+
+	for _, panel := range panels {
+		if !isElementVisible(panel) {
+			continue
+		}
+
+		inputs, err := panel.Elements("input[type='file']")
+		if err != nil {
+			continue
+		}
+
+		var candidates []*rod.Element
+		for _, input := range inputs {
+			accept, _ := input.Attribute("accept")
+			if accept == nil {
+				candidates = append(candidates, input)
+				continue
+			}
+			if acceptsVideo(*accept) {
+				return input, nil
+			}
+			if !acceptsImageOnly(*accept) {
+				candidates = append(candidates, input)
+			}
+		}
+
+		// 没有声明 accept 的输入框只有一个时，认为它就是视频面板的上传框
+		if len(candidates) == 1 {
+			return candidates[0], nil
+		}
+	}
+
+	return nil, errors.New("未找到视频上传输入框")
+}
+
+// acceptsVideo 判断 input 的 accept 属性是否接受视频文件
+func acceptsVideo(accept string) bool {
+	for _, part := range strings.Split(strings.ToLower(accept), ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "video/") {
+			return true
+		}
+		switch part {
+		case ".mp4", ".mov", ".flv", ".f4v", ".mkv", ".rm", ".rmvb", ".m4v", ".mpg", ".mpeg", ".ts":
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsImageOnly 判断 input 的 accept 属性是否只接受图片文件
+func acceptsImageOnly(accept string) bool {
+	parts := strings.Split(strings.ToLower(accept), ",")
+	n := 0
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n++
+		if !strings.HasPrefix(part, "image/") {
+			switch part {
+			case ".jpg", ".jpeg", ".png", ".webp", ".gif", ".heic", ".heif":
+			default:
+				return false
+			}
+		}
+	}
+	return n > 0
+}
+
+// waitForPublishButtonClickable 等待发布按钮可点击，onPoll 非空时在每次轮询时调用，返回错误时停止等待
+func waitForPublishButtonClickable(page *rod.Page, onPoll func(page *rod.Page) error) (*rod.Element, error) {
 	maxWait := 10 * time.Minute
 	interval := 1 * time.Second
 	start := time.Now()
-	selector := "button.publishBtn"
+	selector := selectors.Get(selectors.VideoSubmit)
 
 	slog.Info("开始等待发布按钮可点击(视频)")
 
 	for time.Since(start) < maxWait {
+		if onPoll != nil {
+			if err := onPoll(page); err != nil {
+				return nil, err
+			}
+		}
 		btn, err := page.Element(selector)
 		if err == nil && btn != nil {
 			vis, verr := btn.Visible()
@@ -114,7 +291,7 @@ func waitForPublishButtonClickable(page *rod.Page) (*rod.Element, error) {
 
 // submitPublishVideo 填写标题、正文、标签并点击发布
 func submitPublishVideo(page *rod.Page, title, content string, tags []string) error {
-	titleElem, err := page.Element("div.d-input input")
+	titleElem, err := page.Element(selectors.Get(selectors.TitleInput))
 	if err != nil {
 		return errors.Wrap(err, "未找到标题输入框")
 	}
@@ -137,7 +314,7 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string) er
 
 	time.Sleep(1 * time.Second)
 
-	btn, err := waitForPublishButtonClickable(page)
+	btn, err := waitForPublishButtonClickable(page, nil)
 	if err != nil {
 		return err
 	}
@@ -146,6 +323,5 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string) er
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
 
-	time.Sleep(3 * time.Second)
-	return nil
+	return waitForPublishResult(page)
 }
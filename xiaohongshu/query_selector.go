@@ -0,0 +1,121 @@
+package xiaohongshu
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+)
+
+// ErrURLNotAllowed 只允许访问小红书域名下的 https 地址
+var ErrURLNotAllowed = errors.New("only https xiaohongshu.com urls are allowed")
+
+const (
+	// queryWaitTimeout 页面加载后等待选择器出现的最长时间
+	queryWaitTimeout = 5 * time.Second
+	// maxQueryTextRunes 返回元素文本的最大长度
+	maxQueryTextRunes = 2000
+)
+
+// QueryResult 在页面上查询选择器的结果
+type QueryResult struct {
+	URL      string `json:"url"` // 导航后的实际地址，可能被重定向
+	Selector string `json:"selector"`
+	Found    bool   `json:"found"`
+	Count    int    `json:"count"`              // 匹配的元素数量
+	Text     string `json:"text,omitempty"`     // 第一个匹配元素的文本
+	Visible  bool   `json:"visible"`            // 第一个匹配元素是否可见
+	HTMLTag  string `json:"html_tag,omitempty"` // 第一个匹配元素的标签名
+}
+
+type QuerySelectorAction struct {
+	page *rod.Page
+}
+
+func NewQuerySelector(page *rod.Page) *QuerySelectorAction {
+	return &QuerySelectorAction{page: page}
+}
+
+// Query 打开小红书页面并查询 CSS 选择器，供排查选择器失效时使用。
+// 元素最多等待 queryWaitTimeout，不存在时返回 Found 为 false 而不是错误。
+func (q *QuerySelectorAction) Query(ctx context.Context, rawURL, selector string) (*QueryResult, error) {
+	if err := ValidateQueryURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	page := q.page.Context(ctx)
+	if err := page.Navigate(rawURL); err != nil {
+		return nil, errors.Wrap(err, "navigate failed")
+	}
+	if err := page.WaitLoad(); err != nil {
+		return nil, errors.Wrap(err, "wait page load failed")
+	}
+
+	result := &QueryResult{URL: rawURL, Selector: selector}
+	if info, err := page.Info(); err == nil && info != nil {
+		result.URL = info.URL
+	}
+
+	// 等待元素出现；超时说明选择器未命中
+	el, err := page.Timeout(queryWaitTimeout).Element(selector)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return result, nil
+	}
+
+	elements, err := page.Elements(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "query elements failed")
+	}
+	result.Found = true
+	result.Count = len(elements)
+
+	if text, err := el.Text(); err == nil {
+		result.Text = truncateRunes(strings.TrimSpace(text), maxQueryTextRunes)
+	}
+	if visible, err := el.Visible(); err == nil {
+		result.Visible = visible
+	}
+	if tag, err := el.Eval(`() => this.tagName.toLowerCase()`); err == nil {
+		result.HTMLTag = tag.Value.Str()
+	}
+
+	return result, nil
+}
+
+// ValidateQueryURL 检查地址是否允许查询，不是小红书 https 地址时返回 ErrURLNotAllowed
+func ValidateQueryURL(raw string) error {
+	if !isXiaohongshuURL(raw) {
+		return errors.Wrapf(ErrURLNotAllowed, "url %q", raw)
+	}
+	return nil
+}
+
+// isXiaohongshuURL 判断是否为 xiaohongshu.com 或其子域名下的 https 地址
+func isXiaohongshuURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	return isXiaohongshuHost(u.Hostname())
+}
+
+// isXiaohongshuHost 判断主机名是否为 xiaohongshu.com 或其子域名，不接受 evilxiaohongshu.com 之类的地址
+func isXiaohongshuHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "xiaohongshu.com" || strings.HasSuffix(host, ".xiaohongshu.com")
+}
+
+// truncateRunes 把 s 截断到最多 n 个字符
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,24 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsXiaohongshuURL(t *testing.T) {
+	assert.True(t, isXiaohongshuURL("https://www.xiaohongshu.com/explore"))
+	assert.True(t, isXiaohongshuURL("https://creator.xiaohongshu.com/publish/publish"))
+	assert.True(t, isXiaohongshuURL("https://xiaohongshu.com/"))
+
+	assert.False(t, isXiaohongshuURL("http://www.xiaohongshu.com/explore"))
+	assert.False(t, isXiaohongshuURL("https://evilxiaohongshu.com/"))
+	assert.False(t, isXiaohongshuURL("https://www.xiaohongshu.com.example.com/"))
+	assert.False(t, isXiaohongshuURL("file:///etc/passwd"))
+	assert.False(t, isXiaohongshuURL("::"))
+}
+
+func TestTruncateRunes(t *testing.T) {
+	assert.Equal(t, "小红", truncateRunes("小红书", 2))
+	assert.Equal(t, "abc", truncateRunes("abc", 5))
+}
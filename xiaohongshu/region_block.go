@@ -0,0 +1,81 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrRegionBlocked 笔记或用户在当前网络所在地区不可见。与临时失败不同，重试不会成功，需要更换代理。
+// 可用 errors.Is 判断，errors.As 到 *RegionBlockedError 获取页面提示
+var ErrRegionBlocked = errors.New("content is not available in the current region")
+
+// 被地区限制的内容类型
+const (
+	RegionBlockedFeed = "feed"
+	RegionBlockedUser = "user"
+)
+
+// RegionBlockedError 地区限制的详细信息
+type RegionBlockedError struct {
+	Target  string // feed 或 user
+	ID      string // 笔记 ID 或用户 ID
+	Message string // 页面展示的提示
+}
+
+func (e *RegionBlockedError) Error() string {
+	return fmt.Sprintf("%s %s: %v: %s", e.Target, e.ID, ErrRegionBlocked, e.Message)
+}
+
+func (e *RegionBlockedError) Is(target error) bool {
+	return target == ErrRegionBlocked
+}
+
+// regionBlockKeywords 页面提示内容受地区限制时包含的文本
+var regionBlockKeywords = []string{
+	"地区限制",
+	"当前地区",
+	"所在地区",
+	"所在的地区",
+	"该地区",
+	"地区不可见",
+	"地区暂不支持",
+	"not available in your region",
+	"not available in your country",
+}
+
+// regionBlockMessage 从页面文本中找出地区限制的提示，返回包含关键词的那一行，没有时返回空字符串
+func regionBlockMessage(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+		for _, kw := range regionBlockKeywords {
+			if strings.Contains(lower, kw) {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// checkRegionBlocked 页面提示内容受地区限制时返回 *RegionBlockedError，并记录被限制的笔记或用户
+func checkRegionBlocked(page *rod.Page, target, id string) error {
+	res, err := page.Evaluate(&rod.EvalOptions{
+		JS:      `() => (document.body ? document.body.innerText.slice(0, 2000) : "")`,
+		ByValue: true,
+	})
+	if err != nil || res == nil {
+		return nil
+	}
+
+	msg := regionBlockMessage(res.Value.Str())
+	if msg == "" {
+		return nil
+	}
+
+	logrus.Warnf("%s %s 受地区限制: %s", target, id, msg)
+	return &RegionBlockedError{Target: target, ID: id, Message: msg}
+}
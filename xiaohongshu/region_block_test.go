@@ -0,0 +1,42 @@
+package xiaohongshu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionBlockMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"chinese notice", "小红书\n 抱歉，该内容在你所在的地区暂不可见 \n返回首页", "抱歉，该内容在你所在的地区暂不可见"},
+		{"region limit", "由于地区限制，无法查看该用户", "由于地区限制，无法查看该用户"},
+		{"english notice", "This content is Not Available in Your Region", "This content is Not Available in Your Region"},
+		{"deleted note", "笔记不存在\n返回首页", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, regionBlockMessage(tt.text))
+		})
+	}
+}
+
+func TestRegionBlockedError(t *testing.T) {
+	var err error = &RegionBlockedError{Target: RegionBlockedFeed, ID: "65f1a2b3", Message: "该内容在你所在的地区暂不可见"}
+
+	assert.True(t, errors.Is(err, ErrRegionBlocked))
+	assert.False(t, errors.Is(err, ErrStaleXsecToken))
+	assert.Contains(t, err.Error(), ErrRegionBlocked.Error())
+	assert.Contains(t, err.Error(), "65f1a2b3")
+	assert.Contains(t, err.Error(), "该内容在你所在的地区暂不可见")
+
+	var blocked *RegionBlockedError
+	assert.True(t, errors.As(err, &blocked))
+	assert.Equal(t, RegionBlockedFeed, blocked.Target)
+}
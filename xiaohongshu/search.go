@@ -3,12 +3,17 @@ package xiaohongshu
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
 )
 
 type SearchResult struct {
@@ -17,6 +22,35 @@ type SearchResult struct {
 	} `json:"search"`
 }
 
+// searchEmptySettle 结果容器已渲染但没有笔记的状态需持续的时间，避免把加载中误判为无结果
+const searchEmptySettle = 3 * time.Second
+
+// searchReadyExpr 搜索结果有数据时就绪；结果容器已渲染但没有笔记、且 search.feeds 为空数组并持续
+// searchEmptySettle 时也视为就绪（关键词没有搜索结果），不必等到超时
+func searchReadyExpr() string {
+	return fmt.Sprintf(`() => {
+	const state = window.__INITIAL_STATE__;
+	const feeds = state && state.search && state.search.feeds && state.search.feeds._value;
+	if (feeds && feeds.length > 0) {
+		window.__xhsSearchEmptySince = 0;
+		return true;
+	}
+
+	const container = document.querySelector(%q);
+	if (!Array.isArray(feeds) || !container || container.querySelector(%q)) {
+		window.__xhsSearchEmptySince = 0;
+		return false;
+	}
+	if (!window.__xhsSearchEmptySince) {
+		window.__xhsSearchEmptySince = Date.now();
+	}
+	return Date.now() - window.__xhsSearchEmptySince >= %d;
+}`, selectors.Get(selectors.SearchResults), selectors.Get(selectors.FeedCard), searchEmptySettle.Milliseconds())
+}
+
+// ErrFilterUIChanged 表示搜索筛选面板结构与预期不符（通常是页面改版）
+var ErrFilterUIChanged = errors.New("search filter UI changed")
+
 type SearchAction struct {
 	page *rod.Page
 }
@@ -37,10 +71,6 @@ const (
 	SortMostComments  = "most_comments"
 	SortMostFavorites = "most_favorites"
 
-	NoteTypeAll   = "all"
-	NoteTypeVideo = "video"
-	NoteTypeImage = "image"
-
 	PublishAll    = "all"
 	PublishDay    = "day"
 	PublishWeek   = "week"
@@ -65,9 +95,9 @@ var sortOptionLabels = map[string]string{
 }
 
 var noteTypeLabels = map[string]string{
-	NoteTypeAll:   "不限",
-	NoteTypeVideo: "视频",
-	NoteTypeImage: "图文",
+	string(NoteTypeAll):   "不限",
+	string(NoteTypeVideo): "视频",
+	string(NoteTypeImage): "图文",
 }
 
 var publishTimeLabels = map[string]string{
@@ -90,13 +120,46 @@ var distanceLabels = map[string]string{
 	DistanceNearby:   "附近",
 }
 
+// FilterError 搜索筛选项校验失败，Fields 为字段名到错误信息的映射，包含所有不合法的字段
+type FilterError struct {
+	Fields map[string]string
+}
+
+func (e *FilterError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	msgs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		msgs = append(msgs, field+": "+e.Fields[field])
+	}
+	return "invalid search filters: " + strings.Join(msgs, "; ")
+}
+
+func (e *FilterError) check(field, value string, labels map[string]string) {
+	if _, ok := labels[value]; ok {
+		return
+	}
+
+	options := make([]string, 0, len(labels))
+	for option := range labels {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	e.Fields[field] = fmt.Sprintf("invalid option %q, expected one of %s", value, strings.Join(options, ", "))
+}
+
 // NewSearchFilters 构建筛选器，若值为空则回退到默认
 func NewSearchFilters(sort, noteType, publishTime, searchScope, distance string) (*SearchFilters, error) {
 	if sort == "" {
 		sort = SortDefault
 	}
 	if noteType == "" {
-		noteType = NoteTypeAll
+		noteType = string(NoteTypeAll)
 	}
 	if publishTime == "" {
 		publishTime = PublishAll
@@ -108,20 +171,15 @@ func NewSearchFilters(sort, noteType, publishTime, searchScope, distance string)
 		distance = DistanceAll
 	}
 
-	if _, ok := sortOptionLabels[sort]; !ok {
-		return nil, fmt.Errorf("invalid sort option: %s", sort)
-	}
-	if _, ok := noteTypeLabels[noteType]; !ok {
-		return nil, fmt.Errorf("invalid note_type option: %s", noteType)
-	}
-	if _, ok := publishTimeLabels[publishTime]; !ok {
-		return nil, fmt.Errorf("invalid publish_time option: %s", publishTime)
-	}
-	if _, ok := searchScopeLabels[searchScope]; !ok {
-		return nil, fmt.Errorf("invalid search_scope option: %s", searchScope)
-	}
-	if _, ok := distanceLabels[distance]; !ok {
-		return nil, fmt.Errorf("invalid distance option: %s", distance)
+	// 一次性校验所有筛选项，便于调用方一次修正全部错误
+	filterErr := &FilterError{Fields: map[string]string{}}
+	filterErr.check("sort", sort, sortOptionLabels)
+	filterErr.check("note_type", noteType, noteTypeLabels)
+	filterErr.check("publish_time", publishTime, publishTimeLabels)
+	filterErr.check("search_scope", searchScope, searchScopeLabels)
+	filterErr.check("distance", distance, distanceLabels)
+	if len(filterErr.Fields) > 0 {
+		return nil, filterErr
 	}
 
 	return &SearchFilters{
@@ -133,12 +191,20 @@ func NewSearchFilters(sort, noteType, publishTime, searchScope, distance string)
 	}, nil
 }
 
+// filterFeeds 页面筛选之外再按解析后的类型过滤一次，保证与其他列表接口的筛选结果一致
+func (f *SearchFilters) filterFeeds(feeds []Feed) []Feed {
+	if f == nil {
+		return feeds
+	}
+	return FilterFeedsByNoteType(feeds, NoteType(f.NoteType))
+}
+
 func (f *SearchFilters) isDefault() bool {
 	if f == nil {
 		return true
 	}
 	return f.Sort == SortDefault &&
-		f.NoteType == NoteTypeAll &&
+		f.NoteType == string(NoteTypeAll) &&
 		f.PublishTime == PublishAll &&
 		f.SearchScope == ScopeAll &&
 		f.Distance == DistanceAll
@@ -150,59 +216,48 @@ func NewSearchAction(page *rod.Page) *SearchAction {
 	return &SearchAction{page: pp}
 }
 
+// Search 搜索笔记并返回首屏结果，关键词没有搜索结果时返回空列表而不是错误
 func (s *SearchAction) Search(ctx context.Context, keyword string, filters *SearchFilters) ([]Feed, error) {
-	page := s.page.Context(ctx)
-
-	searchURL := makeSearchURL(keyword)
-	if err := page.Navigate(searchURL); err != nil {
-		return nil, err
-	}
-
-	if err := waitForInitialState(page, `() => {
-		const state = window.__INITIAL_STATE__;
-		return !!(
-			state &&
-			state.search &&
-			state.search.feeds &&
-			state.search.feeds._value &&
-			state.search.feeds._value.length > 0
-		);
-	}`, 30*time.Second); err != nil {
-		return nil, err
-	}
+	_, feeds, err := s.open(ctx, keyword, filters)
+	return feeds, err
+}
 
-	if filters != nil && !filters.isDefault() {
-		if err := applySearchFilters(page, filters); err != nil {
-			return nil, err
-		}
-	}
+// open 打开搜索结果页并应用筛选条件，返回页面和首屏结果
+func (s *SearchAction) open(ctx context.Context, keyword string, filters *SearchFilters) (*rod.Page, []Feed, error) {
+	page := s.page.Context(ctx)
 
-	// 获取 window.__INITIAL_STATE__ 并转换为 JSON 字符串
-	result, err := page.Evaluate(&rod.EvalOptions{JS: `() => {
-		if (window.__INITIAL_STATE__) {
-			return JSON.stringify(window.__INITIAL_STATE__);
-		}
-		return "";
-	}`, ByValue: true})
+	// 刷新页面重试后需重新应用筛选条件
+	str, err := navigateAndWaitState(ctx, page, configs.WaitActionSearch, makeSearchURL(keyword), searchReadyExpr(), 30*time.Second,
+		prepareState(func() error {
+			if filters == nil || filters.isDefault() {
+				return nil
+			}
+			return applySearchFilters(page, filters)
+		}))
 	if err != nil {
-		return nil, err
-	}
-	if result == nil {
-		return nil, fmt.Errorf("failed to evaluate search initial state")
+		return nil, nil, err
 	}
 
-	str := result.Value.Str()
-
-	if str == "" {
-		return nil, fmt.Errorf("__INITIAL_STATE__ not found")
+	feeds, err := parseSearchFeeds(str)
+	if err != nil {
+		return nil, nil, err
 	}
+	return page, filters.filterFeeds(feeds), nil
+}
 
+// parseSearchFeeds 从搜索结果页的 __INITIAL_STATE__ JSON 中解析 search.feeds._value
+func parseSearchFeeds(jsonStr string) ([]Feed, error) {
 	var searchResult SearchResult
-	if err := json.Unmarshal([]byte(str), &searchResult); err != nil {
+	if err := json.Unmarshal([]byte(jsonStr), &searchResult); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal __INITIAL_STATE__: %w", err)
 	}
 
-	return searchResult.Search.Feeds.Value, nil
+	// 关键词没有搜索结果时 _value 为空数组或缺失，返回空列表
+	feeds := searchResult.Search.Feeds.Value
+	if feeds == nil {
+		feeds = []Feed{}
+	}
+	return normalizeFeeds(feeds), nil
 }
 
 func makeSearchURL(keyword string) string {
@@ -215,9 +270,20 @@ func makeSearchURL(keyword string) string {
 }
 
 func applySearchFilters(page *rod.Page, filters *SearchFilters) error {
-	filterBtn := page.MustElement(`div.filter`)
-	filterBtn.MustHover()
-	panel := page.MustElement(`div.filter-panel`).MustWaitVisible()
+	filterBtn, err := page.Element(selectors.Get(selectors.SearchFilterButton))
+	if err != nil {
+		return fmt.Errorf("%w: 未找到筛选按钮: %v", ErrFilterUIChanged, err)
+	}
+	if err := filterBtn.Hover(); err != nil {
+		return fmt.Errorf("%w: 悬停筛选按钮失败: %v", ErrFilterUIChanged, err)
+	}
+	panel, err := page.Element(selectors.Get(selectors.SearchFilterPanel))
+	if err != nil {
+		return fmt.Errorf("%w: 未找到筛选面板: %v", ErrFilterUIChanged, err)
+	}
+	if err := panel.WaitVisible(); err != nil {
+		return fmt.Errorf("%w: 筛选面板不可见: %v", ErrFilterUIChanged, err)
+	}
 
 	if filters.Sort != SortDefault {
 		if err := clickFilterTag(panel, `.filters-wrapper > div:nth-child(1) .tags`, sortOptionLabels[filters.Sort]); err != nil {
@@ -225,7 +291,7 @@ func applySearchFilters(page *rod.Page, filters *SearchFilters) error {
 		}
 	}
 
-	if filters.NoteType != NoteTypeAll {
+	if filters.NoteType != string(NoteTypeAll) {
 		if err := clickFilterTag(panel, `.filters-wrapper > div:nth-child(2) .tags`, noteTypeLabels[filters.NoteType]); err != nil {
 			return err
 		}
@@ -249,37 +315,42 @@ func applySearchFilters(page *rod.Page, filters *SearchFilters) error {
 		}
 	}
 
-	panel.MustElement(`.operation-container .operation:nth-child(2)`).MustClick()
+	confirmBtn, err := panel.Element(`.operation-container .operation:nth-child(2)`)
+	if err != nil {
+		return fmt.Errorf("%w: 未找到筛选确认按钮: %v", ErrFilterUIChanged, err)
+	}
+	if err := confirmBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("%w: 点击筛选确认按钮失败: %v", ErrFilterUIChanged, err)
+	}
 	time.Sleep(500 * time.Millisecond)
-	return waitForInitialState(page, `() => {
-		const state = window.__INITIAL_STATE__;
-		return !!(
-			state &&
-			state.search &&
-			state.search.feeds &&
-			state.search.feeds._value &&
-			state.search.feeds._value.length > 0
-		);
-	}`, 30*time.Second)
+	return waitForInitialState(page, searchReadyExpr(), 30*time.Second)
 }
 
 func clickFilterTag(panel *rod.Element, selector, target string) error {
-	tags := panel.MustElements(selector)
+	tags, err := panel.Elements(selector)
+	if err != nil {
+		return fmt.Errorf("%w: 查找筛选项 %s 失败: %v", ErrFilterUIChanged, target, err)
+	}
 	for _, tag := range tags {
 		textEl, err := tag.Element("span")
 		if err != nil || textEl == nil {
 			continue
 		}
-		text := strings.TrimSpace(textEl.MustText())
-		if text == target {
+		text, err := textEl.Text()
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(text) == target {
 			className, _ := tag.Attribute("class")
 			if className != nil && strings.Contains(*className, "active") {
 				return nil
 			}
-			tag.MustClick()
+			if err := tag.Click(proto.InputMouseButtonLeft, 1); err != nil {
+				return fmt.Errorf("%w: 点击筛选项 %s 失败: %v", ErrFilterUIChanged, target, err)
+			}
 			time.Sleep(200 * time.Millisecond)
 			return nil
 		}
 	}
-	return fmt.Errorf("未找到筛选项 %s", target)
+	return fmt.Errorf("%w: 未找到筛选项 %s", ErrFilterUIChanged, target)
 }
@@ -0,0 +1,96 @@
+package xiaohongshu
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxSearchScrolls 流式搜索最多滚动加载的次数
+	maxSearchScrolls = 30
+	// maxStaleSearchScrolls 连续多少次滚动没有新结果后认为已加载完
+	maxStaleSearchScrolls = 3
+)
+
+const scrollSearchExpr = `() => {
+	window.scrollTo(0, document.body.scrollHeight);
+}`
+
+// SearchStream 搜索并滚动加载更多结果，每加载出一批新结果（按笔记 ID 去重）调用一次 onBatch。
+// 累计达到 limit、连续多次滚动没有新结果或 onBatch 返回错误时停止，onBatch 的错误原样返回。
+func (s *SearchAction) SearchStream(ctx context.Context, keyword string, filters *SearchFilters, limit int, onBatch func([]Feed) error) error {
+	page, feeds, err := s.open(ctx, keyword, filters)
+	if err != nil {
+		return err
+	}
+	if len(feeds) == 0 && filters.isDefault() {
+		// 没有搜索结果，无需滚动加载
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	emit := func(feeds []Feed) error {
+		batch := newSearchFeeds(seen, feeds, limit)
+		if len(batch) == 0 {
+			return nil
+		}
+		return onBatch(batch)
+	}
+
+	if err := emit(feeds); err != nil {
+		return err
+	}
+
+	stale := 0
+	for i := 0; i < maxSearchScrolls && len(seen) < limit; i++ {
+		if err := page.GetContext().Err(); err != nil {
+			return err
+		}
+
+		if _, err := page.Evaluate(&rod.EvalOptions{JS: scrollSearchExpr}); err != nil {
+			return errors.Wrap(err, "scroll search results failed")
+		}
+		time.Sleep(1500 * time.Millisecond)
+
+		str, err := evalInitialState(page)
+		if err != nil {
+			return err
+		}
+		loaded, err := parseSearchFeeds(str)
+		if err != nil {
+			return err
+		}
+
+		prev := len(seen)
+		if err := emit(filters.filterFeeds(loaded)); err != nil {
+			return err
+		}
+		stale = nextStaleScrolls(prev, len(seen), stale)
+		if stale >= maxStaleSearchScrolls {
+			logrus.Infof("搜索结果不再增加，共 %d 条", len(seen))
+			break
+		}
+	}
+
+	return nil
+}
+
+// newSearchFeeds 返回 feeds 中尚未出现过的笔记并记录到 seen，累计数量不超过 limit
+func newSearchFeeds(seen map[string]bool, feeds []Feed, limit int) []Feed {
+	var batch []Feed
+	for _, f := range feeds {
+		if len(seen) >= limit {
+			break
+		}
+		if f.ID == "" || seen[f.ID] {
+			continue
+		}
+		seen[f.ID] = true
+		batch = append(batch, f)
+	}
+	return batch
+}
@@ -2,9 +2,11 @@ package xiaohongshu
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 )
@@ -32,3 +34,63 @@ func TestSearch(t *testing.T) {
 		fmt.Printf("Feed Title: %s\n", feed.NoteCard.DisplayTitle)
 	}
 }
+
+func TestNewSearchFilters(t *testing.T) {
+	filters, err := NewSearchFilters("", "", "", "", "")
+	require.NoError(t, err)
+	assert.True(t, filters.isDefault())
+
+	_, err = NewSearchFilters("hottest", "video", "month", "", "mars")
+
+	var filterErr *FilterError
+	require.ErrorAs(t, err, &filterErr)
+	assert.Len(t, filterErr.Fields, 3)
+	assert.Contains(t, filterErr.Fields, "sort")
+	assert.Contains(t, filterErr.Fields, "publish_time")
+	assert.Contains(t, filterErr.Fields, "distance")
+	assert.Contains(t, filterErr.Fields["distance"], `"mars"`)
+	assert.Contains(t, err.Error(), "distance: ")
+	assert.Contains(t, err.Error(), "sort: ")
+}
+
+func TestNewSearchFeeds(t *testing.T) {
+	seen := make(map[string]bool)
+
+	batch := newSearchFeeds(seen, []Feed{{ID: "a"}, {ID: "b"}, {ID: ""}, {ID: "a"}}, 10)
+	assert.Equal(t, []string{"a", "b"}, feedIDs(batch))
+
+	// 滚动后页面状态包含全部已加载的结果，只返回新增部分
+	batch = newSearchFeeds(seen, []Feed{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}, 3)
+	assert.Equal(t, []string{"c"}, feedIDs(batch))
+
+	assert.Empty(t, newSearchFeeds(seen, []Feed{{ID: "e"}}, 3))
+	assert.Len(t, seen, 3)
+}
+
+func TestParseSearchUsers(t *testing.T) {
+	raw := `[
+		{"id": "5a1b00000000000001000001", "name": " 咖啡品牌官方 ", "red_id": "coffee_official", "fans": "12.3万", "note_count": 256, "xsec_token": "ABuser1=", "sub_title": "品牌号"},
+		{"profileUrl": "https://www.xiaohongshu.com/user/profile/5a1b00000000000001000002?xsec_token=ABuser2%3D&xsec_source=pc_search", "name": "咖啡日记", "info": "咖啡日记\n小红书号：123 | 粉丝・1024"},
+		{"id": "5a1b00000000000001000001", "name": "重复"},
+		{"name": "没有ID"}
+	]`
+	var users []rawSearchUser
+	require.NoError(t, json.Unmarshal([]byte(raw), &users))
+
+	result := parseSearchUsers(users)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, "5a1b00000000000001000001", result[0].UserID)
+	assert.Equal(t, "咖啡品牌官方", result[0].Nickname)
+	assert.Equal(t, "coffee_official", result[0].RedID)
+	assert.Equal(t, "12.3万", result[0].Fans)
+	assert.Equal(t, "256", result[0].NoteCount)
+	assert.Equal(t, "ABuser1=", result[0].XsecToken)
+	assert.Equal(t, "品牌号", result[0].Desc)
+
+	assert.Equal(t, "5a1b00000000000001000002", result[1].UserID)
+	assert.Equal(t, "ABuser2=", result[1].XsecToken)
+	assert.Equal(t, "1024", result[1].Fans)
+
+	assert.Empty(t, parseSearchUsers(nil))
+}
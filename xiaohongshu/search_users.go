@@ -0,0 +1,262 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/selectors"
+)
+
+// ErrUserTabNotFound 搜索结果页没有“用户”标签（通常是页面改版）
+var ErrUserTabNotFound = errors.New("user tab not found on search result page")
+
+// ErrUserResultsNotLoaded 切换到“用户”标签后既没有读到用户列表，也没有出现无结果提示，
+// 无法判断是没有匹配的用户还是页面加载失败
+var ErrUserResultsNotLoaded = errors.New("user search results not loaded")
+
+// userTabLabel 搜索结果页“用户”标签的文本
+const userTabLabel = `^\s*用户\s*$`
+
+// userTabActiveExpr 判断标签是否处于选中状态
+const userTabActiveExpr = `function () {
+	return this.getAttribute("aria-selected") === "true" || /(^|[\s_-])(active|selected)/.test(this.className);
+}`
+
+// UserResult 搜索到的用户
+type UserResult struct {
+	UserID    string `json:"userId"`
+	Nickname  string `json:"nickname"`
+	RedID     string `json:"redId,omitempty"`     // 小红书号
+	Avatar    string `json:"avatar,omitempty"`    // 头像地址
+	Fans      string `json:"fans,omitempty"`      // 粉丝数，页面展示的文本，如 "1.2万"
+	NoteCount string `json:"noteCount,omitempty"` // 笔记数
+	Desc      string `json:"desc,omitempty"`      // 简介或认证信息
+	XsecToken string `json:"xsecToken"`           // 访问用户主页所需的令牌
+	Followed  bool   `json:"followed,omitempty"`  // 当前账号是否已关注
+}
+
+// searchUsersExpr 读取用户搜索结果：优先取 __INITIAL_STATE__ 中的用户列表，否则读取页面上的用户卡片。
+// 返回 {users, empty, container}：empty 表示页面显示了无结果提示，container 表示用户列表容器已出现
+const searchUsersExpr = `(cardSelector, listSelector, emptySelector) => {
+	const unwrap = (v) => (v && v._value !== undefined ? v._value : v);
+	const state = window.__INITIAL_STATE__;
+	const search = state && unwrap(state.search);
+	let users = [];
+	if (search) {
+		for (const key of ["userLists", "userList", "users"]) {
+			let list = unwrap(search[key]);
+			if (list && !Array.isArray(list)) list = unwrap(list.users || list.list || list.items);
+			if (Array.isArray(list) && list.length > 0) {
+				users = list;
+				break;
+			}
+		}
+	}
+
+	if (users.length === 0) {
+		document.querySelectorAll(cardSelector).forEach((el) => {
+			const link = el.querySelector("a[href*='/user/profile/']") || (el.matches("a") ? el : null);
+			if (!link) return;
+			const text = (sel) => {
+				const node = el.querySelector(sel);
+				return node ? node.innerText.trim() : "";
+			};
+			const img = el.querySelector("img");
+			users.push({
+				profileUrl: link.href,
+				name: text(".user-name, .name, .title"),
+				desc: text(".user-desc, .desc, .sub-title"),
+				info: el.innerText,
+				image: img ? img.src : "",
+			});
+		});
+	}
+
+	const visible = (el) => el && el.offsetParent !== null;
+	return JSON.stringify({
+		users,
+		empty: users.length === 0 && Array.from(document.querySelectorAll(emptySelector)).some(visible),
+		container: !!document.querySelector(listSelector),
+	});
+}`
+
+// rawSearchUser 兼容用户搜索数据中不同的字段命名；profileUrl、info 来自页面上的用户卡片
+type rawSearchUser struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"userId"`
+	Name       string          `json:"name"`
+	Nickname   string          `json:"nickname"`
+	RedID      string          `json:"red_id"`
+	RedID2     string          `json:"redId"`
+	Image      string          `json:"image"`
+	Avatar     string          `json:"avatar"`
+	Fans       json.RawMessage `json:"fans"`
+	NoteCount  json.RawMessage `json:"note_count"`
+	NoteCount2 json.RawMessage `json:"noteCount"`
+	SubTitle   string          `json:"sub_title"`
+	Desc       string          `json:"desc"`
+	XsecToken  string          `json:"xsec_token"`
+	XsecToken2 string          `json:"xsecToken"`
+	Followed   bool            `json:"followed"`
+	ProfileURL string          `json:"profileUrl"`
+	Info       string          `json:"info"`
+}
+
+// SearchUsers 按关键词搜索用户（如品牌官方账号），切换到搜索结果页的“用户”标签读取匹配的用户。
+// 只有页面显示无结果提示时才返回空列表；用户列表始终没有加载出来时返回 ErrUserResultsNotLoaded
+func (s *SearchAction) SearchUsers(ctx context.Context, keyword string) ([]UserResult, error) {
+	page := s.page.Context(ctx)
+
+	if err := navigateAndWait(page, configs.WaitActionSearch, makeSearchURL(keyword)); err != nil {
+		return nil, err
+	}
+
+	tab, err := page.Timeout(15*time.Second).ElementR(selectors.Get(selectors.SearchChannel), userTabLabel)
+	if err != nil {
+		return nil, errors.Wrap(ErrUserTabNotFound, err.Error())
+	}
+	if err := tab.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, errors.Wrap(err, "点击用户标签失败")
+	}
+	if err := waitUserTabActive(page, tab); err != nil {
+		return nil, err
+	}
+
+	var found struct {
+		Users     []rawSearchUser `json:"users"`
+		Empty     bool            `json:"empty"`
+		Container bool            `json:"container"`
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		select {
+		case <-page.GetContext().Done():
+			return nil, page.GetContext().Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+
+		res, err := page.Evaluate(&rod.EvalOptions{
+			JS: searchUsersExpr,
+			JSArgs: []interface{}{
+				selectors.Get(selectors.UserCard),
+				selectors.Get(selectors.UserList),
+				selectors.Get(selectors.SearchEmpty),
+			},
+			ByValue: true,
+		})
+		if err == nil && res != nil && json.Unmarshal([]byte(res.Value.Str()), &found) == nil {
+			if len(found.Users) > 0 {
+				break
+			}
+			if found.Empty {
+				logrus.Infof("没有匹配的用户: %s", keyword)
+				return []UserResult{}, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if captcha := detectCaptcha(page); captcha != nil {
+				return nil, captcha
+			}
+			if found.Container {
+				return nil, errors.Wrapf(ErrUserResultsNotLoaded, "用户列表已出现但未读到用户，也没有无结果提示: %s", keyword)
+			}
+			return nil, errors.Wrapf(ErrUserResultsNotLoaded, "等待用户列表超时: %s", keyword)
+		}
+	}
+
+	return parseSearchUsers(found.Users), nil
+}
+
+// waitUserTabActive 等待点击后的“用户”标签变为选中状态，避免读到其他标签下的内容
+func waitUserTabActive(page *rod.Page, tab *rod.Element) error {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, err := tab.Eval(userTabActiveExpr)
+		if err == nil && res.Value.Bool() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Wrap(ErrUserTabNotFound, "点击后“用户”标签未处于选中状态")
+		}
+
+		select {
+		case <-page.GetContext().Done():
+			return page.GetContext().Err()
+		case <-time.After(300 * time.Millisecond):
+		}
+	}
+}
+
+// parseSearchUsers 归一化用户搜索结果，跳过没有用户 ID 的条目，按用户 ID 去重
+func parseSearchUsers(raw []rawSearchUser) []UserResult {
+	users := make([]UserResult, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		u := UserResult{
+			UserID:    firstNonEmpty(r.ID, r.UserID),
+			Nickname:  firstNonEmpty(r.Name, r.Nickname),
+			RedID:     firstNonEmpty(r.RedID, r.RedID2),
+			Avatar:    firstNonEmpty(r.Image, r.Avatar),
+			Fans:      rawScalar(r.Fans),
+			NoteCount: firstNonEmpty(rawScalar(r.NoteCount), rawScalar(r.NoteCount2)),
+			Desc:      firstNonEmpty(r.SubTitle, r.Desc),
+			XsecToken: firstNonEmpty(r.XsecToken, r.XsecToken2),
+			Followed:  r.Followed,
+		}
+		if r.ProfileURL != "" {
+			id, token := parseProfileURL(r.ProfileURL)
+			u.UserID = firstNonEmpty(u.UserID, id)
+			u.XsecToken = firstNonEmpty(u.XsecToken, token)
+		}
+		if u.Fans == "" {
+			u.Fans = fansFromCardText(r.Info)
+		}
+
+		if u.UserID == "" || seen[u.UserID] {
+			continue
+		}
+		seen[u.UserID] = true
+		users = append(users, u)
+	}
+	return users
+}
+
+// parseProfileURL 从用户主页链接中解析用户 ID 和 xsec_token
+func parseProfileURL(raw string) (userID, xsecToken string) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", ""
+	}
+	const prefix = "/user/profile/"
+	idx := strings.Index(u.Path, prefix)
+	if idx < 0 {
+		return "", ""
+	}
+	userID, _, _ = strings.Cut(u.Path[idx+len(prefix):], "/")
+	return userID, u.Query().Get("xsec_token")
+}
+
+// fansFromCardText 从用户卡片文本（如 "小红书号：123 | 粉丝・1.2万"）中取出粉丝数
+func fansFromCardText(text string) string {
+	_, after, ok := strings.Cut(text, "粉丝")
+	if !ok {
+		return ""
+	}
+	after = strings.TrimLeft(after, " ·・:：")
+	end := strings.IndexFunc(after, func(r rune) bool {
+		return r == ' ' || r == '\n' || r == '|' || r == '·' || r == '・'
+	})
+	if end >= 0 {
+		after = after[:end]
+	}
+	return strings.TrimSpace(after)
+}
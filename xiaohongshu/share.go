@@ -0,0 +1,41 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+)
+
+// ShareCapabilityLink 网页端不支持转发到个人主页，分享能力仅限生成分享链接
+const ShareCapabilityLink = "share_link"
+
+// ShareAction 表示笔记分享动作
+type ShareAction struct {
+	page *rod.Page
+}
+
+// NewShareAction 创建笔记分享动作
+func NewShareAction(page *rod.Page) *ShareAction {
+	return &ShareAction{page: page}
+}
+
+// GetShareLink 确认笔记可见后返回其分享链接
+func (a *ShareAction) GetShareLink(ctx context.Context, feedID, xsecToken string) (string, error) {
+	exists, reason, err := NewFeedDetailAction(a.page).CheckFeedExists(ctx, feedID, xsecToken)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.Errorf("笔记 %s 不可分享: %s", feedID, reason)
+	}
+
+	return makeShareURL(feedID, xsecToken), nil
+}
+
+func makeShareURL(feedID, xsecToken string) string {
+	return fmt.Sprintf("https://www.xiaohongshu.com/discovery/item/%s?xsec_token=%s&xsec_source=pc_share",
+		url.PathEscape(feedID), url.QueryEscape(xsecToken))
+}
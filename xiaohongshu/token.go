@@ -0,0 +1,41 @@
+package xiaohongshu
+
+import (
+	"errors"
+
+	"github.com/go-rod/rod"
+)
+
+// ErrStaleXsecToken 详情页数据已加载但没有对应笔记，通常是 xsec_token 已过期。
+// 调用方可以重新获取 token 后重试。
+var ErrStaleXsecToken = errors.New("feed not available with the given xsec_token, token may be stale")
+
+// feedMissingExpr 判断 noteDetailMap 已加载但其中没有指定笔记
+const feedMissingExpr = `(id) => {
+	const state = window.__INITIAL_STATE__;
+	const map = state && state.note && state.note.noteDetailMap;
+	if (!map) {
+		return false;
+	}
+	const detail = map[id];
+	return !(detail && detail.note && detail.note.noteId);
+}`
+
+// isFeedMissing 页面已加载详情数据但没有指定笔记时返回 true，数据尚未加载或读取失败时返回 false
+func isFeedMissing(page *rod.Page, feedID string) bool {
+	res, err := page.Evaluate(&rod.EvalOptions{JS: feedMissingExpr, JSArgs: []interface{}{feedID}, ByValue: true})
+	if err != nil || res == nil {
+		return false
+	}
+	return res.Value.Bool()
+}
+
+// FindXsecToken 在 Feed 列表中查找指定笔记的 xsec_token，找不到时返回空字符串
+func FindXsecToken(feeds []Feed, feedID string) string {
+	for _, f := range feeds {
+		if f.ID == feedID && f.XsecToken != "" {
+			return f.XsecToken
+		}
+	}
+	return ""
+}
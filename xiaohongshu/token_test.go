@@ -0,0 +1,15 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindXsecToken(t *testing.T) {
+	feeds := []Feed{{ID: "a", XsecToken: "ta"}, {ID: "b"}, {ID: "b", XsecToken: "tb"}}
+
+	assert.Equal(t, "ta", FindXsecToken(feeds, "a"))
+	assert.Equal(t, "tb", FindXsecToken(feeds, "b"))
+	assert.Equal(t, "", FindXsecToken(feeds, "c"))
+}
@@ -2,6 +2,12 @@ package xiaohongshu
 
 // 小红书 Feed 相关的数据结构定义
 
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
 // FeedResponse 表示从 __INITIAL_STATE__ 中获取的完整 Feed 响应
 type FeedResponse struct {
 	Feed FeedData `json:"feed"`
@@ -24,16 +30,74 @@ type Feed struct {
 	ModelType string   `json:"modelType"`
 	NoteCard  NoteCard `json:"noteCard"`
 	Index     int      `json:"index"`
+
+	// 以下字段由 normalizeFeeds 从 NoteCard 派生，调用方无需了解原始字段结构
+	AuthorID   string   `json:"authorId,omitempty"`   // 作者用户ID
+	AuthorName string   `json:"authorName,omitempty"` // 作者昵称
+	CoverURL   string   `json:"coverUrl,omitempty"`   // 封面图片地址
+	Type       NoteType `json:"type,omitempty"`       // 笔记类型：image、video 或 unknown，原始值见 NoteCard.Type
+
+	// 视频笔记的时长（秒）和分辨率，图文笔记及列表中没有该信息时为 0（不输出）
+	VideoDuration int `json:"videoDuration,omitempty"`
+	VideoWidth    int `json:"videoWidth,omitempty"`
+	VideoHeight   int `json:"videoHeight,omitempty"`
+
+	// PublishedAt 发布时间，来自卡片的时间戳或 "3天前" 等发布时间角标，列表中没有时为零值（不输出）
+	PublishedAt time.Time `json:"publishedAt,omitzero"`
+}
+
+// normalize 根据原始字段填充派生字段
+func (f *Feed) normalize() {
+	if f.XsecToken == "" {
+		f.XsecToken = f.NoteCard.XsecToken
+	}
+	f.AuthorID = f.NoteCard.User.UserID
+	f.AuthorName = f.NoteCard.User.Nickname
+	if f.AuthorName == "" {
+		f.AuthorName = f.NoteCard.User.NickName
+	}
+	f.CoverURL = f.NoteCard.Cover.bestURL()
+	f.Type = ParseNoteType(f.NoteCard.Type)
+	f.VideoDuration, f.VideoWidth, f.VideoHeight = 0, 0, 0
+	if f.Type == NoteTypeVideo && f.NoteCard.Video != nil {
+		f.VideoDuration = f.NoteCard.Video.Capa.Duration
+		f.VideoWidth, f.VideoHeight = f.NoteCard.Video.resolution()
+		if f.VideoWidth == 0 || f.VideoHeight == 0 {
+			// 列表卡片通常没有视频流信息，视频封面即视频首帧，尺寸与视频一致
+			f.VideoWidth, f.VideoHeight = f.NoteCard.Cover.Width, f.NoteCard.Cover.Height
+		}
+	}
+	f.PublishedAt = f.NoteCard.publishedAt(time.Now())
+}
+
+// normalizeFeeds 为 feeds、search、profile 返回的 Feed 统一填充派生字段
+// 每个 Feed 都需要携带 xsecToken，否则无法用于详情、点赞、评论等后续操作
+func normalizeFeeds(feeds []Feed) []Feed {
+	missing := 0
+	for i := range feeds {
+		feeds[i].normalize()
+		if feeds[i].XsecToken == "" {
+			missing++
+		}
+	}
+	if missing > 0 {
+		logrus.Warnf("%d/%d 个 Feed 缺少 xsecToken，无法用于后续操作", missing, len(feeds))
+	}
+	return feeds
 }
 
 // NoteCard 表示笔记卡片信息
 type NoteCard struct {
+	XsecToken    string       `json:"xsecToken,omitempty"` // 部分页面（如用户主页）令牌位于 noteCard 内
 	Type         string       `json:"type"`
 	DisplayTitle string       `json:"displayTitle"`
 	User         User         `json:"user"`
 	InteractInfo InteractInfo `json:"interactInfo"`
 	Cover        Cover        `json:"cover"`
 	Video        *Video       `json:"video,omitempty"` // 视频内容，可能为空
+
+	Time          int64       `json:"time,omitempty"`          // 发布时间（毫秒时间戳），部分页面提供
+	CornerTagInfo []CornerTag `json:"cornerTagInfo,omitempty"` // 角标，搜索结果中包含发布时间文本
 }
 
 // User 表示用户信息
@@ -54,6 +118,8 @@ type InteractInfo struct {
 
 	CollectedCount string `json:"collectedCount"`
 	Collected      bool   `json:"collected"`
+
+	Sticky bool `json:"sticky,omitempty"` // 用户主页中的置顶笔记
 }
 
 // Cover 表示封面信息
@@ -67,6 +133,21 @@ type Cover struct {
 	InfoList   []ImageInfo `json:"infoList"`
 }
 
+// bestURL 返回可用的封面地址，优先默认尺寸
+func (c Cover) bestURL() string {
+	for _, u := range []string{c.URLDefault, c.URL, c.URLPre} {
+		if u != "" {
+			return u
+		}
+	}
+	for _, info := range c.InfoList {
+		if info.URL != "" {
+			return info.URL
+		}
+	}
+	return ""
+}
+
 // ImageInfo 表示图片信息
 type ImageInfo struct {
 	ImageScene string `json:"imageScene"`
@@ -75,7 +156,8 @@ type ImageInfo struct {
 
 // Video 表示视频信息
 type Video struct {
-	Capa VideoCapability `json:"capa"`
+	Capa  VideoCapability `json:"capa"`
+	Media *VideoMedia     `json:"media,omitempty"` // 视频流信息，部分页面提供
 }
 
 // VideoCapability 表示视频能力信息
@@ -83,12 +165,46 @@ type VideoCapability struct {
 	Duration int `json:"duration"` // 视频时长，单位秒
 }
 
+// VideoMedia 表示视频的媒体信息
+type VideoMedia struct {
+	Stream VideoStreams `json:"stream"`
+}
+
+// VideoStreams 表示各编码格式的视频流
+type VideoStreams struct {
+	H264 []VideoStream `json:"h264"`
+	H265 []VideoStream `json:"h265"`
+	AV1  []VideoStream `json:"av1"`
+}
+
+// VideoStream 表示单个视频流
+type VideoStream struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// resolution 返回视频流中最大的分辨率，没有视频流信息时返回 0, 0
+func (v *Video) resolution() (width, height int) {
+	if v.Media == nil {
+		return 0, 0
+	}
+	for _, streams := range [][]VideoStream{v.Media.Stream.H264, v.Media.Stream.H265, v.Media.Stream.AV1} {
+		for _, st := range streams {
+			if st.Width*st.Height > width*height {
+				width, height = st.Width, st.Height
+			}
+		}
+	}
+	return width, height
+}
+
 // ================ Feed 详情页相关结构体 ================
 
 // FeedDetailResponse 表示 Feed 详情页完整响应
 type FeedDetailResponse struct {
 	Note     FeedDetail  `json:"note"`
 	Comments CommentList `json:"comments"`
+	Tags     []string    `json:"tags"` // 笔记的话题标签，不含 #，没有标签时为空数组
 }
 
 // FeedDetail 表示详情页的笔记内容
@@ -103,6 +219,14 @@ type FeedDetail struct {
 	User         User              `json:"user"`
 	InteractInfo InteractInfo      `json:"interactInfo"`
 	ImageList    []DetailImageInfo `json:"imageList"`
+	TagList      []NoteTag         `json:"tagList"`
+}
+
+// NoteTag 表示笔记关联的话题标签
+type NoteTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
 }
 
 // DetailImageInfo 表示详情页的图片信息
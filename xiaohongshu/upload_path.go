@@ -0,0 +1,60 @@
+package xiaohongshu
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// ErrUploadPathNotAllowed 配置了上传根目录（-upload_root）时，本地文件不在该目录下
+var ErrUploadPathNotAllowed = errors.New("local file is outside the allowed upload root")
+
+// CheckUploadPath 配置了上传根目录时，检查本地图片或视频路径是否位于该目录或 extraDirs 下
+// （如服务自己保存图片的账号目录），都不在时返回 ErrUploadPathNotAllowed。未配置时不做限制。
+func CheckUploadPath(path string, extraDirs ...string) error {
+	root := configs.GetUploadRoot()
+	if root == "" {
+		return nil
+	}
+
+	ok, err := pathWithinRoot(path, root)
+	if err != nil {
+		return errors.Wrapf(err, "无法读取本地文件: %s", path)
+	}
+	for _, dir := range extraDirs {
+		if ok {
+			break
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+				ok, _ = pathWithinRoot(path, resolved)
+			}
+		}
+	}
+	if !ok {
+		return errors.Wrapf(ErrUploadPathNotAllowed, "path %q", path)
+	}
+	return nil
+}
+
+// pathWithinRoot 判断 path 解析符号链接后是否位于 root 下，root 需为已解析的绝对路径。
+// 通过 .. 或符号链接指向 root 以外的文件都视为不在 root 下。
+func pathWithinRoot(path, root string) (bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false, err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return false, err
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil {
+		return false, nil
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) && !filepath.IsAbs(rel), nil
+}
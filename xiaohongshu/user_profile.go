@@ -7,8 +7,14 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
+const userProfileReadyExpr = `() => {
+	const state = window.__INITIAL_STATE__;
+	return !!(state && state.user && state.user.userPageData);
+}`
+
 type UserProfileAction struct {
 	page *rod.Page
 }
@@ -22,37 +28,29 @@ func NewUserProfileAction(page *rod.Page) *UserProfileAction {
 func (u *UserProfileAction) UserProfile(ctx context.Context, userID, xsecToken string) (*UserProfileResponse, error) {
 	page := u.page.Context(ctx)
 
-	searchURL := makeUserProfileURL(userID, xsecToken)
-	if err := page.Navigate(searchURL); err != nil {
-		return nil, err
-	}
-
-	if err := waitForInitialState(page, `() => {
-		const state = window.__INITIAL_STATE__;
-		return !!(state && state.user && state.user.userPageData);
-	}`, 30*time.Second); err != nil {
+	jsonStr, err := navigateAndWaitState(ctx, page, configs.WaitActionUserProfile, makeUserProfileURL(userID, xsecToken), userProfileReadyExpr, 30*time.Second,
+		onStateWaitFailed(func() error {
+			return checkRegionBlocked(page, RegionBlockedUser, userID)
+		}))
+	if err != nil {
 		return nil, err
 	}
 
-	// 获取 window.__INITIAL_STATE__ 并转换为 JSON 字符串
-	result, err := page.Evaluate(&rod.EvalOptions{JS: `() => {
-		if (window.__INITIAL_STATE__) {
-			return JSON.stringify(window.__INITIAL_STATE__);
-		}
-		return "";
-	}`, ByValue: true})
+	profile, err := parseUserProfile(jsonStr)
 	if err != nil {
 		return nil, err
 	}
-	if result == nil {
-		return nil, fmt.Errorf("failed to evaluate user profile initial state")
+	// 主页数据为空时检查是否受地区限制
+	if profile.UserBasicInfo.Nickname == "" && len(profile.Feeds) == 0 {
+		if blocked := checkRegionBlocked(page, RegionBlockedUser, userID); blocked != nil {
+			return nil, blocked
+		}
 	}
+	return profile, nil
+}
 
-	jsonStr := result.Value.Str()
-
-	if jsonStr == "" {
-		return nil, fmt.Errorf("__INITIAL_STATE__ not found")
-	}
+// parseUserProfile 从用户主页的 __INITIAL_STATE__ JSON 中解析用户信息和帖子
+func parseUserProfile(jsonStr string) (*UserProfileResponse, error) {
 	// 定义响应结构并直接反序列化
 	var initialState = struct {
 		User struct {
@@ -75,11 +73,15 @@ func (u *UserProfileAction) UserProfile(ctx context.Context, userID, xsecToken s
 			response.Feeds = append(response.Feeds, feeds...)
 		}
 	}
+	response.Feeds = normalizeFeeds(response.Feeds)
 
 	return response, nil
-
 }
 
+// makeUserProfileURL 生成用户主页地址，xsecToken 为空时（如打开自己的主页）省略 token 参数
 func makeUserProfileURL(userID, xsecToken string) string {
+	if xsecToken == "" {
+		return fmt.Sprintf("https://www.xiaohongshu.com/user/profile/%s", userID)
+	}
 	return fmt.Sprintf("https://www.xiaohongshu.com/user/profile/%s?xsec_token=%s&xsec_source=pc_note", userID, xsecToken)
 }
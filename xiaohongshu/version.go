@@ -0,0 +1,5 @@
+package xiaohongshu
+
+// SiteAdaptationVersion 站点适配（页面选择器、交互流程）的版本。
+// 为适配站点改版调整选择器或页面流程时递增，客户端可据此判断服务端适配的站点版本。
+const SiteAdaptationVersion = 1
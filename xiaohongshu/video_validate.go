@@ -0,0 +1,145 @@
+package xiaohongshu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// supportedVideoExts 支持上传的视频格式
+var supportedVideoExts = map[string]bool{
+	".mp4": true,
+	".mov": true,
+}
+
+// ValidateVideoFile 上传前校验视频的格式、大小和时长，限制由 configs 配置。
+// 无法解析时长时只记录警告，交由平台处理。
+func ValidateVideoFile(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !supportedVideoExts[ext] {
+		return errors.Errorf("不支持的视频格式 %q，仅支持 mp4、mov", ext)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "视频文件不存在: %s", path)
+	}
+	if info.IsDir() {
+		return errors.Errorf("视频路径是目录: %s", path)
+	}
+	if maxSize := configs.GetVideoMaxSize(); maxSize > 0 && info.Size() > maxSize {
+		return errors.Errorf("视频文件过大: %d MB，上限 %d MB", info.Size()>>20, maxSize>>20)
+	}
+
+	maxDuration := configs.GetVideoMaxDuration()
+	if maxDuration <= 0 {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "打开视频文件失败")
+	}
+	defer f.Close()
+
+	duration, err := mp4Duration(f)
+	if err != nil {
+		logrus.Warnf("无法解析视频时长，跳过时长校验: %v", err)
+		return nil
+	}
+	if duration > maxDuration {
+		return errors.Errorf("视频时长 %s 超过上限 %s", duration.Round(time.Second), maxDuration)
+	}
+
+	return nil
+}
+
+// mp4Duration 从 mp4/mov 的 moov/mvhd box 中读取视频时长
+func mp4Duration(r io.ReadSeeker) (time.Duration, error) {
+	moovSize, err := findBox(r, "moov", -1)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := findBox(r, "mvhd", moovSize); err != nil {
+		return 0, err
+	}
+
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+
+	var timescale uint32
+	var duration uint64
+	if header[0] == 1 {
+		// version 1: 创建/修改时间各 8 字节，时长 8 字节
+		var buf [28]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, err
+		}
+		timescale = binary.BigEndian.Uint32(buf[16:20])
+		duration = binary.BigEndian.Uint64(buf[20:28])
+	} else {
+		var buf [16]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, err
+		}
+		timescale = binary.BigEndian.Uint32(buf[8:12])
+		duration = uint64(binary.BigEndian.Uint32(buf[12:16]))
+	}
+	if timescale == 0 {
+		return 0, errors.New("invalid mvhd timescale")
+	}
+
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second)), nil
+}
+
+// findBox 在当前位置开始的 limit 字节内（-1 表示直到文件末尾）查找指定类型的 box，
+// 找到后 r 定位在 box 内容起始处，返回内容长度（-1 表示延伸到文件末尾）
+func findBox(r io.ReadSeeker, boxType string, limit int64) (int64, error) {
+	var consumed int64
+	for limit < 0 || consumed < limit {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return 0, fmt.Errorf("box %s not found: %w", boxType, err)
+		}
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+
+		switch size {
+		case 1:
+			var ext [8]byte
+			if _, err := io.ReadFull(r, ext[:]); err != nil {
+				return 0, err
+			}
+			size = int64(binary.BigEndian.Uint64(ext[:]))
+			headerSize = 16
+		case 0:
+			// box 延伸到文件末尾
+			if string(header[4:]) == boxType {
+				return -1, nil
+			}
+			return 0, fmt.Errorf("box %s not found", boxType)
+		}
+		if size < headerSize {
+			return 0, fmt.Errorf("invalid box size %d", size)
+		}
+
+		if string(header[4:]) == boxType {
+			return size - headerSize, nil
+		}
+		if _, err := r.Seek(size-headerSize, io.SeekCurrent); err != nil {
+			return 0, err
+		}
+		consumed += size
+	}
+	return 0, fmt.Errorf("box %s not found", boxType)
+}
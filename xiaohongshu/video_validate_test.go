@@ -0,0 +1,61 @@
+package xiaohongshu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+func makeBox(boxType string, payload []byte) []byte {
+	buf := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(buf[:4], uint32(8+len(payload)))
+	copy(buf[4:], boxType)
+	return append(buf, payload...)
+}
+
+// makeMP4 构造只包含 ftyp 和 moov/mvhd(version 0) 的最小 mp4
+func makeMP4(timescale, duration uint32) []byte {
+	mvhd := make([]byte, 20)
+	binary.BigEndian.PutUint32(mvhd[12:16], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:20], duration)
+
+	moov := makeBox("moov", append(makeBox("udta", nil), makeBox("mvhd", mvhd)...))
+	return append(makeBox("ftyp", []byte("isom")), moov...)
+}
+
+func TestMP4Duration(t *testing.T) {
+	d, err := mp4Duration(bytes.NewReader(makeMP4(1000, 90500)))
+	require.NoError(t, err)
+	assert.Equal(t, 90500*time.Millisecond, d)
+
+	_, err = mp4Duration(bytes.NewReader(makeBox("ftyp", []byte("isom"))))
+	assert.Error(t, err)
+}
+
+func TestValidateVideoFile(t *testing.T) {
+	defer configs.SetVideoLimits(configs.GetVideoMaxSize(), configs.GetVideoMaxDuration())
+	configs.SetVideoLimits(1<<20, time.Minute)
+
+	dir := t.TempDir()
+	short := filepath.Join(dir, "short.mp4")
+	long := filepath.Join(dir, "long.MOV")
+	avi := filepath.Join(dir, "clip.avi")
+	require.NoError(t, os.WriteFile(short, makeMP4(600, 600*30), 0o644))
+	require.NoError(t, os.WriteFile(long, makeMP4(600, 600*120), 0o644))
+	require.NoError(t, os.WriteFile(avi, []byte("avi"), 0o644))
+
+	assert.NoError(t, ValidateVideoFile(short))
+	assert.Error(t, ValidateVideoFile(long))
+	assert.Error(t, ValidateVideoFile(avi))
+	assert.Error(t, ValidateVideoFile(filepath.Join(dir, "missing.mp4")))
+
+	configs.SetVideoLimits(10, 0)
+	assert.Error(t, ValidateVideoFile(short))
+}
@@ -5,10 +5,18 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
+// errInitialStateNotFound 页面已加载但 window.__INITIAL_STATE__ 尚未注入
+var errInitialStateNotFound = errors.New("__INITIAL_STATE__ not found")
+
 func waitForInitialState(page *rod.Page, expr string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	// 以页面自身的 context 为父 context，请求被取消或超时后立即停止等待
+	ctx, cancel := context.WithTimeout(page.GetContext(), timeout)
 	defer cancel()
 
 	ticker := time.NewTicker(500 * time.Millisecond)
@@ -17,6 +25,12 @@ func waitForInitialState(page *rod.Page, expr string, timeout time.Duration) err
 	for {
 		select {
 		case <-ctx.Done():
+			// 等待超时时检查是否被完善资料弹窗挡住，给出明确的错误
+			if page.GetContext().Err() == nil {
+				if err := dismissProfileSetup(page); err != nil {
+					return err
+				}
+			}
 			return ctx.Err()
 		case <-ticker.C:
 			res, err := page.Evaluate(&rod.EvalOptions{JS: expr, ByValue: true})
@@ -35,3 +49,198 @@ func waitForInitialState(page *rod.Page, expr string, timeout time.Duration) err
 		}
 	}
 }
+
+// evalInitialState 获取 window.__INITIAL_STATE__ 并转换为 JSON 字符串
+func evalInitialState(page *rod.Page) (string, error) {
+	result, err := page.Evaluate(&rod.EvalOptions{JS: `() => {
+		if (window.__INITIAL_STATE__) {
+			return JSON.stringify(window.__INITIAL_STATE__);
+		}
+		return "";
+	}`, ByValue: true})
+	if err != nil {
+		return "", err
+	}
+	if result == nil {
+		return "", errors.New("failed to evaluate initial state")
+	}
+
+	jsonStr := result.Value.Str()
+	if jsonStr == "" {
+		return "", errInitialStateNotFound
+	}
+
+	return jsonStr, nil
+}
+
+// loadInitialState 等待 readyExpr 为真（每次最长 timeout）后执行 ready（可为 nil），再读取 __INITIAL_STATE__。
+// 页面水合竞态时 __INITIAL_STATE__ 一直没有注入，表现为等待超时或读取为空：此时刷新页面重新等待，
+// 最多重试 configs.GetInitialStateRetries() 次。__INITIAL_STATE__ 已存在但 readyExpr 不满足时不重试。
+// waitFailed（可为 nil）在等待失败时给出更明确的原因（如登录跳转、验证码），返回非 nil 时不再重试。
+// 整体耗时仍受 page 自身的超时约束。
+func loadInitialState(page *rod.Page, readyExpr string, timeout time.Duration, ready func() error, waitFailed func() error) (string, error) {
+	retries := configs.GetInitialStateRetries()
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			logrus.Warnf("__INITIAL_STATE__ 为空，刷新页面重试 (%d/%d)", attempt, retries)
+			if err := page.Reload(); err != nil {
+				return "", errors.Wrap(err, "reload page failed")
+			}
+		}
+
+		waitErr := waitForInitialState(page, readyExpr, timeout)
+		if waitErr == nil {
+			if ready != nil {
+				if err := ready(); err != nil {
+					return "", err
+				}
+			}
+			jsonStr, err := evalInitialState(page)
+			if errors.Is(err, errInitialStateNotFound) && attempt < retries {
+				continue
+			}
+			return jsonStr, err
+		}
+
+		if waitFailed != nil {
+			if reason := waitFailed(); reason != nil {
+				return "", reason
+			}
+		}
+		if !errors.Is(waitErr, context.DeadlineExceeded) || page.GetContext().Err() != nil ||
+			attempt >= retries || !initialStateMissing(page) {
+			return "", waitErr
+		}
+	}
+}
+
+// initialStateMissing 页面上是否还没有 __INITIAL_STATE__
+func initialStateMissing(page *rod.Page) bool {
+	_, err := evalInitialState(page)
+	return errors.Is(err, errInitialStateNotFound)
+}
+
+// 各动作在 selector 等待策略下等待出现的关键元素
+var waitSelectors = map[string]string{
+	configs.WaitActionFeeds:       "div#app .feeds-container",
+	configs.WaitActionSearch:      "div#app .feeds-container",
+	configs.WaitActionFeedDetail:  "#noteContainer",
+	configs.WaitActionUserProfile: "div#app .user-info",
+	configs.WaitActionComment:     "div.input-box div.content-edit",
+	configs.WaitActionInteract:    ".interact-container",
+}
+
+// navigateAndWait 导航到 url，并按 configs.GetWaitStrategy(action) 配置的策略等待页面就绪。
+// 默认策略只做导航，由调用方沿用原有的等待逻辑。
+// 页面出现完善资料弹窗时尝试跳过，无法跳过时返回 ErrProfileSetupRequired。
+func navigateAndWait(page *rod.Page, action, url string) error {
+	strategy := configs.GetWaitStrategy(action)
+
+	// 网络空闲需要在导航前开始监听请求
+	var waitIdle func()
+	if strategy == configs.WaitNetworkIdle {
+		waitIdle = page.WaitRequestIdle(500*time.Millisecond, nil, nil,
+			[]proto.NetworkResourceType{proto.NetworkResourceTypeWebSocket, proto.NetworkResourceTypeEventSource})
+	}
+
+	if err := page.Navigate(url); err != nil {
+		return err
+	}
+
+	switch strategy {
+	case configs.WaitDOMStable:
+		if err := page.WaitDOMStable(time.Second, 0); err != nil {
+			return errors.Wrap(err, "wait dom stable failed")
+		}
+	case configs.WaitNetworkIdle:
+		waitIdle()
+		if err := page.GetContext().Err(); err != nil {
+			return errors.Wrap(err, "wait network idle failed")
+		}
+	case configs.WaitSelector:
+		if _, err := page.Element(waitSelectors[action]); err != nil {
+			return errors.Wrapf(err, "wait selector %s failed", waitSelectors[action])
+		}
+	}
+
+	// 出现验证码时等待完成验证，无法等待时返回 ErrCaptchaRequired
+	if err := handleCaptcha(page); err != nil {
+		return err
+	}
+
+	// 登录状态失效时页面会被重定向到登录页
+	if err := checkLoginRedirect(page); err != nil {
+		return err
+	}
+
+	// 新账号可能弹出完善资料弹窗挡住页面
+	return dismissProfileSetup(page)
+}
+
+// stateOptions navigateAndWaitState 的可选步骤
+type stateOptions struct {
+	afterNavigate func() error // 导航后、等待状态前执行
+	prepare       func() error // 状态就绪后执行，刷新页面重试后会再次执行
+	onWaitFailed  func() error // 等待状态失败时执行，返回非 nil 时替代原错误
+}
+
+type stateOption func(*stateOptions)
+
+// afterNavigate 导航完成后、等待状态就绪前执行 fn，如关闭挡住内容的弹窗
+func afterNavigate(fn func() error) stateOption {
+	return func(o *stateOptions) {
+		o.afterNavigate = fn
+	}
+}
+
+// prepareState 状态就绪后执行 fn（如应用筛选条件），__INITIAL_STATE__ 为空刷新页面后会再次执行
+func prepareState(fn func() error) stateOption {
+	return func(o *stateOptions) {
+		o.prepare = fn
+	}
+}
+
+// onStateWaitFailed 等待状态就绪失败时调用 fn 检查更明确的原因（如地区限制），fn 返回 nil 时使用原错误
+func onStateWaitFailed(fn func() error) stateOption {
+	return func(o *stateOptions) {
+		o.onWaitFailed = fn
+	}
+}
+
+// navigateAndWaitState 以 ctx 作为页面的 context（需保留页面超时时传入 page.GetContext()）导航到 url，按 action 的等待策略等待页面就绪并检查登录状态，
+// 再等待 readyExpr 为真（最长 timeout），返回 __INITIAL_STATE__ 的 JSON 字符串。
+// 等待超时时先检查是否被重定向到登录页或出现验证码，__INITIAL_STATE__ 未注入时按 loadInitialState 刷新重试。
+func navigateAndWaitState(ctx context.Context, page *rod.Page, action, url, readyExpr string, timeout time.Duration, opts ...stateOption) (string, error) {
+	var o stateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	page = page.Context(ctx)
+	if err := navigateAndWait(page, action, url); err != nil {
+		return "", err
+	}
+	if o.afterNavigate != nil {
+		if err := o.afterNavigate(); err != nil {
+			return "", err
+		}
+	}
+
+	// 部分页面在加载数据时才重定向到登录页或弹出验证码
+	waitFailed := func() error {
+		if captcha := detectCaptcha(page); captcha != nil {
+			logrus.Warnf("页面出现%s验证码: %s", captcha.Type, captcha.PageURL)
+			return captcha
+		}
+		if redirected := checkLoginRedirect(page); redirected != nil {
+			return redirected
+		}
+		if o.onWaitFailed != nil {
+			return o.onWaitFailed()
+		}
+		return nil
+	}
+
+	return loadInitialState(page, readyExpr, timeout, o.prepare, waitFailed)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/accounts"
+	"github.com/xpzouying/xiaohongshu-mcp/browser"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// ErrXsecTokenNotFound 只提供笔记 ID 时未能找到可用的 xsec_token
+var ErrXsecTokenNotFound = errors.New("xsec_token not found")
+
+// maxCachedTokensPerAccount 每个账号最多缓存的 xsec_token 数量，超出后清空重新累积
+const maxCachedTokensPerAccount = 2000
+
+// xsecTokenCache 记录各账号在推荐列表、搜索、用户主页结果中见过的最新 xsec_token，
+// 用于在调用方传入的 token 过期时重新获取
+type xsecTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]map[string]string // accountID -> feedID -> xsecToken
+}
+
+func newXsecTokenCache() *xsecTokenCache {
+	return &xsecTokenCache{tokens: make(map[string]map[string]string)}
+}
+
+func (c *xsecTokenCache) remember(accountID string, feeds []xiaohongshu.Feed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tokens := c.tokens[accountID]
+	if tokens == nil || len(tokens) >= maxCachedTokensPerAccount {
+		tokens = make(map[string]string)
+		c.tokens[accountID] = tokens
+	}
+	for _, f := range feeds {
+		if f.ID != "" && f.XsecToken != "" {
+			tokens[f.ID] = f.XsecToken
+		}
+	}
+}
+
+func (c *xsecTokenCache) lookup(accountID, feedID string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.tokens[accountID][feedID]
+}
+
+// withTokenRefresh 使用 xsecToken 执行 fn，若因 token 过期失败，则重新获取 token 后重试一次；
+// 登录状态失效时通过 withLoginRetry 重新加载 cookies 后重试。
+// 找不到新 token 时返回明确的错误，提示调用方重新从列表或搜索结果获取。
+func (s *XiaohongshuService) withTokenRefresh(ctx context.Context, accountID string, b *browser.Browser, feedID, xsecToken string, fn func(token string) error) error {
+	// 登录状态失效时先尝试重新加载 cookies
+	call := func(token string) error {
+		return s.withLoginRetry(accountID, b, func() error { return fn(token) })
+	}
+
+	err := call(xsecToken)
+	if !errors.Is(err, xiaohongshu.ErrStaleXsecToken) {
+		return err
+	}
+
+	log := logrus.WithField("account", accounts.DisplayName(accountID))
+	log.Warnf("笔记 %s 的 xsec_token 可能已过期，尝试重新获取", feedID)
+
+	fresh := s.resolveXsecToken(ctx, accountID, b, feedID, xsecToken)
+	if fresh == "" {
+		return fmt.Errorf("未能找到笔记 %s 的新 xsec_token，请重新从推荐列表或搜索结果获取: %w", feedID, err)
+	}
+
+	log.Infof("已刷新笔记 %s 的 xsec_token，重试一次", feedID)
+	return call(fresh)
+}
+
+// resolveXsecToken 依次从缓存和推荐列表中查找与 stale 不同的 token，找不到时返回空字符串
+func (s *XiaohongshuService) resolveXsecToken(ctx context.Context, accountID string, b *browser.Browser, feedID, stale string) string {
+	if token := s.tokens.lookup(accountID, feedID); token != "" && token != stale {
+		return token
+	}
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	action, err := xiaohongshu.NewFeedsListAction(page)
+	if err != nil {
+		logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("打开推荐列表失败，无法刷新 xsec_token: %v", err)
+		return ""
+	}
+	feeds, err := action.GetFeedsList(ctx)
+	if err != nil {
+		logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("获取推荐列表失败，无法刷新 xsec_token: %v", err)
+		return ""
+	}
+	s.tokens.remember(accountID, feeds)
+
+	if token := xiaohongshu.FindXsecToken(feeds, feedID); token != stale {
+		return token
+	}
+	return ""
+}
+
+// withResolvedToken 调用方未提供 xsec_token 时，依次从缓存、推荐列表、keyword 搜索结果中查找 token
+// 后再执行 withTokenRefresh；都找不到时以空 token 直接打开一次，仍失败则返回 ErrXsecTokenNotFound
+func (s *XiaohongshuService) withResolvedToken(ctx context.Context, accountID string, b *browser.Browser, feedID, xsecToken, keyword string, fn func(token string) error) error {
+	if xsecToken != "" {
+		return s.withTokenRefresh(ctx, accountID, b, feedID, xsecToken, fn)
+	}
+
+	log := logrus.WithField("account", accounts.DisplayName(accountID))
+	if token := s.findXsecToken(ctx, accountID, b, feedID, keyword); token != "" {
+		log.Infof("已找到笔记 %s 的 xsec_token", feedID)
+		return s.withTokenRefresh(ctx, accountID, b, feedID, token, fn)
+	}
+
+	log.Warnf("未找到笔记 %s 的 xsec_token，尝试不带 token 打开", feedID)
+	err := s.withLoginRetry(accountID, b, func() error { return fn("") })
+	if errors.Is(err, xiaohongshu.ErrStaleXsecToken) {
+		return fmt.Errorf("%w: 笔记 %s 不在缓存、推荐列表或搜索结果中，请提供 xsec_token，或提供笔记标题等 keyword 以便搜索", ErrXsecTokenNotFound, feedID)
+	}
+	return err
+}
+
+// findXsecToken 只有笔记 ID 时查找 xsec_token：先查缓存和推荐列表，keyword 非空时再查搜索结果，
+// 找不到时返回空字符串
+func (s *XiaohongshuService) findXsecToken(ctx context.Context, accountID string, b *browser.Browser, feedID, keyword string) string {
+	if token := s.resolveXsecToken(ctx, accountID, b, feedID, ""); token != "" {
+		return token
+	}
+	if keyword == "" {
+		return ""
+	}
+
+	page := b.NewPage().Context(ctx)
+	defer page.Close()
+
+	var feeds []xiaohongshu.Feed
+	if err := s.withLoginRetry(accountID, b, func() (err error) {
+		feeds, err = xiaohongshu.NewSearchAction(page).Search(ctx, keyword, nil)
+		return err
+	}); err != nil {
+		logrus.WithField("account", accounts.DisplayName(accountID)).Warnf("搜索 %q 失败，无法获取 xsec_token: %v", keyword, err)
+		return ""
+	}
+	s.tokens.remember(accountID, feeds)
+
+	return xiaohongshu.FindXsecToken(feeds, feedID)
+}